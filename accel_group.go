@@ -1,7 +1,7 @@
 package ctk
 
 import (
-	"fmt"
+	"sort"
 
 	"github.com/kckrinke/go-cdk"
 )
@@ -32,7 +32,7 @@ type AccelGroup interface {
 
 	Init() (already bool)
 	AccelConnect(accelKey cdk.Key, accelMods cdk.ModMask, accelFlags AccelFlags, closure GClosure) (id int)
-	ConnectByPath(accelPath string, closure GClosure)
+	ConnectByPath(accelPath string, closure GClosure) (id int)
 	AccelGroupActivate(acceleratable Object, keyval cdk.Key, modifier cdk.ModMask) (activated bool)
 	AccelDisconnect(id int) (removed bool)
 	DisconnectKey(accelKey cdk.Key, accelMods cdk.ModMask) (removed bool)
@@ -87,7 +87,7 @@ func (a *CAccelGroup) Init() (already bool) {
 	a.entries = make(map[int]*AccelGroupEntry, 0)
 	a.locking = 0
 	_ = a.InstallProperty(PropertyIsLocked, cdk.BoolProperty, false, false)
-	_ = a.InstallProperty(PropertyModifierMask, cdk.StructProperty, false, nil)
+	_ = a.InstallProperty(PropertyModifierMask, cdk.StructProperty, false, accelDefaultModMask)
 	return false
 }
 
@@ -103,7 +103,14 @@ func (a *CAccelGroup) Init() (already bool) {
 // 	accelMods	modifier combination of the accelerator
 // 	accelFlags	a flag mask to configure this accelerator
 // 	closure	closure to be executed upon accelerator activation
+// Returns:
+// 	the id of the connection, or -1 if the accelerator group is locked
 func (a *CAccelGroup) AccelConnect(accelKey cdk.Key, accelMods cdk.ModMask, accelFlags AccelFlags, closure GClosure) (id int) {
+	if a.GetIsLocked() {
+		a.LogError("cannot connect %v, accel group is locked", accelName(accelKey, accelMods))
+		return -1
+	}
+	accelMods &= accelDefaultModMask
 	key := MakeAccelKey(accelKey, accelMods, accelFlags)
 	age := NewAccelGroupEntry(key, closure, cdk.QuarkFromString(accelMods.String()))
 	next := 0
@@ -114,25 +121,80 @@ func (a *CAccelGroup) AccelConnect(accelKey cdk.Key, accelMods cdk.ModMask, acce
 	}
 	a.entries[next] = age
 	id = next
+	a.Emit(SignalAccelChanged, a, accelKey, accelMods, closure)
 	return
 }
 
 // Installs an accelerator in this group, using an accelerator path to look
-// up the appropriate key and modifiers (see AccelMapAddEntry). When
+// up the appropriate key and modifiers (see AccelMap.AddEntry). When
 // accel_group is being activated in response to a call to
 // AccelGroupsActivate, closure will be invoked if the accel_key and
 // accel_mods from AccelGroupsActivate match the key and modifiers
 // for the path. The signature used for the closure is that of
-// AccelGroupActivate. Note that accel_path string will be stored in a
-// GQuark. Therefore, if you pass a static string, you can save some memory
-// by interning it first with g_intern_static_string.
+// AccelGroupActivate. The accelerator follows the changes made to the path
+// with AccelMap.ChangeEntry, paths not known to the AccelMap yet are
+// connected without an accelerator until they are added.
 // Parameters:
 // 	accelGroup	the accelerator group to install an accelerator in
 // 	accelPath	path used for determining key and modifiers.
 // 	closure	closure to be executed upon accelerator activation
-func (a *CAccelGroup) ConnectByPath(accelPath string, closure GClosure) {}
+// Returns:
+// 	the id of the connection, or -1 if the accelerator group is locked
+func (a *CAccelGroup) ConnectByPath(accelPath string, closure GClosure) (id int) {
+	accelMap := getAccelMap()
+	key, _ := accelMap.LookupEntry(accelPath)
+	if id = a.AccelConnect(key.Key, key.Mods, ACCEL_VISIBLE, closure); id >= 0 {
+		a.entries[id].Path = accelPath
+		accelMap.watchPath(accelPath, a)
+	}
+	return
+}
+
+// update the accelerators connected to the accelerator path
+func (a *CAccelGroup) accelPathChanged(accelPath string, accelKey cdk.Key, accelMods cdk.ModMask) {
+	for _, id := range a.sortedIds() {
+		if entry := a.entries[id]; entry.Path == accelPath {
+			entry.Accelerator.Key, entry.Accelerator.Mods = accelKey, accelMods
+			a.Emit(SignalAccelChanged, a, accelKey, accelMods, entry.Closure)
+		}
+	}
+}
 
+// returns TRUE if an accelerator is connected to the accelerator path
+func (a *CAccelGroup) hasPath(accelPath string) bool {
+	for _, entry := range a.entries {
+		if entry.Path == accelPath {
+			return true
+		}
+	}
+	return false
+}
+
+// Activates the accelerators of this group matching keyval and modifier ,
+// with modifiers outside of the default mod mask ignored, emitting the
+// accel-activate signal first. Handlers of the signal returning EVENT_STOP
+// prevent the accelerators from being activated. Each closure connected to
+// the accelerator is invoked until one returns TRUE.
+// Parameters:
+// 	acceleratable	the Object, usually a Window, on which to activate the
+// accelerator
+// 	keyval	accelerator keyval from a key event
+// 	modifier	keyboard state mask from a key event
+// Returns:
+// 	TRUE if an accelerator was activated and handled the keypress
 func (a *CAccelGroup) AccelGroupActivate(acceleratable Object, keyval cdk.Key, modifier cdk.ModMask) (activated bool) {
+	if keyval == 0 {
+		return false
+	}
+	modifier &= accelDefaultModMask
+	if f := a.Emit(SignalAccelActivate, a, acceleratable, keyval, modifier); f == cdk.EVENT_STOP {
+		return false
+	}
+	for _, entry := range a.Query(keyval, modifier) {
+		if entry.Closure != nil && entry.Closure(acceleratable, keyval, modifier, entry.Accelerator.Flags) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -143,9 +205,15 @@ func (a *CAccelGroup) AccelGroupActivate(acceleratable Object, keyval cdk.Key, m
 // 	closure	the closure to remove from this accelerator group, or NULL
 // to remove all closures.
 // 	returns	TRUE if the closure was found and got disconnected
+// Accelerators can not be removed while the group is locked, nor can those
+// connected with the ACCEL_LOCKED flag.
 func (a *CAccelGroup) AccelDisconnect(id int) (removed bool) {
-	if _, ok := a.entries[id]; ok {
+	if entry, ok := a.entries[id]; ok && a.canRemove(entry) {
 		delete(a.entries, id)
+		if entry.Path != "" && !a.hasPath(entry.Path) {
+			getAccelMap().unwatchPath(entry.Path, a)
+		}
+		a.Emit(SignalAccelChanged, a, entry.Accelerator.Key, entry.Accelerator.Mods, entry.Closure)
 		return true
 	}
 	return false
@@ -160,10 +228,10 @@ func (a *CAccelGroup) AccelDisconnect(id int) (removed bool) {
 // 	returns	TRUE if there was an accelerator which could be
 // removed, FALSE otherwise
 func (a *CAccelGroup) DisconnectKey(accelKey cdk.Key, accelMods cdk.ModMask) (removed bool) {
-	for id, entry := range a.entries {
-		if entry.Accelerator.Key == accelKey {
-			if entry.Accelerator.Mods == accelMods {
-				delete(a.entries, id)
+	accelMods &= accelDefaultModMask
+	for _, id := range a.sortedIds() {
+		if entry := a.entries[id]; entry.Accelerator.Key == accelKey && entry.Accelerator.Mods == accelMods {
+			if a.AccelDisconnect(id) {
 				return true
 			}
 		}
@@ -171,6 +239,29 @@ func (a *CAccelGroup) DisconnectKey(accelKey cdk.Key, accelMods cdk.ModMask) (re
 	return false
 }
 
+// accelerators can be removed when the group is not locked and the
+// accelerator was not connected with the ACCEL_LOCKED flag
+func (a *CAccelGroup) canRemove(entry *AccelGroupEntry) bool {
+	if a.GetIsLocked() {
+		a.LogError("cannot disconnect %v, accel group is locked", entry.Accelerator)
+		return false
+	}
+	if entry.Accelerator.Flags&ACCEL_LOCKED != 0 {
+		a.LogError("cannot disconnect %v, accelerator is locked", entry.Accelerator)
+		return false
+	}
+	return true
+}
+
+// returns the ids of all entries, in the order they were connected
+func (a *CAccelGroup) sortedIds() (ids []int) {
+	for id := range a.entries {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return
+}
+
 // Queries an accelerator group for all entries matching accel_key and
 // accel_mods .
 // Parameters:
@@ -181,8 +272,9 @@ func (a *CAccelGroup) DisconnectKey(accelKey cdk.Key, accelMods cdk.ModMask) (re
 // 	returns	an array of n_entries
 // AccelGroupEntry elements, or NULL. The array is owned by CTK and must not be freed.
 func (a *CAccelGroup) Query(accelKey cdk.Key, accelMods cdk.ModMask) (entries []*AccelGroupEntry) {
-	for _, entry := range a.entries {
-		if entry.Accelerator.Key == accelKey && entry.Accelerator.Mods == accelMods {
+	accelMods &= accelDefaultModMask
+	for _, id := range a.sortedIds() {
+		if entry := a.entries[id]; entry.Accelerator.Key == accelKey && entry.Accelerator.Mods == accelMods {
 			entries = append(entries, entry)
 		}
 	}
@@ -200,9 +292,9 @@ func (a *CAccelGroup) Query(accelKey cdk.Key, accelMods cdk.ModMask) (entries []
 // Returns:
 // 	TRUE if an accelerator was activated and handled this keypress
 func (a *CAccelGroup) Activate(accelQuark cdk.QuarkID, acceleratable Object, accelKey cdk.Key, accelMods cdk.ModMask) (value bool) {
-	for _, entry := range a.entries {
-		if entry.Accelerator.Key == accelKey && entry.Accelerator.Mods == accelMods {
-			return entry.Closure(acceleratable, accelKey, accelMods, entry.Accelerator.Flags)
+	for _, entry := range a.Query(accelKey, accelMods) {
+		if entry.Closure != nil {
+			return entry.Closure(acceleratable, accelKey, accelMods&accelDefaultModMask, entry.Accelerator.Flags)
 		}
 	}
 	return false
@@ -220,8 +312,10 @@ func (a *CAccelGroup) Lock() {
 
 // Undoes the last call to Lock on this accel_group .
 func (a *CAccelGroup) Unlock() {
-	a.locking -= 1
-	if a.locking <= 0 {
+	if a.locking > 0 {
+		a.locking -= 1
+	}
+	if a.locking == 0 {
 		_ = a.SetBoolProperty(PropertyIsLocked, false)
 	}
 }
@@ -272,7 +366,13 @@ func (a *CAccelGroup) GetModifierMask() (value cdk.ModMask) {
 // find_func
 // . The key is owned by CTK and must not be freed.
 func (a *CAccelGroup) Find(findFunc AccelGroupFindFunc, data interface{}) (key *AccelKey) {
-	a.LogError("method not implemented")
+	for _, id := range a.sortedIds() {
+		entry := a.entries[id]
+		if findFunc(entry.Accelerator, entry.Closure, []interface{}{data}) {
+			accelerator := entry.Accelerator
+			return &accelerator
+		}
+	}
 	return
 }
 
@@ -284,9 +384,10 @@ func (a *CAccelGroup) Find(findFunc AccelGroupFindFunc, data interface{}) (key *
 // 	keyval	a GDK keyval
 // 	modifiers	modifier mask
 // 	returns	TRUE if the accelerator is valid
+// In CTK the special keys known to AcceleratorParse and the printable Latin-1
+// characters are valid keys, with any modifiers.
 func (a *CAccelGroup) AcceleratorValid(keyval cdk.Key, modifiers cdk.ModMask) (valid bool) {
-	a.LogError("method unimplemented")
-	return true
+	return accelKeyName(keyval) != ""
 }
 
 // Parses a string representing an accelerator. The format looks like
@@ -295,16 +396,16 @@ func (a *CAccelGroup) AcceleratorValid(keyval cdk.Key, modifiers cdk.ModMask) (v
 // also abbreviations such as "<Ctl>" and "<Ctrl>". Key names are parsed
 // using KeyvalFromName. For character keys the name is not the
 // symbol, but the lowercase name, e.g. one would use "<Ctrl>minus" instead
-// of "<Ctrl>-". If the parse fails, accelerator_key and accelerator_mods
-// will be set to 0 (zero).
+// of "<Ctrl>-", though the symbol is accepted as well. The "<Release>"
+// modifier is not supported as terminals do not report key releases. If the
+// parse fails, accelerator_key and accelerator_mods will be set to 0 (zero).
 // Parameters:
 // 	accelerator	string representing an accelerator
 // Returns:
 // 	acceleratorKey	    keyval.
 // 	acceleratorMods	    modifier mask.
 func (a *CAccelGroup) AcceleratorParse(accelerator string) (acceleratorKey cdk.Key, acceleratorMods cdk.ModMask) {
-	a.LogError("method unimplemented")
-	return
+	return accelParse(accelerator)
 }
 
 // Converts an accelerator keyval and modifier mask into a string parseable
@@ -318,37 +419,39 @@ func (a *CAccelGroup) AcceleratorParse(accelerator string) (acceleratorKey cdk.K
 // Returns:
 // 	a newly-allocated accelerator name
 func (a *CAccelGroup) AcceleratorName(acceleratorKey cdk.Key, acceleratorMods cdk.ModMask) (value string) {
-	return fmt.Sprintf("%v %v", acceleratorMods.String(), cdk.LookupKeyName(acceleratorKey))
+	return accelName(acceleratorKey, acceleratorMods)
 }
 
 // Converts an accelerator keyval and modifier mask into a string which can
-// be used to represent the accelerator to the user.
+// be used to represent the accelerator to the user, for example "Ctrl+Q".
 // Parameters:
 // 	acceleratorKey	accelerator keyval
 // 	acceleratorMods	accelerator modifier mask
 // Returns:
 // 	a newly-allocated string representing the accelerator.
 func (a *CAccelGroup) AcceleratorGetLabel(acceleratorKey cdk.Key, acceleratorMods cdk.ModMask) (value string) {
-	return fmt.Sprintf("%v %v", acceleratorMods.String(), cdk.LookupKeyName(acceleratorKey))
+	return accelLabel(acceleratorKey, acceleratorMods)
 }
 
 // Sets the modifiers that will be considered significant for keyboard
-// accelerators. The default mod mask is GDK_CONTROL_MASK | GDK_SHIFT_MASK |
-// GDK_MOD1_MASK | GDK_SUPER_MASK | GDK_HYPER_MASK | GDK_META_MASK, that is,
-// Control, Shift, Alt, Super, Hyper and Meta. Other modifiers will by
+// accelerators. The default mod mask is cdk.ModCtrl | cdk.ModShift |
+// cdk.ModAlt | cdk.ModMeta, that is, Control, Shift, Alt and Meta. The mod
+// mask is shared by all accelerator groups. Other modifiers will by
 // default be ignored by AccelGroup. You must include at least the three
 // modifiers Control, Shift and Alt in any value you pass to this function.
 // The default mod mask should be changed on application startup, before
 // using any accelerator groups.
 // Parameters:
 // 	defaultModMask	accelerator modifier mask
-func (a *CAccelGroup) AcceleratorSetDefaultModMask(defaultModMask cdk.ModMask) {}
+func (a *CAccelGroup) AcceleratorSetDefaultModMask(defaultModMask cdk.ModMask) {
+	accelDefaultModMask = defaultModMask | cdk.ModCtrl | cdk.ModShift | cdk.ModAlt
+}
 
 // Gets the value set by AcceleratorSetDefaultModMask.
 // Parameters:
 // 	returns	the default accelerator modifier mask
 func (a *CAccelGroup) AcceleratorGetDefaultModMask() (value int) {
-	return 0
+	return int(accelDefaultModMask)
 }
 
 // Is the accel group locked.
@@ -358,7 +461,7 @@ const PropertyIsLocked cdk.Property = "is-locked"
 
 // Modifier Mask.
 // Flags: Read
-// Default value: GDK_SHIFT_MASK | GDK_CONTROL_MASK | GDK_MOD1_MASK | GDK_SUPER_MASK | GDK_HYPER_MASK | GDK_META_MASK
+// Default value: cdk.ModShift | cdk.ModCtrl | cdk.ModAlt | cdk.ModMeta
 const PropertyModifierMask cdk.Property = "modifier-mask"
 
 // The accel-activate signal is an implementation detail of AccelGroup and
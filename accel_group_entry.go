@@ -8,6 +8,7 @@ type AccelGroupEntry struct {
 	Accelerator AccelKey
 	Closure     GClosure
 	Quark       cdk.QuarkID
+	Path        string
 }
 
 func NewAccelGroupEntry(key AccelKey, closure GClosure, quark cdk.QuarkID) (age *AccelGroupEntry) {
@@ -0,0 +1,84 @@
+package ctk
+
+import (
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAccelGroup(t *testing.T) {
+	Convey("Testing AccelGroups", t, func() {
+		group := NewAccelGroup()
+		Convey("parsing and naming", func() {
+			key, mods := group.AcceleratorParse("<Control><Shift>F1")
+			So(key, ShouldEqual, cdk.KeyF1)
+			So(mods, ShouldEqual, cdk.ModCtrl|cdk.ModShift)
+			So(group.AcceleratorName(key, mods), ShouldEqual, "<Control><Shift>F1")
+			So(group.AcceleratorGetLabel(key, mods), ShouldEqual, "Ctrl+Shift+F1")
+			key, mods = group.AcceleratorParse("<ctl><ALT>Q")
+			So(key, ShouldEqual, cdk.Key('q'))
+			So(mods, ShouldEqual, cdk.ModCtrl|cdk.ModAlt)
+			So(group.AcceleratorName(key, mods), ShouldEqual, "<Control><Alt>q")
+			So(group.AcceleratorGetLabel(key, mods), ShouldEqual, "Ctrl+Alt+Q")
+			key, mods = group.AcceleratorParse("<Primary>minus")
+			So(key, ShouldEqual, cdk.Key('-'))
+			So(group.AcceleratorName(key, mods), ShouldEqual, "<Control>minus")
+			So(group.AcceleratorGetLabel(key, mods), ShouldEqual, "Ctrl+-")
+			key, _ = group.AcceleratorParse("Page_Down")
+			So(key, ShouldEqual, cdk.KeyPgDn)
+			So(group.AcceleratorGetLabel(key, 0), ShouldEqual, "Page Down")
+			key, mods = group.AcceleratorParse("<Release>z")
+			So(key, ShouldEqual, 0)
+			So(mods, ShouldEqual, 0)
+			key, _ = group.AcceleratorParse("<Control>")
+			So(key, ShouldEqual, 0)
+			So(group.AcceleratorValid(cdk.KeyF5, 0), ShouldBeTrue)
+			So(group.AcceleratorValid(cdk.KeyRune, cdk.ModCtrl), ShouldBeFalse)
+		})
+		Convey("locking and flags", func() {
+			id := group.AccelConnect(cdk.KeyF2, 0, ACCEL_VISIBLE, func(argv ...interface{}) bool { return true })
+			locked := group.AccelConnect(cdk.KeyF3, 0, ACCEL_LOCKED, func(argv ...interface{}) bool { return true })
+			So(group.DisconnectKey(cdk.KeyF3, 0), ShouldBeFalse)
+			group.Lock()
+			group.Lock()
+			So(group.GetIsLocked(), ShouldBeTrue)
+			So(group.AccelConnect(cdk.KeyF4, 0, 0, nil), ShouldEqual, -1)
+			So(group.AccelDisconnect(id), ShouldBeFalse)
+			group.Unlock()
+			So(group.GetIsLocked(), ShouldBeTrue)
+			group.Unlock()
+			So(group.GetIsLocked(), ShouldBeFalse)
+			So(group.AccelDisconnect(id), ShouldBeTrue)
+			So(group.AccelDisconnect(locked), ShouldBeFalse)
+			found := group.Find(func(key AccelKey, closure GClosure, data []interface{}) bool {
+				return key.Flags&ACCEL_LOCKED != 0
+			}, nil)
+			So(found, ShouldNotBeNil)
+			So(found.Key, ShouldEqual, cdk.KeyF3)
+		})
+		Convey("window dispatch", func() {
+			window := NewWindowWithTitle("accelerators")
+			activated := ""
+			group.AccelConnect(cdk.Key('q'), cdk.ModCtrl, ACCEL_VISIBLE, func(argv ...interface{}) bool {
+				activated = "quit"
+				return true
+			})
+			group.AccelConnect(cdk.KeyF1, cdk.ModCtrl|cdk.ModShift, ACCEL_VISIBLE, func(argv ...interface{}) bool {
+				activated = "help"
+				return true
+			})
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlQ, 0, cdk.ModCtrl)), ShouldEqual, cdk.EVENT_PASS)
+			So(activated, ShouldEqual, "")
+			window.AddAccelGroup(group)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlQ, 0, cdk.ModCtrl)), ShouldEqual, cdk.EVENT_STOP)
+			So(activated, ShouldEqual, "quit")
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyF1, 0, cdk.ModCtrl|cdk.ModShift)), ShouldEqual, cdk.EVENT_STOP)
+			So(activated, ShouldEqual, "help")
+			activated = ""
+			window.RemoveAccelGroup(group)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyF1, 0, cdk.ModCtrl|cdk.ModShift)), ShouldEqual, cdk.EVENT_PASS)
+			So(activated, ShouldEqual, "")
+		})
+	})
+}
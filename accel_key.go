@@ -1,7 +1,9 @@
 package ctk
 
 import (
-	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/kckrinke/go-cdk"
 )
@@ -21,6 +23,296 @@ func MakeAccelKey(key cdk.Key, mods cdk.ModMask, flags AccelFlags) (accelKey Acc
 	return
 }
 
+// Returns the accelerator name of the key, as with AcceleratorName.
 func (a AccelKey) String() (key string) {
-	return fmt.Sprintf("%v %v", a.Mods.String(), cdk.LookupKeyName(a.Key))
+	return accelName(a.Key, a.Mods)
+}
+
+// Accelerators for printable characters use the lowercase character itself as
+// the key, as GDK keyvals do. Characters are limited to Latin-1, the key
+// values above are taken by the special keys of cdk. Shifted characters a
+// terminal reports without the shift modifier, such as "!", are accelerators
+// of their own without the modifier.
+
+// the modifiers considered significant for accelerators, see
+// AcceleratorSetDefaultModMask
+var accelDefaultModMask = cdk.ModShift | cdk.ModCtrl | cdk.ModAlt | cdk.ModMeta
+
+// the modifiers of accelerators, in the order named
+var accelModifiers = []struct {
+	mod   cdk.ModMask
+	name  string
+	label string
+}{
+	{cdk.ModCtrl, "Control", "Ctrl"},
+	{cdk.ModShift, "Shift", "Shift"},
+	{cdk.ModAlt, "Alt", "Alt"},
+	{cdk.ModMeta, "Meta", "Meta"},
+}
+
+// the lowercase modifier names accepted when parsing accelerators
+var accelModifierNames = map[string]cdk.ModMask{
+	"control": cdk.ModCtrl,
+	"ctrl":    cdk.ModCtrl,
+	"ctl":     cdk.ModCtrl,
+	"primary": cdk.ModCtrl,
+	"shift":   cdk.ModShift,
+	"shft":    cdk.ModShift,
+	"alt":     cdk.ModAlt,
+	"mod1":    cdk.ModAlt,
+	"meta":    cdk.ModMeta,
+}
+
+// the GDK names of the special keys
+var accelKeyNames = map[cdk.Key]string{
+	cdk.KeyEnter:      "Return",
+	cdk.KeyTab:        "Tab",
+	cdk.KeyBacktab:    "ISO_Left_Tab",
+	cdk.KeyEscape:     "Escape",
+	cdk.KeyBackspace:  "BackSpace",
+	cdk.KeyBackspace2: "BackSpace",
+	cdk.KeyInsert:     "Insert",
+	cdk.KeyDelete:     "Delete",
+	cdk.KeyHome:       "Home",
+	cdk.KeyEnd:        "End",
+	cdk.KeyPgUp:       "Page_Up",
+	cdk.KeyPgDn:       "Page_Down",
+	cdk.KeyUp:         "Up",
+	cdk.KeyDown:       "Down",
+	cdk.KeyLeft:       "Left",
+	cdk.KeyRight:      "Right",
+	cdk.KeyF1:         "F1",
+	cdk.KeyF2:         "F2",
+	cdk.KeyF3:         "F3",
+	cdk.KeyF4:         "F4",
+	cdk.KeyF5:         "F5",
+	cdk.KeyF6:         "F6",
+	cdk.KeyF7:         "F7",
+	cdk.KeyF8:         "F8",
+	cdk.KeyF9:         "F9",
+	cdk.KeyF10:        "F10",
+	cdk.KeyF11:        "F11",
+	cdk.KeyF12:        "F12",
+}
+
+// the special keys by GDK name, including the aliases accepted when parsing
+var accelKeysByName = map[string]cdk.Key{
+	"Return":       cdk.KeyEnter,
+	"Enter":        cdk.KeyEnter,
+	"KP_Enter":     cdk.KeyEnter,
+	"Tab":          cdk.KeyTab,
+	"ISO_Left_Tab": cdk.KeyBacktab,
+	"Escape":       cdk.KeyEscape,
+	"BackSpace":    cdk.KeyBackspace2,
+	"Insert":       cdk.KeyInsert,
+	"Delete":       cdk.KeyDelete,
+	"Home":         cdk.KeyHome,
+	"End":          cdk.KeyEnd,
+	"Page_Up":      cdk.KeyPgUp,
+	"Prior":        cdk.KeyPgUp,
+	"Page_Down":    cdk.KeyPgDn,
+	"Next":         cdk.KeyPgDn,
+	"Up":           cdk.KeyUp,
+	"Down":         cdk.KeyDown,
+	"Left":         cdk.KeyLeft,
+	"Right":        cdk.KeyRight,
+	"F1":           cdk.KeyF1,
+	"F2":           cdk.KeyF2,
+	"F3":           cdk.KeyF3,
+	"F4":           cdk.KeyF4,
+	"F5":           cdk.KeyF5,
+	"F6":           cdk.KeyF6,
+	"F7":           cdk.KeyF7,
+	"F8":           cdk.KeyF8,
+	"F9":           cdk.KeyF9,
+	"F10":          cdk.KeyF10,
+	"F11":          cdk.KeyF11,
+	"F12":          cdk.KeyF12,
+}
+
+// the labels of special keys which differ from their names
+var accelKeyLabels = map[cdk.Key]string{
+	cdk.KeyEnter:      "Enter",
+	cdk.KeyBacktab:    "Shift+Tab",
+	cdk.KeyEscape:     "Esc",
+	cdk.KeyBackspace:  "Backspace",
+	cdk.KeyBackspace2: "Backspace",
+	cdk.KeyPgUp:       "Page Up",
+	cdk.KeyPgDn:       "Page Down",
+}
+
+// the GDK names of the printable ASCII characters which are not letters or
+// digits
+var accelRuneNames = map[rune]string{
+	' ':  "space",
+	'!':  "exclam",
+	'"':  "quotedbl",
+	'#':  "numbersign",
+	'$':  "dollar",
+	'%':  "percent",
+	'&':  "ampersand",
+	'\'': "apostrophe",
+	'(':  "parenleft",
+	')':  "parenright",
+	'*':  "asterisk",
+	'+':  "plus",
+	',':  "comma",
+	'-':  "minus",
+	'.':  "period",
+	'/':  "slash",
+	':':  "colon",
+	';':  "semicolon",
+	'<':  "less",
+	'=':  "equal",
+	'>':  "greater",
+	'?':  "question",
+	'@':  "at",
+	'[':  "bracketleft",
+	'\\': "backslash",
+	']':  "bracketright",
+	'^':  "asciicircum",
+	'_':  "underscore",
+	'`':  "grave",
+	'{':  "braceleft",
+	'|':  "bar",
+	'}':  "braceright",
+	'~':  "asciitilde",
+}
+
+// returns the accelerator key of a printable character, or zero if the
+// character is not printable or outside of Latin-1
+func accelKeyForRune(r rune) cdk.Key {
+	r = unicode.ToLower(r)
+	if r >= rune(cdk.KeyRune) || r == rune(cdk.KeyBackspace2) || !unicode.IsPrint(r) {
+		return 0
+	}
+	return cdk.Key(r)
+}
+
+// returns the printable character of an accelerator key and TRUE, or FALSE if
+// the key is a special key
+func accelKeyRune(key cdk.Key) (r rune, ok bool) {
+	if key < ' ' || key >= cdk.KeyRune || key == cdk.KeyBackspace2 {
+		return 0, false
+	}
+	r = rune(key)
+	return r, unicode.IsPrint(r)
+}
+
+// returns the accelerator key and modifiers of a key event, with modifiers
+// outside of the default mod mask removed. Control characters are reported by
+// terminals as keys of their own and are translated back to the lowercase
+// letter with the control modifier, uppercase letters to the lowercase letter
+// with the shift modifier. The key is zero if the event has no accelerator
+func accelEventKey(e *cdk.EventKey) (key cdk.Key, mods cdk.ModMask) {
+	key, mods = e.Key(), e.Modifiers()
+	switch {
+	case key == cdk.KeyRune:
+		r := e.Rune()
+		if unicode.IsUpper(r) {
+			mods |= cdk.ModShift
+		}
+		key = accelKeyForRune(r)
+	case key >= cdk.KeyCtrlA && key <= cdk.KeyCtrlZ && mods.Has(cdk.ModCtrl):
+		key = cdk.Key('a') + (key - cdk.KeyCtrlA)
+	}
+	mods &= accelDefaultModMask
+	return
+}
+
+// returns the GDK name of an accelerator key, or an empty string if the key
+// cannot be used in accelerators
+func accelKeyName(key cdk.Key) string {
+	if name, ok := accelKeyNames[key]; ok {
+		return name
+	}
+	if r, ok := accelKeyRune(key); ok {
+		if name, ok := accelRuneNames[r]; ok {
+			return name
+		}
+		return string(r)
+	}
+	return ""
+}
+
+// returns the accelerator key of a GDK key name, or zero if the name is unknown
+func accelKeyFromName(name string) cdk.Key {
+	if key, ok := accelKeysByName[name]; ok {
+		return key
+	}
+	for r, n := range accelRuneNames {
+		if n == name {
+			return cdk.Key(r)
+		}
+	}
+	if r, size := utf8.DecodeRuneInString(name); size > 0 && size == len(name) {
+		return accelKeyForRune(r)
+	}
+	// the key names are case-sensitive, though not ambiguous
+	for n, key := range accelKeysByName {
+		if strings.EqualFold(n, name) {
+			return key
+		}
+	}
+	return 0
+}
+
+// parses an accelerator such as "<Control><Shift>F1", returning zeros if the
+// accelerator is not valid
+func accelParse(accelerator string) (key cdk.Key, mods cdk.ModMask) {
+	accelerator = strings.TrimSpace(accelerator)
+	for strings.HasPrefix(accelerator, "<") {
+		end := strings.Index(accelerator, ">")
+		if end < 0 {
+			return 0, 0
+		}
+		mod, ok := accelModifierNames[strings.ToLower(accelerator[1:end])]
+		if !ok {
+			return 0, 0
+		}
+		mods |= mod
+		accelerator = accelerator[end+1:]
+	}
+	if key = accelKeyFromName(accelerator); key == 0 {
+		return 0, 0
+	}
+	if r, ok := accelKeyRune(key); ok && unicode.IsUpper(r) {
+		key = accelKeyForRune(r)
+	}
+	return
+}
+
+// returns the name of an accelerator such as "<Control><Shift>F1", parseable
+// by accelParse
+func accelName(key cdk.Key, mods cdk.ModMask) string {
+	var name string
+	for _, modifier := range accelModifiers {
+		if mods.Has(modifier.mod) {
+			name += "<" + modifier.name + ">"
+		}
+	}
+	return name + accelKeyName(key)
+}
+
+// returns the label of an accelerator such as "Ctrl+Shift+F1", for display
+func accelLabel(key cdk.Key, mods cdk.ModMask) string {
+	var parts []string
+	for _, modifier := range accelModifiers {
+		if mods.Has(modifier.mod) {
+			parts = append(parts, modifier.label)
+		}
+	}
+	var label string
+	if r, ok := accelKeyRune(key); ok {
+		if r == ' ' {
+			label = "Space"
+		} else {
+			label = string(unicode.ToUpper(r))
+		}
+	} else if l, ok := accelKeyLabels[key]; ok {
+		label = l
+	} else {
+		label = strings.ReplaceAll(accelKeyName(key), "_", " ")
+	}
+	return strings.Join(append(parts, label), "+")
 }
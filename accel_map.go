@@ -0,0 +1,507 @@
+package ctk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for AccelMap objects
+const TypeAccelMap cdk.CTypeTag = "ctk-accel-map"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeAccelMap, func() interface{} { return MakeAccelMap() })
+}
+
+// AccelMap Hierarchy:
+//	Object
+//	  +- AccelMap
+//
+// The AccelMap holds the accelerators of accelerator paths, such as
+// "<App>/File/Save". Applications register the default accelerator of each
+// path with AddEntry and connect the paths to AccelGroups with
+// AccelGroup.ConnectByPath or Widget.SetAccelPath. Accelerators changed with
+// ChangeEntry, for example to let users rebind keys, update all AccelGroups
+// connected to the path and emit the changed signal. The accelerators can be
+// saved to and loaded from a plain text file, see Save and Load, by default
+// the accels file in the user's configuration directory. There is one AccelMap
+// shared by all AccelGroups, see GetAccelMap.
+type AccelMap interface {
+	Object
+
+	Init() (already bool)
+	AddEntry(accelPath string, accelKey cdk.Key, accelMods cdk.ModMask)
+	LookupEntry(accelPath string) (key AccelKey, found bool)
+	ChangeEntry(accelPath string, accelKey cdk.Key, accelMods cdk.ModMask, replace bool) (changed bool)
+	LookupConflicts(accelKey cdk.Key, accelMods cdk.ModMask) (paths []string)
+	ListPaths() (paths []string)
+	LockPath(accelPath string)
+	UnlockPath(accelPath string)
+	Load(fileName string) (err error)
+	LoadFromReader(reader io.Reader) (err error)
+	Save(fileName string) (err error)
+	SaveToWriter(writer io.Writer) (err error)
+	LoadDefault() (err error)
+	SaveDefault() (err error)
+}
+
+// The CAccelMap structure implements the AccelMap interface and is exported
+// to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with AccelMap objects
+type CAccelMap struct {
+	CObject
+
+	entries map[string]*accelMapEntry
+	groups  map[string][]*CAccelGroup
+	lock    *sync.RWMutex
+}
+
+type accelMapEntry struct {
+	path       string
+	key        AccelKey
+	defaultKey AccelKey
+	changed    bool
+	locks      int
+}
+
+// entries are changed when their accelerator differs from the default one
+func (e *accelMapEntry) update() {
+	e.changed = e.key.Key != e.defaultKey.Key || e.key.Mods != e.defaultKey.Mods
+}
+
+var (
+	accelMap     *CAccelMap
+	accelMapLock = &sync.Mutex{}
+)
+
+// The name of the directory within the user's configuration directory the
+// accels file is kept in by LoadDefault and SaveDefault. Defaults to the name
+// of the running program.
+var AccelMapName = filepath.Base(os.Args[0])
+
+// Default constructor for AccelMap objects
+func MakeAccelMap() *CAccelMap {
+	return NewAccelMap()
+}
+
+// Returns a newly created AccelMap. Applications should use the shared
+// instance returned by GetAccelMap.
+func NewAccelMap() (value *CAccelMap) {
+	m := new(CAccelMap)
+	m.Init()
+	return m
+}
+
+// Returns the AccelMap shared by all AccelGroups.
+func GetAccelMap() (value AccelMap) {
+	return getAccelMap()
+}
+
+func getAccelMap() *CAccelMap {
+	accelMapLock.Lock()
+	defer accelMapLock.Unlock()
+	if accelMap == nil {
+		accelMap = NewAccelMap()
+	}
+	return accelMap
+}
+
+// Returns the path of the accels file used by LoadDefault and SaveDefault.
+func GetAccelMapFile() (path string, err error) {
+	var dir string
+	if dir, err = os.UserConfigDir(); err != nil {
+		return
+	}
+	return filepath.Join(dir, AccelMapName, "accels"), nil
+}
+
+// AccelMap object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the AccelMap instance
+func (m *CAccelMap) Init() (already bool) {
+	if m.InitTypeItem(TypeAccelMap, m) {
+		return true
+	}
+	m.CObject.Init()
+	m.entries = make(map[string]*accelMapEntry)
+	m.groups = make(map[string][]*CAccelGroup)
+	m.lock = &sync.RWMutex{}
+	return false
+}
+
+// Registers a new accelerator with the global accelerator map. This function
+// should only be called once per accel_path with the canonical accel_key and
+// accel_mods for this path. To change the accelerator during runtime
+// programmatically, use ChangeEntry. Registering a path already known keeps
+// its accelerator, unless the path was added without a default accelerator,
+// as happens when loading an accels file before the application registered
+// its paths.
+// Parameters:
+// 	accelPath	valid accelerator path
+// 	accelKey	the accelerator key
+// 	accelMods	the accelerator modifiers
+func (m *CAccelMap) AddEntry(accelPath string, accelKey cdk.Key, accelMods cdk.ModMask) {
+	if !validAccelPath(accelPath) {
+		m.LogError("invalid accelerator path: %q", accelPath)
+		return
+	}
+	key := MakeAccelKey(accelKey, accelMods&accelDefaultModMask, ACCEL_VISIBLE)
+	m.lock.Lock()
+	entry, ok := m.entries[accelPath]
+	if !ok {
+		m.entries[accelPath] = &accelMapEntry{path: accelPath, key: key, defaultKey: key}
+		m.lock.Unlock()
+		m.notify(accelPath, key)
+		return
+	}
+	apply := false
+	if entry.defaultKey.Key == 0 && accelKey != 0 {
+		if !entry.changed {
+			entry.key = key
+			apply = true
+		}
+		entry.defaultKey = key
+		entry.update()
+	}
+	m.lock.Unlock()
+	if apply {
+		m.notify(accelPath, key)
+	}
+}
+
+// Looks up the accelerator entry for accel_path .
+// Parameters:
+// 	accelPath	a valid accelerator path
+// Returns:
+// 	key	the accelerator key of the path
+// 	found	TRUE if accel_path is known, FALSE otherwise
+func (m *CAccelMap) LookupEntry(accelPath string) (key AccelKey, found bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if entry, ok := m.entries[accelPath]; ok {
+		return entry.key, true
+	}
+	return
+}
+
+// Changes the accel_key and accel_mods currently associated with accel_path .
+// Due to conflicts with other accelerators, a change may not always be
+// possible, replace indicates whether other accelerators may be deleted to
+// resolve such conflicts. A change will only occur if all conflicts could be
+// resolved (which might not be the case if conflicting accelerators are
+// locked). Paths are locked with LockPath, or by locking an AccelGroup
+// connected to the path. Successful changes are indicated by a TRUE return
+// value and the changed signal emitted for each path changed. Passing a zero
+// accel_key removes the accelerator of the path.
+// Parameters:
+// 	accelPath	a valid accelerator path
+// 	accelKey	the new accelerator key
+// 	accelMods	the new accelerator modifiers
+// 	replace	TRUE if other accelerators may be deleted upon conflicts
+// Returns:
+// 	TRUE if the accelerator could be changed, FALSE otherwise
+func (m *CAccelMap) ChangeEntry(accelPath string, accelKey cdk.Key, accelMods cdk.ModMask, replace bool) (changed bool) {
+	accelMods &= accelDefaultModMask
+	m.lock.Lock()
+	entry, ok := m.entries[accelPath]
+	if !ok || m.isLocked(entry) {
+		m.lock.Unlock()
+		return false
+	}
+	var updates []*accelMapEntry
+	if accelKey != 0 {
+		for _, path := range m.conflicts(accelKey, accelMods) {
+			if path == accelPath {
+				continue
+			}
+			if !replace || m.isLocked(m.entries[path]) {
+				m.lock.Unlock()
+				return false
+			}
+			updates = append(updates, m.entries[path])
+		}
+	}
+	for _, conflict := range updates {
+		conflict.key.Key, conflict.key.Mods = 0, 0
+		conflict.update()
+	}
+	entry.key.Key, entry.key.Mods = accelKey, accelMods
+	entry.update()
+	updates = append(updates, entry)
+	m.lock.Unlock()
+	for _, update := range updates {
+		m.notify(update.path, update.key)
+	}
+	return true
+}
+
+// Returns the paths with the given accelerator, sorted. The accelerator of a
+// path is in conflict with any other path returned.
+// Parameters:
+// 	accelKey	the accelerator key
+// 	accelMods	the accelerator modifiers
+func (m *CAccelMap) LookupConflicts(accelKey cdk.Key, accelMods cdk.ModMask) (paths []string) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.conflicts(accelKey, accelMods&accelDefaultModMask)
+}
+
+// Returns all known accelerator paths, sorted.
+func (m *CAccelMap) ListPaths() (paths []string) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for path := range m.entries {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return
+}
+
+// Locks the given accelerator path, preventing its accelerator from being
+// changed with ChangeEntry, including by changes of other paths replacing
+// it. If called more than once, accel_path remains locked until UnlockPath
+// has been called an equivalent number of times. Unknown paths are added
+// without an accelerator.
+// Parameters:
+// 	accelPath	a valid accelerator path
+func (m *CAccelMap) LockPath(accelPath string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry, ok := m.entries[accelPath]
+	if !ok {
+		entry = &accelMapEntry{path: accelPath}
+		m.entries[accelPath] = entry
+	}
+	entry.locks += 1
+}
+
+// Undoes the last call to LockPath on this accel_path .
+// Parameters:
+// 	accelPath	a valid accelerator path
+func (m *CAccelMap) UnlockPath(accelPath string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if entry, ok := m.entries[accelPath]; ok && entry.locks > 0 {
+		entry.locks -= 1
+	}
+}
+
+// Parses a file previously saved with Save for accelerator specifications,
+// and propagates them accordingly. Paths which are not known yet are added.
+// Parameters:
+// 	fileName	a file containing accelerator specifications
+func (m *CAccelMap) Load(fileName string) (err error) {
+	var f *os.File
+	if f, err = os.Open(fileName); err != nil {
+		return
+	}
+	defer f.Close()
+	if err = m.LoadFromReader(f); err != nil {
+		err = fmt.Errorf("%v: %w", fileName, err)
+	}
+	return
+}
+
+// Parses accelerator specifications from the reader, see Load. Each line
+// specifies the accelerator of one path, as written by SaveToWriter, lines
+// beginning with a semicolon are comments. All valid lines are applied, the
+// error returned reports the first line which could not be parsed.
+func (m *CAccelMap) LoadFromReader(reader io.Reader) (err error) {
+	scanner := bufio.NewScanner(reader)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+		path, accelerator, e := parseAccelMapLine(line)
+		if e == nil {
+			key, mods := accelParse(accelerator)
+			if key == 0 && accelerator != "" {
+				e = fmt.Errorf("invalid accelerator: %q", accelerator)
+			} else {
+				m.AddEntry(path, 0, 0)
+				if !m.ChangeEntry(path, key, mods, true) {
+					e = fmt.Errorf("accelerator of %v could not be changed", path)
+				}
+			}
+		}
+		if e != nil && err == nil {
+			err = fmt.Errorf("line %d: %w", number, e)
+		}
+	}
+	if e := scanner.Err(); e != nil {
+		err = e
+	}
+	return
+}
+
+// Saves current accelerator specifications (accelerator path, key and
+// modifiers) to fileName , creating the directory of the file if necessary.
+// The file is written in a format suitable to be read back in by Load.
+// Parameters:
+// 	fileName	the name of the file to contain accelerator specifications
+func (m *CAccelMap) Save(fileName string) (err error) {
+	var content strings.Builder
+	if err = m.SaveToWriter(&content); err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return
+	}
+	return ioutil.WriteFile(fileName, []byte(content.String()), 0644)
+}
+
+// Writes the accelerator specifications to the writer, see Save. Each path is
+// written on a line of its own, the lines of paths with their default
+// accelerator are commented out so that changes to the defaults made by the
+// application take effect.
+func (m *CAccelMap) SaveToWriter(writer io.Writer) (err error) {
+	m.lock.RLock()
+	lines := []string{fmt.Sprintf("; %v accelerator map, lines beginning with a semicolon are ignored", AccelMapName)}
+	for _, path := range m.sortedPaths() {
+		entry := m.entries[path]
+		line := fmt.Sprintf("(ctk_accel_path %v %v)", strconv.Quote(path), strconv.Quote(accelName(entry.key.Key, entry.key.Mods)))
+		if !entry.changed {
+			line = "; " + line
+		}
+		lines = append(lines, line)
+	}
+	m.lock.RUnlock()
+	_, err = io.WriteString(writer, strings.Join(lines, "\n")+"\n")
+	return
+}
+
+// Loads the accels file returned by GetAccelMapFile, if there is one.
+func (m *CAccelMap) LoadDefault() (err error) {
+	var path string
+	if path, err = GetAccelMapFile(); err != nil {
+		return
+	}
+	if err = m.Load(path); os.IsNotExist(err) {
+		err = nil
+	}
+	return
+}
+
+// Saves the accels file returned by GetAccelMapFile.
+func (m *CAccelMap) SaveDefault() (err error) {
+	var path string
+	if path, err = GetAccelMapFile(); err != nil {
+		return
+	}
+	return m.Save(path)
+}
+
+// watch the accelerator path on behalf of the group, for the group to be
+// updated when the accelerator of the path changes and to lock the path while
+// the group is locked
+func (m *CAccelMap) watchPath(accelPath string, group *CAccelGroup) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, g := range m.groups[accelPath] {
+		if g == group {
+			return
+		}
+	}
+	m.groups[accelPath] = append(m.groups[accelPath], group)
+}
+
+// stop watching the accelerator path on behalf of the group, once the group
+// has no accelerator connected to the path anymore
+func (m *CAccelMap) unwatchPath(accelPath string, group *CAccelGroup) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	groups := m.groups[accelPath]
+	for idx, g := range groups {
+		if g == group {
+			groups = append(groups[:idx], groups[idx+1:]...)
+			break
+		}
+	}
+	if len(groups) == 0 {
+		delete(m.groups, accelPath)
+	} else {
+		m.groups[accelPath] = groups
+	}
+}
+
+// paths are locked with LockPath or by any locked group with an accelerator
+// connected to the path
+func (m *CAccelMap) isLocked(entry *accelMapEntry) bool {
+	if entry.locks > 0 {
+		return true
+	}
+	for _, group := range m.groups[entry.path] {
+		if group.GetIsLocked() && group.hasPath(entry.path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *CAccelMap) conflicts(accelKey cdk.Key, accelMods cdk.ModMask) (paths []string) {
+	if accelKey == 0 {
+		return
+	}
+	for _, path := range m.sortedPaths() {
+		if entry := m.entries[path]; entry.key.Key == accelKey && entry.key.Mods == accelMods {
+			paths = append(paths, path)
+		}
+	}
+	return
+}
+
+func (m *CAccelMap) sortedPaths() (paths []string) {
+	for path := range m.entries {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return
+}
+
+// update the groups connected to the path and emit the changed signal
+func (m *CAccelMap) notify(accelPath string, key AccelKey) {
+	m.lock.RLock()
+	groups := append([]*CAccelGroup{}, m.groups[accelPath]...)
+	m.lock.RUnlock()
+	for _, group := range groups {
+		group.accelPathChanged(accelPath, key.Key, key.Mods)
+	}
+	m.Emit(SignalChanged, m, accelPath, key.Key, key.Mods)
+}
+
+var rxAccelMapLine = regexp.MustCompile(`^\((?:ctk|gtk)_accel_path\s+("(?:[^"\\]|\\.)*")\s+("(?:[^"\\]|\\.)*")\s*\)$`)
+
+// parse a line such as (ctk_accel_path "<App>/File/Save" "<Control>s"), lines
+// written by GTK applications are accepted as well
+func parseAccelMapLine(line string) (path, accelerator string, err error) {
+	match := rxAccelMapLine.FindStringSubmatch(line)
+	if match == nil {
+		return "", "", fmt.Errorf("invalid accelerator specification: %v", line)
+	}
+	if path, err = strconv.Unquote(match[1]); err == nil {
+		accelerator, err = strconv.Unquote(match[2])
+	}
+	if err == nil && !validAccelPath(path) {
+		err = fmt.Errorf("invalid accelerator path: %q", path)
+	}
+	return
+}
+
+// accelerator paths have the form "<WINDOWTYPE>/Category1/Category2/.../Action"
+func validAccelPath(accelPath string) bool {
+	end := strings.Index(accelPath, ">/")
+	return strings.HasPrefix(accelPath, "<") && end > 1 && len(accelPath) > end+2
+}
@@ -0,0 +1,102 @@
+package ctk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAccelMap(t *testing.T) {
+	Convey("Testing AccelMaps", t, func() {
+		Convey("entries and conflicts", func() {
+			m := NewAccelMap()
+			m.AddEntry("invalid", cdk.KeyF1, 0)
+			So(m.ListPaths(), ShouldBeEmpty)
+			m.AddEntry("<Test>/File/Save", cdk.Key('s'), cdk.ModCtrl)
+			m.AddEntry("<Test>/File/Quit", cdk.Key('q'), cdk.ModCtrl)
+			m.AddEntry("<Test>/File/Save", cdk.Key('x'), cdk.ModCtrl)
+			key, found := m.LookupEntry("<Test>/File/Save")
+			So(found, ShouldBeTrue)
+			So(key.Key, ShouldEqual, cdk.Key('s'))
+			So(key.Mods, ShouldEqual, cdk.ModCtrl)
+			var changes []string
+			m.Connect(SignalChanged, "accel-map-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				changes = append(changes, argv[1].(string))
+				return cdk.EVENT_PASS
+			})
+			So(m.ChangeEntry("<Test>/File/Missing", cdk.KeyF2, 0, true), ShouldBeFalse)
+			So(m.LookupConflicts(cdk.Key('q'), cdk.ModCtrl), ShouldResemble, []string{"<Test>/File/Quit"})
+			So(m.ChangeEntry("<Test>/File/Save", cdk.Key('q'), cdk.ModCtrl, false), ShouldBeFalse)
+			So(changes, ShouldBeEmpty)
+			So(m.ChangeEntry("<Test>/File/Save", cdk.Key('q'), cdk.ModCtrl, true), ShouldBeTrue)
+			So(changes, ShouldResemble, []string{"<Test>/File/Quit", "<Test>/File/Save"})
+			key, _ = m.LookupEntry("<Test>/File/Quit")
+			So(key.Key, ShouldEqual, 0)
+			m.LockPath("<Test>/File/Quit")
+			So(m.ChangeEntry("<Test>/File/Quit", cdk.KeyF3, 0, true), ShouldBeFalse)
+			m.UnlockPath("<Test>/File/Quit")
+			So(m.ChangeEntry("<Test>/File/Quit", cdk.KeyF3, 0, true), ShouldBeTrue)
+		})
+		Convey("saving and loading", func() {
+			dir, err := ioutil.TempDir("", "ctk-accel-map")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "app", "accels")
+			m := NewAccelMap()
+			m.AddEntry("<Test>/Edit/Copy", cdk.Key('c'), cdk.ModCtrl)
+			m.AddEntry("<Test>/Edit/Paste", cdk.Key('v'), cdk.ModCtrl)
+			So(m.ChangeEntry("<Test>/Edit/Paste", cdk.KeyF5, cdk.ModShift, false), ShouldBeTrue)
+			So(m.Save(path), ShouldBeNil)
+			content, err := ioutil.ReadFile(path)
+			So(err, ShouldBeNil)
+			So(string(content), ShouldContainSubstring, "; (ctk_accel_path \"<Test>/Edit/Copy\" \"<Control>c\")\n")
+			So(string(content), ShouldContainSubstring, "\n(ctk_accel_path \"<Test>/Edit/Paste\" \"<Shift>F5\")\n")
+			loaded := NewAccelMap()
+			So(loaded.Load(path), ShouldBeNil)
+			So(loaded.ListPaths(), ShouldResemble, []string{"<Test>/Edit/Paste"})
+			loaded.AddEntry("<Test>/Edit/Paste", cdk.Key('v'), cdk.ModCtrl)
+			key, _ := loaded.LookupEntry("<Test>/Edit/Paste")
+			So(key.Key, ShouldEqual, cdk.KeyF5)
+			So(key.Mods, ShouldEqual, cdk.ModShift)
+			err = loaded.LoadFromReader(strings.NewReader("(gtk_accel_path \"<Test>/Edit/Cut\" \"<Control>x\")\nnonsense\n"))
+			So(err, ShouldNotBeNil)
+			key, found := loaded.LookupEntry("<Test>/Edit/Cut")
+			So(found, ShouldBeTrue)
+			So(key.Key, ShouldEqual, cdk.Key('x'))
+		})
+		Convey("connecting paths", func() {
+			m := GetAccelMap()
+			group := NewAccelGroup()
+			window := NewWindowWithTitle("accel paths")
+			window.AddAccelGroup(group)
+			button := NewButtonWithLabel("open")
+			window.Add(button)
+			activated := 0
+			button.Connect(SignalActivate, "accel-path-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				activated++
+				return cdk.EVENT_STOP
+			})
+			button.SetAccelPath("<Test>/File/Open", group)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlO, 0, cdk.ModCtrl)), ShouldEqual, cdk.EVENT_PASS)
+			m.AddEntry("<Test>/File/Open", cdk.Key('o'), cdk.ModCtrl)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlO, 0, cdk.ModCtrl)), ShouldEqual, cdk.EVENT_STOP)
+			So(activated, ShouldEqual, 1)
+			So(m.ChangeEntry("<Test>/File/Open", cdk.KeyF6, 0, true), ShouldBeTrue)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyF6, 0, cdk.ModNone)), ShouldEqual, cdk.EVENT_STOP)
+			So(activated, ShouldEqual, 2)
+			group.Lock()
+			So(m.ChangeEntry("<Test>/File/Open", cdk.KeyF7, 0, true), ShouldBeFalse)
+			group.Unlock()
+			button.SetAccelPath("", nil)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyF6, 0, cdk.ModNone)), ShouldEqual, cdk.EVENT_PASS)
+			So(activated, ShouldEqual, 2)
+			// the map no longer updates the group once disconnected
+			So(getAccelMap().groups["<Test>/File/Open"], ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,507 @@
+package ctk
+
+import (
+	"fmt"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for Action objects
+const TypeAction cdk.CTypeTag = "ctk-action"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeAction, func() interface{} { return MakeAction() })
+}
+
+// Action Hierarchy:
+//	Object
+//	  +- Action
+//	    +- ToggleAction
+//	      +- RadioAction
+//
+// Actions represent operations that the user can perform, along with some
+// information how it should be presented in the interface. Each action
+// provides methods to create proxies, such as buttons or menu items, which
+// the action keeps in sync with its sensitivity, visibility, label and
+// tooltip. Activating any of the proxies, or the accelerator of the action,
+// emits the activate signal of the action, so that applications handle the
+// operation in one place. Actions are organised into ActionGroups, which
+// provide the accelerators of their actions, see ActionGroup.GetAccelGroup.
+// Within a Builder, widgets reference actions by id with the
+// "related-action" property.
+type Action interface {
+	Object
+
+	Init() (already bool)
+	GetName() (value string)
+	IsSensitive() (value bool)
+	GetSensitive() (value bool)
+	SetSensitive(sensitive bool)
+	IsVisible() (value bool)
+	GetVisible() (value bool)
+	SetVisible(visible bool)
+	Activate() (value bool)
+	ConnectProxy(proxy Widget)
+	DisconnectProxy(proxy Widget)
+	GetProxies() (value []Widget)
+	SyncProxies()
+	ConnectAccelerator()
+	DisconnectAccelerator()
+	GetAccelPath() (value string)
+	SetAccelPath(accelPath string)
+	GetAccelGroup() (value AccelGroup)
+	SetAccelGroup(accelGroup AccelGroup)
+	GetAccelerator() (value string)
+	SetAccelerator(accelerator string)
+	GetLabel() (value string)
+	SetLabel(label string)
+	GetTooltip() (value string)
+	SetTooltip(tooltip string)
+	GetStockId() (value StockID)
+	SetStockId(stockId StockID)
+	GetActionGroup() (value ActionGroup)
+	SetActionGroup(actionGroup ActionGroup)
+}
+
+// The CAction structure implements the Action interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Action objects
+type CAction struct {
+	CObject
+
+	actionGroup ActionGroup
+	proxies     []Widget
+	proxyHandle string
+	accelGroup  AccelGroup
+	accelId     int
+}
+
+// Default constructor for Action objects
+func MakeAction() *CAction {
+	return NewAction("", "", "", "")
+}
+
+// Creates a new Action object. To add the action to a ActionGroup and set
+// the accelerator for the action, call ActionGroup.AddActionWithAccel.
+// Parameters:
+// 	name	A unique name for the action
+// 	label	the label displayed in menu items and on buttons, or empty
+// 	tooltip	a tooltip for the action, or empty
+// 	stockId	the stock icon to display in widgets representing the
+// action, or empty
+func NewAction(name string, label string, tooltip string, stockId StockID) (value *CAction) {
+	a := new(CAction)
+	a.Init()
+	a.initAction(name, label, tooltip, stockId)
+	return a
+}
+
+// Action object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the Action instance
+func (a *CAction) Init() (already bool) {
+	if a.InitTypeItem(TypeAction, a) {
+		return true
+	}
+	a.CObject.Init()
+	a.proxies = make([]Widget, 0)
+	a.proxyHandle = fmt.Sprintf("%v.action-proxy", a.ObjectName())
+	a.accelId = -1
+	_ = a.InstallBuildableProperty(PropertyLabel, cdk.StringProperty, true, "")
+	_ = a.InstallBuildableProperty(PropertyTooltip, cdk.StringProperty, true, "")
+	_ = a.InstallBuildableProperty(PropertyStockId, cdk.StringProperty, true, "")
+	_ = a.InstallBuildableProperty(PropertyAccelerator, cdk.StringProperty, true, "")
+	_ = a.InstallBuildableProperty(PropertyAccelPath, cdk.StringProperty, true, "")
+	_ = a.InstallBuildableProperty(PropertySensitive, cdk.BoolProperty, true, true)
+	_ = a.InstallBuildableProperty(PropertyVisible, cdk.BoolProperty, true, true)
+	return false
+}
+
+func (a *CAction) initAction(name string, label string, tooltip string, stockId StockID) {
+	if name != "" {
+		a.SetName(name)
+	}
+	a.SetLabel(label)
+	a.SetTooltip(tooltip)
+	a.SetStockId(stockId)
+}
+
+// Builds the Action from a Builder element. The "name" property sets the
+// name of the action, the id is used if not given.
+func (a *CAction) Build(builder Builder, element *CBuilderElement) error {
+	a.Freeze()
+	defer a.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		a.SetName(name)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyName:
+			a.SetName(v)
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// Returns the name of the action.
+func (a *CAction) GetName() (value string) {
+	return a.ObjectName()
+}
+
+// Returns whether the action is effectively sensitive.
+// Returns:
+// 	TRUE if the action and its associated action group are both
+// 	sensitive.
+func (a *CAction) IsSensitive() (value bool) {
+	if !a.GetSensitive() {
+		return false
+	}
+	if a.actionGroup != nil {
+		return a.actionGroup.GetSensitive()
+	}
+	return true
+}
+
+// Returns whether the action itself is sensitive. Note that this doesn't
+// necessarily mean effective sensitivity. See IsSensitive for that.
+func (a *CAction) GetSensitive() (value bool) {
+	var err error
+	if value, err = a.GetBoolProperty(PropertySensitive); err != nil {
+		a.LogErr(err)
+	}
+	return
+}
+
+// Sets the ::sensitive property of the action to sensitive . Note that this
+// doesn't necessarily mean effective sensitivity. See IsSensitive for that.
+// The proxies of the action are updated.
+// Parameters:
+// 	sensitive	TRUE to make the action sensitive
+func (a *CAction) SetSensitive(sensitive bool) {
+	if err := a.SetBoolProperty(PropertySensitive, sensitive); err != nil {
+		a.LogErr(err)
+	}
+	a.SyncProxies()
+}
+
+// Returns whether the action is effectively visible.
+// Returns:
+// 	TRUE if the action and its associated action group are both
+// 	visible.
+func (a *CAction) IsVisible() (value bool) {
+	if !a.GetVisible() {
+		return false
+	}
+	if a.actionGroup != nil {
+		return a.actionGroup.GetVisible()
+	}
+	return true
+}
+
+// Returns whether the action itself is visible. Note that this doesn't
+// necessarily mean effective visibility. See IsVisible for that.
+func (a *CAction) GetVisible() (value bool) {
+	var err error
+	if value, err = a.GetBoolProperty(PropertyVisible); err != nil {
+		a.LogErr(err)
+	}
+	return
+}
+
+// Sets the ::visible property of the action to visible . Note that this
+// doesn't necessarily mean effective visibility. See IsVisible for that.
+// The proxies of the action are updated.
+// Parameters:
+// 	visible	TRUE to make the action visible
+func (a *CAction) SetVisible(visible bool) {
+	if err := a.SetBoolProperty(PropertyVisible, visible); err != nil {
+		a.LogErr(err)
+	}
+	a.SyncProxies()
+}
+
+// Emits the "activate" signal on the specified action, if it is effectively
+// sensitive. This gets called by the proxy widgets when they get activated
+// and by the accelerator of the action.
+// Returns:
+// 	TRUE if the action was activated
+func (a *CAction) Activate() (value bool) {
+	if !a.IsSensitive() {
+		return false
+	}
+	a.Emit(SignalActivate, a)
+	return true
+}
+
+// Connects a widget to the action object as a proxy. Synchronises various
+// properties of the action with the widget (such as label text, tooltip,
+// sensitivity and visibility) and activates the action when the widget is
+// activated. Widgets with a SetLabel method, such as Button, display the
+// label of the action with mnemonics.
+// Parameters:
+// 	proxy	the proxy widget
+func (a *CAction) ConnectProxy(proxy Widget) {
+	for _, p := range a.proxies {
+		if p.ObjectID() == proxy.ObjectID() {
+			return
+		}
+	}
+	a.proxies = append(a.proxies, proxy)
+	proxy.Connect(SignalActivate, a.proxyHandle, func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+		a.Activate()
+		return cdk.EVENT_PASS
+	})
+	a.syncProxy(proxy)
+}
+
+// Disconnects a proxy widget from an action. Does not destroy the widget,
+// however.
+// Parameters:
+// 	proxy	the proxy widget
+func (a *CAction) DisconnectProxy(proxy Widget) {
+	for idx, p := range a.proxies {
+		if p.ObjectID() == proxy.ObjectID() {
+			_ = proxy.Disconnect(SignalActivate, a.proxyHandle)
+			a.proxies = append(a.proxies[:idx], a.proxies[idx+1:]...)
+			return
+		}
+	}
+}
+
+// Returns the proxy widgets for an action.
+func (a *CAction) GetProxies() (value []Widget) {
+	return append([]Widget{}, a.proxies...)
+}
+
+// Updates all proxies of the action with the current state of the action.
+// This is called by the setters of the action and need only be called
+// directly after changing the properties of the action otherwise.
+func (a *CAction) SyncProxies() {
+	for _, proxy := range a.proxies {
+		a.syncProxy(proxy)
+	}
+}
+
+func (a *CAction) syncProxy(proxy Widget) {
+	proxy.SetSensitive(a.IsSensitive())
+	if a.IsVisible() {
+		proxy.Show()
+	} else {
+		proxy.Hide()
+	}
+	if label := a.GetLabel(); label != "" {
+		if p, ok := proxy.(interface{ SetUseUnderline(useUnderline bool) }); ok {
+			p.SetUseUnderline(true)
+		}
+		if p, ok := proxy.(interface{ SetLabel(label string) }); ok {
+			p.SetLabel(label)
+		}
+	}
+	if tooltip := a.GetTooltip(); tooltip != "" {
+		proxy.SetTooltipText(tooltip)
+	}
+}
+
+// Installs the accelerator for action if action has an accel path and
+// group. See SetAccelPath and SetAccelGroup. Since multiple proxies may
+// independently trigger the installation of the accelerator, the action
+// installs the accelerator once.
+func (a *CAction) ConnectAccelerator() {
+	if a.accelId >= 0 {
+		return
+	}
+	accelPath := a.GetAccelPath()
+	if accelPath == "" || a.accelGroup == nil {
+		return
+	}
+	a.accelId = a.accelGroup.ConnectByPath(accelPath, func(argv ...interface{}) (handled bool) {
+		return a.Activate()
+	})
+}
+
+// Undoes the effect of ConnectAccelerator.
+func (a *CAction) DisconnectAccelerator() {
+	if a.accelId >= 0 && a.accelGroup != nil {
+		a.accelGroup.AccelDisconnect(a.accelId)
+	}
+	a.accelId = -1
+}
+
+// Returns the accel path for this action.
+func (a *CAction) GetAccelPath() (value string) {
+	var err error
+	if value, err = a.GetStringProperty(PropertyAccelPath); err != nil {
+		a.LogErr(err)
+	}
+	return
+}
+
+// Sets the accel path for this action. All proxy widgets associated with the
+// action will have this accel path, so that their accelerators are
+// consistent. An accelerator installed previously is reinstalled for the
+// new path.
+// Parameters:
+// 	accelPath	the accelerator path
+func (a *CAction) SetAccelPath(accelPath string) {
+	connected := a.accelId >= 0
+	a.DisconnectAccelerator()
+	if err := a.SetStringProperty(PropertyAccelPath, accelPath); err != nil {
+		a.LogErr(err)
+	}
+	if connected {
+		a.ConnectAccelerator()
+	}
+}
+
+// Returns the AccelGroup the accelerator of the action is installed in.
+func (a *CAction) GetAccelGroup() (value AccelGroup) {
+	return a.accelGroup
+}
+
+// Sets the AccelGroup in which the accelerator for this action will be
+// installed. An accelerator installed previously is moved to the new group.
+// Parameters:
+// 	accelGroup	a AccelGroup or nil
+func (a *CAction) SetAccelGroup(accelGroup AccelGroup) {
+	connected := a.accelId >= 0
+	a.DisconnectAccelerator()
+	a.accelGroup = accelGroup
+	if connected {
+		a.ConnectAccelerator()
+	}
+}
+
+// Returns the default accelerator of the action, as set with SetAccelerator.
+func (a *CAction) GetAccelerator() (value string) {
+	var err error
+	if value, err = a.GetStringProperty(PropertyAccelerator); err != nil {
+		a.LogErr(err)
+	}
+	return
+}
+
+// Sets the default accelerator of the action, in the format understood by
+// AccelGroup.AcceleratorParse such as "<Control>s". The accelerator is
+// registered with the AccelMap for the accel path of the action when the
+// action is added to an ActionGroup.
+// Parameters:
+// 	accelerator	the default accelerator
+func (a *CAction) SetAccelerator(accelerator string) {
+	if err := a.SetStringProperty(PropertyAccelerator, accelerator); err != nil {
+		a.LogErr(err)
+	}
+}
+
+// Gets the label text of action , or the label of the stock item of the
+// action if no label was set.
+func (a *CAction) GetLabel() (value string) {
+	var err error
+	if value, err = a.GetStringProperty(PropertyLabel); err != nil {
+		a.LogErr(err)
+	}
+	if value == "" {
+		if stockId := a.GetStockId(); stockId != "" {
+			if item := LookupStockItem(stockId); item != nil {
+				value = item.Label
+			}
+		}
+	}
+	return
+}
+
+// Sets the label of action, which may contain mnemonics. The proxies of the
+// action are updated.
+// Parameters:
+// 	label	the label text to set
+func (a *CAction) SetLabel(label string) {
+	if err := a.SetStringProperty(PropertyLabel, label); err != nil {
+		a.LogErr(err)
+	}
+	a.SyncProxies()
+}
+
+// Gets the tooltip text of action .
+func (a *CAction) GetTooltip() (value string) {
+	var err error
+	if value, err = a.GetStringProperty(PropertyTooltip); err != nil {
+		a.LogErr(err)
+	}
+	return
+}
+
+// Sets the tooltip text on action. The proxies of the action are updated.
+// Parameters:
+// 	tooltip	the tooltip text
+func (a *CAction) SetTooltip(tooltip string) {
+	if err := a.SetStringProperty(PropertyTooltip, tooltip); err != nil {
+		a.LogErr(err)
+	}
+	a.SyncProxies()
+}
+
+// Gets the stock id of action .
+func (a *CAction) GetStockId() (value StockID) {
+	if v, err := a.GetStringProperty(PropertyStockId); err != nil {
+		a.LogErr(err)
+	} else {
+		value = StockID(v)
+	}
+	return
+}
+
+// Sets the stock id on action. The label of the stock item is used if no
+// label is set. The proxies of the action are updated.
+// Parameters:
+// 	stockId	the stock id
+func (a *CAction) SetStockId(stockId StockID) {
+	if err := a.SetStringProperty(PropertyStockId, string(stockId)); err != nil {
+		a.LogErr(err)
+	}
+	a.SyncProxies()
+}
+
+// Returns the ActionGroup the action belongs to, or nil.
+func (a *CAction) GetActionGroup() (value ActionGroup) {
+	return a.actionGroup
+}
+
+// Sets the ActionGroup the action belongs to, which is done by
+// ActionGroup.AddAction and ActionGroup.RemoveAction. The proxies of the
+// action are updated, as the sensitivity and visibility of the group apply
+// to the action.
+// Parameters:
+// 	actionGroup	the action group, or nil
+func (a *CAction) SetActionGroup(actionGroup ActionGroup) {
+	a.actionGroup = actionGroup
+	a.SyncProxies()
+}
+
+// The "activate" signal is emitted when the action is activated.
+// const SignalActivate cdk.Signal = "activate"
+
+// A tooltip for this action.
+// Flags: Read / Write
+// Default value: NULL
+const PropertyTooltip cdk.Property = "tooltip"
+
+// The stock item of this action, providing the label of the action if none
+// is set.
+// Flags: Read / Write
+// Default value: NULL
+const PropertyStockId cdk.Property = "stock-id"
+
+// The default accelerator of the action, such as "<Control>s".
+// Flags: Read / Write
+// Default value: NULL
+const PropertyAccelerator cdk.Property = "accelerator"
+
+// The accel path used to look up the accelerator of the action in the
+// AccelMap.
+// Flags: Read / Write
+// Default value: NULL
+const PropertyAccelPath cdk.Property = "accel-path"
@@ -0,0 +1,262 @@
+package ctk
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for ActionGroup objects
+const TypeActionGroup cdk.CTypeTag = "ctk-action-group"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeActionGroup, func() interface{} { return MakeActionGroup() })
+}
+
+// ActionGroup Hierarchy:
+//	Object
+//	  +- ActionGroup
+//
+// Actions are organised into groups. An action group is essentially a map
+// from names to Action objects. All actions that would make sense to use in
+// a particular context should be in a single group. Multiple action groups
+// may be used for a particular user interface. The sensitivity and
+// visibility of the group apply to all of its actions.
+//
+// Each action of the group is given the accel path "<Actions>/group/action"
+// and its default accelerator is registered with the AccelMap. The
+// accelerators are installed in the AccelGroup of the action group, which is
+// to be added to the Window the actions are used in, see GetAccelGroup.
+type ActionGroup interface {
+	Object
+
+	Init() (already bool)
+	GetName() (value string)
+	GetSensitive() (value bool)
+	SetSensitive(sensitive bool)
+	GetVisible() (value bool)
+	SetVisible(visible bool)
+	GetAction(actionName string) (value Action)
+	ListActions() (value []Action)
+	AddAction(action Action)
+	AddActionWithAccel(action Action, accelerator string)
+	RemoveAction(action Action)
+	GetAccelGroup() (value AccelGroup)
+}
+
+// The CActionGroup structure implements the ActionGroup interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with ActionGroup objects
+type CActionGroup struct {
+	CObject
+
+	actions    map[string]Action
+	accelGroup AccelGroup
+}
+
+// Default constructor for ActionGroup objects
+func MakeActionGroup() *CActionGroup {
+	return NewActionGroup("")
+}
+
+// Creates a new ActionGroup object. The name of the action group is used
+// when associating keybindings with the actions.
+// Parameters:
+// 	name	the name of the action group.
+func NewActionGroup(name string) (value *CActionGroup) {
+	g := new(CActionGroup)
+	g.Init()
+	if name != "" {
+		g.SetName(name)
+	}
+	return g
+}
+
+// ActionGroup object initialization. This must be called at least once to
+// setup the necessary defaults and allocate any memory structures. Calling this
+// more than once is safe though unnecessary. Only the first call will result in
+// any effect upon the ActionGroup instance
+func (g *CActionGroup) Init() (already bool) {
+	if g.InitTypeItem(TypeActionGroup, g) {
+		return true
+	}
+	g.CObject.Init()
+	g.actions = make(map[string]Action)
+	_ = g.InstallBuildableProperty(PropertySensitive, cdk.BoolProperty, true, true)
+	_ = g.InstallBuildableProperty(PropertyVisible, cdk.BoolProperty, true, true)
+	return false
+}
+
+// Builds the ActionGroup from a Builder element, adding the actions built
+// from the child elements. The accelerator of each action is given either
+// with the "accelerator" property of the action or with an accelerator tag
+// next to the action, as in GTK interface files.
+func (g *CActionGroup) Build(builder Builder, element *CBuilderElement) error {
+	g.Freeze()
+	defer g.Thaw()
+	if err := g.CObject.Build(builder, element); err != nil {
+		return err
+	}
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			if action, ok := newChild.(Action); ok {
+				g.AddAction(action)
+			} else {
+				g.LogError("new child object is not an Action type: %v (%T)", newChild, newChild)
+			}
+		}
+	}
+	return nil
+}
+
+// Gets the name of the action group.
+func (g *CActionGroup) GetName() (value string) {
+	return g.ObjectName()
+}
+
+// Returns TRUE if the group is sensitive. The constituent actions can only
+// be logically sensitive (see Action.IsSensitive) if they are sensitive
+// (see Action.GetSensitive) and their group is sensitive.
+func (g *CActionGroup) GetSensitive() (value bool) {
+	var err error
+	if value, err = g.GetBoolProperty(PropertySensitive); err != nil {
+		g.LogErr(err)
+	}
+	return
+}
+
+// Changes the sensitivity of action_group , updating the proxies of all of
+// its actions.
+// Parameters:
+// 	sensitive	new sensitivity
+func (g *CActionGroup) SetSensitive(sensitive bool) {
+	if err := g.SetBoolProperty(PropertySensitive, sensitive); err != nil {
+		g.LogErr(err)
+	}
+	g.syncActions()
+}
+
+// Returns TRUE if the group is visible. The constituent actions can only be
+// logically visible (see Action.IsVisible) if they are visible (see
+// Action.GetVisible) and their group is visible.
+func (g *CActionGroup) GetVisible() (value bool) {
+	var err error
+	if value, err = g.GetBoolProperty(PropertyVisible); err != nil {
+		g.LogErr(err)
+	}
+	return
+}
+
+// Changes the visible of action_group , updating the proxies of all of its
+// actions.
+// Parameters:
+// 	visible	new visiblity
+func (g *CActionGroup) SetVisible(visible bool) {
+	if err := g.SetBoolProperty(PropertyVisible, visible); err != nil {
+		g.LogErr(err)
+	}
+	g.syncActions()
+}
+
+// Looks up an action in the action group by name.
+// Parameters:
+// 	actionName	the name of the action
+// Returns:
+// 	the action, or nil if no action by that name exists
+func (g *CActionGroup) GetAction(actionName string) (value Action) {
+	value, _ = g.actions[actionName]
+	return
+}
+
+// Lists the actions in the action group, sorted by name.
+func (g *CActionGroup) ListActions() (value []Action) {
+	var names []string
+	for name := range g.actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value = append(value, g.actions[name])
+	}
+	return
+}
+
+// Adds an action object to the action group, using the accelerator set with
+// Action.SetAccelerator as default accelerator. Note that this function does
+// not set up the accel path of the action when the action already has one.
+// Parameters:
+// 	action	an action
+func (g *CActionGroup) AddAction(action Action) {
+	name := action.GetName()
+	if existing, ok := g.actions[name]; ok {
+		if existing.ObjectID() == action.ObjectID() {
+			return
+		}
+		g.LogError("refusing to add non-unique action %q to action group %q", name, g.GetName())
+		return
+	}
+	g.actions[name] = action
+	action.SetActionGroup(g)
+	accelPath := action.GetAccelPath()
+	if accelPath == "" {
+		accelPath = fmt.Sprintf("<Actions>/%v/%v", g.GetName(), name)
+		action.SetAccelPath(accelPath)
+	}
+	var key cdk.Key
+	var mods cdk.ModMask
+	if accelerator := action.GetAccelerator(); accelerator != "" {
+		if key, mods = accelParse(accelerator); key == 0 {
+			g.LogError("invalid accelerator %q for action %q", accelerator, name)
+		}
+	}
+	getAccelMap().AddEntry(accelPath, key, mods)
+	action.SetAccelGroup(g.GetAccelGroup())
+	action.ConnectAccelerator()
+}
+
+// Adds an action object to the action group and sets up the accelerator. If
+// accelerator is empty, the accelerator set with Action.SetAccelerator is
+// used. Accel paths are set to "<Actions>/group-name/action-name".
+// Parameters:
+// 	action	the action to add
+// 	accelerator	the accelerator for the action, in the format
+// understood by AccelGroup.AcceleratorParse, or empty
+func (g *CActionGroup) AddActionWithAccel(action Action, accelerator string) {
+	if accelerator != "" {
+		action.SetAccelerator(accelerator)
+	}
+	g.AddAction(action)
+}
+
+// Removes an action object from the action group, uninstalling its
+// accelerator.
+// Parameters:
+// 	action	an action
+func (g *CActionGroup) RemoveAction(action Action) {
+	name := action.GetName()
+	if existing, ok := g.actions[name]; ok && existing.ObjectID() == action.ObjectID() {
+		delete(g.actions, name)
+		action.DisconnectAccelerator()
+		action.SetAccelGroup(nil)
+		action.SetActionGroup(nil)
+	}
+}
+
+// Returns the AccelGroup the accelerators of the actions are installed in,
+// to be added to the Window the actions are used in with
+// Window.AddAccelGroup.
+func (g *CActionGroup) GetAccelGroup() (value AccelGroup) {
+	if g.accelGroup == nil {
+		g.accelGroup = NewAccelGroup()
+	}
+	return g.accelGroup
+}
+
+func (g *CActionGroup) syncActions() {
+	for _, action := range g.ListActions() {
+		action.SyncProxies()
+	}
+}
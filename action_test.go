@@ -0,0 +1,115 @@
+package ctk
+
+import (
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAction(t *testing.T) {
+	Convey("Testing Actions", t, func() {
+		Convey("proxies and accelerators", func() {
+			group := NewActionGroup("action-test")
+			action := NewAction("save", "_Save", "save the file", "")
+			activated := 0
+			action.Connect(SignalActivate, "action-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				activated++
+				return cdk.EVENT_PASS
+			})
+			group.AddActionWithAccel(action, "<Control>s")
+			So(group.GetAction("save"), ShouldEqual, action)
+			So(action.GetAccelPath(), ShouldEqual, "<Actions>/action-test/save")
+			window := NewWindowWithTitle("actions")
+			window.AddAccelGroup(group.GetAccelGroup())
+			button := NewButtonWithLabel("button")
+			window.Add(button)
+			action.ConnectProxy(button)
+			So(button.GetLabel(), ShouldEqual, "_Save")
+			So(button.GetTooltipText(), ShouldEqual, "save the file")
+			button.Activate()
+			So(activated, ShouldEqual, 1)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlS, 0, cdk.ModCtrl)), ShouldEqual, cdk.EVENT_STOP)
+			So(activated, ShouldEqual, 2)
+			group.SetSensitive(false)
+			So(action.IsSensitive(), ShouldBeFalse)
+			So(button.IsSensitive(), ShouldBeFalse)
+			button.Activate()
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlS, 0, cdk.ModCtrl))
+			So(activated, ShouldEqual, 2)
+			group.SetSensitive(true)
+			So(button.IsSensitive(), ShouldBeTrue)
+			action.SetLabel("Save _As")
+			So(button.GetLabel(), ShouldEqual, "Save _As")
+			action.DisconnectProxy(button)
+			button.Activate()
+			So(activated, ShouldEqual, 2)
+			group.RemoveAction(action)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlS, 0, cdk.ModCtrl)), ShouldEqual, cdk.EVENT_PASS)
+			So(activated, ShouldEqual, 2)
+		})
+		Convey("stock items", func() {
+			action := NewAction("quit", "", "", StockQuit)
+			So(action.GetLabel(), ShouldNotBeEmpty)
+			So(action.GetStockId(), ShouldEqual, StockQuit)
+		})
+		Convey("toggle and radio actions", func() {
+			toggle := NewToggleAction("wrap", "_Wrap", "", "")
+			toggled := 0
+			toggle.Connect(SignalToggled, "action-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				toggled++
+				return cdk.EVENT_PASS
+			})
+			toggle.Activate()
+			So(toggle.GetActive(), ShouldBeTrue)
+			toggle.Activate()
+			So(toggle.GetActive(), ShouldBeFalse)
+			So(toggled, ShouldEqual, 2)
+			left := NewRadioAction("left", "_Left", "", "", 1)
+			right := NewRadioAction("right", "_Right", "", "", 2)
+			right.JoinGroup(left)
+			So(left.GetGroup(), ShouldHaveLength, 2)
+			left.Activate()
+			So(left.GetCurrentValue(), ShouldEqual, 1)
+			right.Activate()
+			So(left.GetActive(), ShouldBeFalse)
+			So(right.GetActive(), ShouldBeTrue)
+			So(left.GetCurrentValue(), ShouldEqual, 2)
+			right.Activate()
+			So(right.GetActive(), ShouldBeTrue)
+			left.SetCurrentValue(1)
+			So(left.GetActive(), ShouldBeTrue)
+			So(right.GetActive(), ShouldBeFalse)
+		})
+		Convey("builder references", func() {
+			builder := NewBuilder()
+			top, err := builder.LoadFromString(`<interface>
+  <object class="GtkActionGroup" id="builder-actions">
+    <child>
+      <object class="GtkAction" id="builder-open">
+        <property name="label">_Open</property>
+        <property name="sensitive">False</property>
+      </object>
+      <accelerator key="o" modifiers="GDK_CONTROL_MASK"/>
+    </child>
+  </object>
+  <object class="GtkButton" id="builder-open-button">
+    <property name="related_action">builder-open</property>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			group, ok := top.Children[0].Instance.(*CActionGroup)
+			So(ok, ShouldBeTrue)
+			action, ok := builder.GetWidget("builder-open").(Action)
+			So(ok, ShouldBeTrue)
+			So(group.GetAction("builder-open"), ShouldEqual, action)
+			So(action.GetAccelerator(), ShouldEqual, "<Control>o")
+			button, ok := top.Children[1].Instance.(*CButton)
+			So(ok, ShouldBeTrue)
+			So(button.GetLabel(), ShouldEqual, "_Open")
+			So(button.IsSensitive(), ShouldBeFalse)
+			action.SetSensitive(true)
+			So(button.IsSensitive(), ShouldBeTrue)
+		})
+	})
+}
@@ -157,16 +157,16 @@ func (a *CAlignment) SetPadding(paddingTop, paddingBottom, paddingLeft, paddingR
 	t, b, l, r := a.GetPadding()
 	if t != paddingTop || b != paddingBottom || l != paddingLeft || r != paddingRight {
 		a.Freeze()
-		if err := a.SetIntProperty(PropertyXAlign, paddingTop); err != nil {
+		if err := a.SetIntProperty(PropertyTopPadding, paddingTop); err != nil {
 			a.LogErr(err)
 		}
-		if err := a.SetIntProperty(PropertyYAlign, paddingBottom); err != nil {
+		if err := a.SetIntProperty(PropertyBottomPadding, paddingBottom); err != nil {
 			a.LogErr(err)
 		}
-		if err := a.SetIntProperty(PropertyXScale, paddingLeft); err != nil {
+		if err := a.SetIntProperty(PropertyLeftPadding, paddingLeft); err != nil {
 			a.LogErr(err)
 		}
-		if err := a.SetIntProperty(PropertyYScale, paddingRight); err != nil {
+		if err := a.SetIntProperty(PropertyRightPadding, paddingRight); err != nil {
 			a.LogErr(err)
 		}
 		a.Thaw()
@@ -174,6 +174,14 @@ func (a *CAlignment) SetPadding(paddingTop, paddingBottom, paddingLeft, paddingR
 	}
 }
 
+// the alignment padding properties are added to any css padding
+func (a *CAlignment) getStyleBox() (box styleBox) {
+	box = a.CBin.getStyleBox()
+	top, bottom, left, right := a.GetPadding()
+	box.padding = box.padding.Add(StyleSides{Top: top, Right: right, Bottom: bottom, Left: left})
+	return
+}
+
 func (a *CAlignment) Add(w Widget) {
 	a.CBin.Add(w)
 	w.Connect(SignalLostFocus, a.aFCHandle, a.handleLostFocus)
@@ -188,6 +196,21 @@ func (a *CAlignment) Remove(w Widget) {
 	a.Invalidate()
 }
 
+func (a *CAlignment) GetSizeRequest() (width, height int) {
+	size := cdk.NewRectangle(a.CWidget.GetSizeRequest())
+	if child := a.GetChild(); child != nil {
+		childSize := cdk.NewRectangle(child.GetSizeRequest())
+		insets := a.getStyleBox().insets()
+		if size.W <= -1 && childSize.W > -1 {
+			size.W = insets.Width() + childSize.W
+		}
+		if size.H <= -1 && childSize.H > -1 {
+			size.H = insets.Height() + childSize.H
+		}
+	}
+	return size.W, size.H
+}
+
 func (a *CAlignment) Invalidate() cdk.EventFlag {
 	theme := a.GetThemeRequest()
 	style := theme.Content.Normal
@@ -223,6 +246,11 @@ func (a *CAlignment) Resize() cdk.EventFlag {
 	if child := a.GetChild(); child != nil {
 		xAlign, yAlign, xScale, yScale := a.Get()
 		origin := a.GetOrigin()
+		// the child is aligned within the content area of the style box
+		local, content := a.getStyleBox().fit(alloc).content(alloc)
+		origin.X += local.X
+		origin.Y += local.Y
+		alloc = content
 		size := cdk.NewRectangle(child.GetSizeRequest())
 		if size.W <= -1 {
 			size.W = alloc.W
@@ -271,7 +299,7 @@ func (a *CAlignment) Draw(canvas cdk.Canvas) cdk.EventFlag {
 	boxOrigin := cdk.MakePoint2I(0, 0)
 	boxSize := alloc
 
-	canvas.BoxWithTheme(boxOrigin, boxSize, false, true, theme)
+	a.getStyleBox().fit(alloc).draw(canvas, a.GetStyle().GetEngine(), boxOrigin, boxSize, true, theme)
 
 	if child := a.GetChild(); child != nil {
 		child.Draw(a.canvas)
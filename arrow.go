@@ -119,14 +119,14 @@ func (a *CArrow) GetArrowRune() (r rune, width int) {
 func (a *CArrow) GetSizeRequest() (width, height int) {
 	size := cdk.NewRectangle(a.CWidget.GetSizeRequest())
 	_, runeWidth := a.GetArrowRune()
-	xPad, yPad := a.GetPadding()
+	insets := a.getStyleBox().insets()
 	if size.W <= -1 {
 		size.W = runeWidth // variable width rune supported
-		size.W += xPad * 2
+		size.W += insets.Width()
 	}
 	if size.H <= -1 {
 		size.H = 1 // always one high
-		size.H += yPad * 2
+		size.H += insets.Height()
 	}
 	size.Floor(1, 1)
 	return size.W, size.H
@@ -141,22 +141,20 @@ func (a *CArrow) Draw(canvas cdk.Canvas) cdk.EventFlag {
 		return cdk.EVENT_PASS
 	}
 	xAlign, yAlign := a.GetAlignment()
-	xPad, yPad := a.GetPadding()
-	size := cdk.MakeRectangle(alloc.W-(xPad*2), alloc.H-(yPad*2))
-	r, _ := a.GetArrowRune()
-	point := cdk.MakePoint2I(xPad, yPad)
-	if size.W < alloc.W {
-		delta := alloc.W - size.W
-		point.X = int(float64(delta) * xAlign)
+	theme := a.GetThemeRequest()
+	box := a.getStyleBox().fit(alloc)
+	if box.border != (StyleSides{}) {
+		box.draw(canvas, a.GetStyle().GetEngine(), cdk.MakePoint2I(0, 0), alloc, false, theme)
 	}
-	if size.H < alloc.H {
-		delta := alloc.H - size.H
-		point.Y = int(float64(delta) * yAlign)
+	point, content := box.content(alloc)
+	_, runeWidth := a.GetArrowRune()
+	if delta := content.W - runeWidth; delta > 0 {
+		point.X += int(float64(delta) * xAlign)
 	}
-	style := a.GetThemeRequest().Content.Normal
-	if err := canvas.SetRune(point.X, point.Y, r, style); err != nil {
-		a.LogError("set rune error: %v", err)
+	if delta := content.H - 1; delta > 0 {
+		point.Y += int(float64(delta) * yAlign)
 	}
+	a.GetStyle().PaintArrow(canvas, a.GetState(), SHADOW_NONE, cdk.Rectangle{}, a, "arrow", a.GetArrowType(), true, point.X, point.Y, 1, 1)
 	if debug, _ := a.GetBoolProperty(cdk.PropertyDebug); debug {
 		canvas.DebugBox(cdk.ColorSilver, a.ObjectInfo())
 	}
@@ -0,0 +1,348 @@
+package ctk
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/kckrinke/go-cdk"
+	"github.com/kckrinke/go-cdk/utils"
+)
+
+// CDK type-tag for AspectFrame objects
+const TypeAspectFrame cdk.CTypeTag = "ctk-aspect-frame"
+
+// The width to height ratio of a single character cell, used when converting
+// between cell counts and visual aspect ratios
+const CellAspectRatio float64 = 0.5
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeAspectFrame, func() interface{} { return MakeAspectFrame() })
+	ctkBuilderTranslators[TypeAspectFrame] = func(builder Builder, widget Widget, name, value string) error {
+		switch name {
+		case "xalign", "x-align", "yalign", "y-align", "ratio":
+			if v, err := strconv.ParseFloat(value, 64); err != nil {
+				return err
+			} else if af, ok := widget.(AspectFrame); ok {
+				xAlign, yAlign, ratio, obeyChild := af.GetSettings()
+				switch name {
+				case "xalign", "x-align":
+					xAlign = v
+				case "yalign", "y-align":
+					yAlign = v
+				default:
+					ratio = v
+				}
+				af.Set(xAlign, yAlign, ratio, obeyChild)
+			}
+			return nil
+		case "obey-child":
+			if af, ok := widget.(AspectFrame); ok {
+				xAlign, yAlign, ratio, _ := af.GetSettings()
+				af.Set(xAlign, yAlign, ratio, utils.IsTrue(value))
+			}
+			return nil
+		}
+		return ErrFallthrough
+	}
+}
+
+// AspectFrame Hierarchy:
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Frame
+//	          +- AspectFrame
+//
+// The AspectFrame is useful when you want pack a widget so that it can
+// resize but always retains the same aspect ratio. For instance, one might
+// be drawing a small preview of a larger image. AspectFrame derives from
+// Frame, so it can draw a label and a frame around the child. The frame
+// will be "shrink-wrapped" to the size of the child. As terminal character
+// cells are roughly twice as tall as they are wide, the ratio is a visual
+// ratio and not a ratio of cell counts; a ratio of 1.0 results in a child
+// which is twice as many cells wide as it is high.
+type AspectFrame interface {
+	Frame
+
+	Init() (already bool)
+	Set(xAlign float64, yAlign float64, ratio float64, obeyChild bool)
+	GetSettings() (xAlign, yAlign, ratio float64, obeyChild bool)
+	Resize() cdk.EventFlag
+	Draw(canvas cdk.Canvas) cdk.EventFlag
+}
+
+// The CAspectFrame structure implements the AspectFrame interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with AspectFrame objects
+type CAspectFrame struct {
+	CFrame
+
+	boxOrigin cdk.Point2I
+	boxSize   cdk.Rectangle
+}
+
+// Default constructor for AspectFrame objects
+func MakeAspectFrame() *CAspectFrame {
+	return NewAspectFrame("", 0.5, 0.5, 1.0, false)
+}
+
+// Create a new AspectFrame.
+// Parameters:
+// 	label	Label text.
+// 	xAlign	Horizontal alignment of the child within the allocation of the
+// 	        AspectFrame. This ranges from 0.0 (left aligned) to 1.0 (right aligned)
+// 	yAlign	Vertical alignment of the child within the allocation of the
+// 	        AspectFrame. This ranges from 0.0 (top aligned) to 1.0 (bottom aligned)
+// 	ratio	The desired aspect ratio.
+// 	obeyChild	If TRUE, ratio is ignored, and the aspect ratio is taken
+// 	            from the requistion of the child.
+// Returns:
+// 	the new AspectFrame.
+func NewAspectFrame(label string, xAlign, yAlign, ratio float64, obeyChild bool) *CAspectFrame {
+	f := new(CAspectFrame)
+	f.Init()
+	l := NewLabel(label)
+	l.SetSingleLineMode(true)
+	l.SetLineWrap(false)
+	l.SetLineWrapMode(cdk.WRAP_NONE)
+	l.SetJustify(cdk.JUSTIFY_LEFT)
+	l.Show()
+	f.SetLabelWidget(l)
+	f.Set(xAlign, yAlign, ratio, obeyChild)
+	return f
+}
+
+// AspectFrame object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the AspectFrame instance
+func (f *CAspectFrame) Init() (already bool) {
+	if f.InitTypeItem(TypeAspectFrame, f) {
+		return true
+	}
+	f.CFrame.Init()
+	_ = f.InstallBuildableProperty(PropertyAspectObeyChild, cdk.BoolProperty, true, true)
+	_ = f.InstallBuildableProperty(PropertyAspectRatio, cdk.FloatProperty, true, 1.0)
+	_ = f.InstallBuildableProperty(PropertyAspectXAlign, cdk.FloatProperty, true, 0.5)
+	_ = f.InstallBuildableProperty(PropertyAspectYAlign, cdk.FloatProperty, true, 0.5)
+	return false
+}
+
+// Set parameters for an existing AspectFrame.
+// Parameters:
+// 	xAlign	Horizontal alignment of the child within the allocation of the
+// 	        AspectFrame. This ranges from 0.0 (left aligned) to 1.0 (right aligned)
+// 	yAlign	Vertical alignment of the child within the allocation of the
+// 	        AspectFrame. This ranges from 0.0 (top aligned) to 1.0 (bottom aligned)
+// 	ratio	The desired aspect ratio.
+// 	obeyChild	If TRUE, ratio is ignored, and the aspect ratio is taken
+// 	            from the requistion of the child.
+func (f *CAspectFrame) Set(xAlign float64, yAlign float64, ratio float64, obeyChild bool) {
+	xAlign = utils.ClampF(xAlign, 0.0, 1.0)
+	yAlign = utils.ClampF(yAlign, 0.0, 1.0)
+	ratio = utils.ClampF(ratio, 0.0001, 10000.0)
+	f.Freeze()
+	if err := f.SetFloatProperty(PropertyAspectXAlign, xAlign); err != nil {
+		f.LogErr(err)
+	}
+	if err := f.SetFloatProperty(PropertyAspectYAlign, yAlign); err != nil {
+		f.LogErr(err)
+	}
+	if err := f.SetFloatProperty(PropertyAspectRatio, ratio); err != nil {
+		f.LogErr(err)
+	}
+	if err := f.SetBoolProperty(PropertyAspectObeyChild, obeyChild); err != nil {
+		f.LogErr(err)
+	}
+	f.Thaw()
+	f.Resize()
+}
+
+// Returns the current alignment, ratio and obey-child settings.
+func (f *CAspectFrame) GetSettings() (xAlign, yAlign, ratio float64, obeyChild bool) {
+	var err error
+	if xAlign, err = f.GetFloatProperty(PropertyAspectXAlign); err != nil {
+		f.LogErr(err)
+	}
+	if yAlign, err = f.GetFloatProperty(PropertyAspectYAlign); err != nil {
+		f.LogErr(err)
+	}
+	if ratio, err = f.GetFloatProperty(PropertyAspectRatio); err != nil {
+		f.LogErr(err)
+	}
+	if obeyChild, err = f.GetBoolProperty(PropertyAspectObeyChild); err != nil {
+		f.LogErr(err)
+	}
+	return
+}
+
+// returns the visual aspect ratio to use, taking obey-child into account
+func (f *CAspectFrame) getRatio() (ratio float64) {
+	_, _, ratio, obeyChild := f.GetSettings()
+	if obeyChild {
+		ratio = 1.0
+		if child := f.GetChild(); child != nil {
+			w, h := child.GetSizeRequest()
+			if w > 0 && h > 0 {
+				ratio = (float64(w) * CellAspectRatio) / float64(h)
+			}
+		}
+	}
+	return
+}
+
+// Returns the number of columns and rows, fitting within the given cell
+// dimensions, which most closely match the given visual aspect ratio.
+func AspectCellSize(ratio float64, maxW, maxH int) (w, h int) {
+	if maxW <= 0 || maxH <= 0 || ratio <= 0 {
+		return 0, 0
+	}
+	h = maxH
+	w = int(math.Round(ratio * float64(h) / CellAspectRatio))
+	if w > maxW {
+		w = maxW
+		h = int(math.Round(float64(w) * CellAspectRatio / ratio))
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	if h > maxH {
+		h = maxH
+	}
+	return
+}
+
+func (f *CAspectFrame) Resize() cdk.EventFlag {
+	f.Lock()
+	alloc := f.GetAllocation()
+	origin := f.GetOrigin()
+	label, _ := f.GetLabelWidget().(Label)
+	if alloc.W <= 0 || alloc.H <= 0 {
+		if label != nil {
+			label.SetAllocation(cdk.MakeRectangle(0, 0))
+			label.Resize()
+		}
+		if child := f.GetChild(); child != nil {
+			child.SetAllocation(cdk.MakeRectangle(0, 0))
+			child.Resize()
+		}
+		f.Unlock()
+		return cdk.EVENT_PASS
+	}
+	xAlign, yAlign, _, _ := f.GetSettings()
+	labelXAlign, labelYAlign := f.GetLabelAlign()
+	// space consumed by the border and label placement
+	extra := 0
+	if labelYAlign <= 0.0 || labelYAlign >= 1.0 {
+		extra = 1
+	}
+	box := f.getStyleBox().fit(alloc)
+	insets := box.insets()
+	edge := box.margin.Add(box.border)
+	avail := cdk.MakeRectangle(alloc.W-insets.Width(), alloc.H-insets.Height()-extra)
+	avail.Floor(0, 0)
+	cols, rows := AspectCellSize(f.getRatio(), avail.W, avail.H)
+	offset := cdk.MakePoint2I(
+		int(float64(avail.W-cols)*xAlign),
+		int(float64(avail.H-rows)*yAlign),
+	)
+	f.boxOrigin = cdk.MakePoint2I(offset.X, offset.Y)
+	f.boxSize = cdk.MakeRectangle(cols+insets.Width(), rows+insets.Height())
+	childOrigin := cdk.MakePoint2I(origin.X+offset.X+insets.Left, origin.Y+offset.Y+insets.Top)
+	labelOrigin := cdk.MakePoint2I(origin.X+offset.X+edge.Left+1, origin.Y+offset.Y+box.margin.Top)
+	if labelYAlign <= 0.0 {
+		labelYAlign = 0.0
+		f.boxOrigin.Y += 1
+		childOrigin.Y += 1
+	} else if labelYAlign >= 1.0 {
+		labelYAlign = 1.0
+		f.boxSize.H += 1
+		labelOrigin.Y += 1
+		childOrigin.Y += 1
+	} else {
+		labelYAlign = 0.5
+	}
+	if label != nil {
+		labelAlloc := cdk.MakeRectangle(f.boxSize.W-edge.Width()-2, 1)
+		labelAlloc.Floor(0, 0)
+		label.SetAlignment(labelXAlign, labelYAlign)
+		label.SetMaxWidthChars(labelAlloc.W)
+		label.SetOrigin(labelOrigin.X, labelOrigin.Y)
+		label.SetAllocation(labelAlloc)
+		label.Resize()
+	}
+	if child := f.GetChild(); child != nil {
+		child.SetOrigin(childOrigin.X, childOrigin.Y)
+		child.SetAllocation(cdk.MakeRectangle(cols, rows))
+		child.Resize()
+	}
+	f.Unlock()
+	return f.Invalidate()
+}
+
+func (f *CAspectFrame) Draw(canvas cdk.Canvas) cdk.EventFlag {
+	f.Lock()
+	defer f.Unlock()
+	alloc := f.GetAllocation()
+	if !f.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+		f.LogTrace("AspectFrame.Draw(): not visible, zero width or zero height")
+		return cdk.EVENT_PASS
+	}
+	child := f.GetChild()
+	theme := f.GetThemeRequest()
+	if child != nil {
+		theme = child.GetThemeRequest()
+	}
+	canvas.Fill(f.GetTheme())
+	f.getStyleBox().fit(alloc).draw(canvas, f.GetStyle().GetEngine(), f.boxOrigin, f.boxSize, true, theme)
+	if widget := f.GetLabelWidget(); widget != nil {
+		if label, ok := widget.(Label); ok {
+			if label.GetTheme().String() != theme.String() {
+				label.SetTheme(theme)
+				label.Invalidate()
+			}
+			label.Draw(f.labelCanvas)
+			if err := canvas.Composite(f.labelCanvas); err != nil {
+				f.LogError("composite error: %v", err)
+			}
+		}
+	}
+	if child != nil {
+		child.Draw(f.canvas)
+		if err := canvas.Composite(f.canvas); err != nil {
+			f.LogError("composite error: %v", err)
+		}
+	}
+	if debug, _ := f.GetBoolProperty(cdk.PropertyDebug); debug {
+		canvas.DebugBox(cdk.ColorSilver, f.ObjectInfo())
+	}
+	return cdk.EVENT_STOP
+}
+
+// Force aspect ratio to match that of the frame's child.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyAspectObeyChild cdk.Property = "obey-child"
+
+// Aspect ratio if obey_child is FALSE.
+// Flags: Read / Write
+// Allowed values: [0.0001,10000]
+// Default value: 1
+const PropertyAspectRatio cdk.Property = "ratio"
+
+// X alignment of the child.
+// Flags: Read / Write
+// Allowed values: [0,1]
+// Default value: 0.5
+const PropertyAspectXAlign cdk.Property = "xalign"
+
+// Y alignment of the child.
+// Flags: Read / Write
+// Allowed values: [0,1]
+// Default value: 0.5
+const PropertyAspectYAlign cdk.Property = "yalign"
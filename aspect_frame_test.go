@@ -0,0 +1,41 @@
+package ctk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAspectFrame(t *testing.T) {
+	Convey("Testing AspectFrames", t, func() {
+		Convey("cell sizes", func() {
+			w, h := AspectCellSize(1.0, 40, 10)
+			So(w, ShouldEqual, 20)
+			So(h, ShouldEqual, 10)
+			w, h = AspectCellSize(1.0, 10, 10)
+			So(w, ShouldEqual, 10)
+			So(h, ShouldEqual, 5)
+			w, h = AspectCellSize(2.0, 100, 10)
+			So(w, ShouldEqual, 40)
+			So(h, ShouldEqual, 10)
+			w, h = AspectCellSize(1.0, 0, 10)
+			So(w, ShouldEqual, 0)
+			So(h, ShouldEqual, 0)
+		})
+		Convey("settings", func() {
+			f := NewAspectFrame("test", 0.0, 1.0, 2.0, false)
+			So(f, ShouldNotBeNil)
+			xAlign, yAlign, ratio, obeyChild := f.GetSettings()
+			So(xAlign, ShouldEqual, 0.0)
+			So(yAlign, ShouldEqual, 1.0)
+			So(ratio, ShouldEqual, 2.0)
+			So(obeyChild, ShouldBeFalse)
+			f.Set(2.0, -1.0, 0.0, true)
+			xAlign, yAlign, ratio, obeyChild = f.GetSettings()
+			So(xAlign, ShouldEqual, 1.0)
+			So(yAlign, ShouldEqual, 0.0)
+			So(ratio, ShouldEqual, 0.0001)
+			So(obeyChild, ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,605 @@
+package ctk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/scanner"
+	"unicode"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// BindingSet maps key combinations to the signals emitted upon the Widgets
+// the set applies to. Each entry of a BindingSet binds a key and modifiers,
+// as in "<Control>n", to one or more signals along with the arguments of
+// each signal. When a key is pressed, the binding sets of the focused Widget
+// are activated and then those of each of its ancestors, up to and including
+// the Window. A binding is handled when any of the signals emitted returns
+// EVENT_STOP, otherwise the search continues so that the same key may be
+// bound to the signals of different Widget types.
+//
+// The binding sets of a Widget are, in order of precedence:
+//
+// 	- the sets named by the "key-bindings" css property of the Widget
+// 	- the set named by the "ctk-key-theme-name" Settings, see SetKeyThemeName
+// 	- the set of the Widget type, see BindingSetByType
+//
+// Binding sets are defined in css with the @binding-set at-rule and are
+// applied to Widgets with the "key-bindings" property:
+//
+// 	@binding-set pager {
+// 	  bind "<Control>n" { "scroll-child" (step-down, false) };
+// 	  bind "space" { "scroll-child" (page-down, false) };
+// 	  unbind "<Control>p";
+// 	}
+// 	ScrolledViewport { key-bindings: pager; }
+//
+// An "unbind" statement stops the search for the key, so that the bindings of
+// sets with a lower precedence are ignored. Arguments are numbers, quoted
+// strings, true or false and the nicknames of the MovementStep, ScrollType
+// and DirectionType enumerations, as in "display-lines", "page-up" and
+// "tab-forward".
+//
+// Two key themes are provided, "emacs" and "vi", which bind the emacs and vi
+// style navigation keys to the "move-cursor", "move-slider" and
+// "scroll-child" signals.
+type BindingSet struct {
+	Name string
+
+	entries map[bindingKey]*bindingEntry
+	parent  *BindingSet
+	lock    *sync.RWMutex
+}
+
+// BindingSignal is a signal emitted by a binding, along with the arguments
+// given to the signal listeners after the Object the signal is emitted upon
+type BindingSignal struct {
+	Signal cdk.Signal
+	Args   []interface{}
+}
+
+type bindingKey struct {
+	key  cdk.Key
+	mods cdk.ModMask
+}
+
+type bindingEntry struct {
+	signals []*BindingSignal
+	skip    bool
+}
+
+var (
+	bindingSets     = make(map[string]*BindingSet)
+	bindingSetsLock = &sync.RWMutex{}
+)
+
+// NewBindingSet creates a new BindingSet with the given name, registered for
+// use with the "key-bindings" css property and as key theme. Any BindingSet
+// previously registered with the same name is replaced.
+func NewBindingSet(name string) (set *BindingSet) {
+	set = newBindingSet(name)
+	bindingSetsLock.Lock()
+	bindingSets[name] = set
+	bindingSetsLock.Unlock()
+	return
+}
+
+// FindBindingSet returns the registered BindingSet with the given name, or
+// nil if there is none.
+func FindBindingSet(name string) (set *BindingSet) {
+	bindingSetsLock.RLock()
+	defer bindingSetsLock.RUnlock()
+	set, _ = bindingSets[name]
+	return
+}
+
+// BindingSetByType returns the BindingSet of the Widget type with the given
+// tag, registered by the name of the tag. The set is created when the type has
+// no bindings yet. The bindings of a type apply to the Objects of that type
+// only, not to types embedding it, see SetParent.
+func BindingSetByType(tag cdk.CTypeTag) (set *BindingSet) {
+	bindingSetsLock.Lock()
+	defer bindingSetsLock.Unlock()
+	if set = bindingSets[string(tag)]; set == nil {
+		set = newBindingSet(string(tag))
+		bindingSets[string(tag)] = set
+	}
+	return
+}
+
+func newBindingSet(name string) *BindingSet {
+	return &BindingSet{
+		Name:    name,
+		entries: make(map[bindingKey]*bindingEntry),
+		lock:    &sync.RWMutex{},
+	}
+}
+
+// SetParent makes the bindings of the parent set apply to the keys which are
+// not bound by this set, as the bindings of a Window apply to a Dialog.
+func (b *BindingSet) SetParent(parent *BindingSet) {
+	b.lock.Lock()
+	b.parent = parent
+	b.lock.Unlock()
+}
+
+// AddSignal binds the key and modifiers given to the emission of the signal
+// with the arguments given. Signals added to a key already bound are emitted
+// after those added before.
+func (b *BindingSet) AddSignal(key cdk.Key, mods cdk.ModMask, signal cdk.Signal, args ...interface{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	k := bindingKey{key: key, mods: mods & accelDefaultModMask}
+	entry, ok := b.entries[k]
+	if !ok || entry.skip {
+		entry = &bindingEntry{}
+		b.entries[k] = entry
+	}
+	entry.signals = append(entry.signals, &BindingSignal{Signal: signal, Args: args})
+}
+
+// Remove removes the binding of the key and modifiers given, the bindings of
+// sets with a lower precedence apply to the key again.
+func (b *BindingSet) Remove(key cdk.Key, mods cdk.ModMask) {
+	b.lock.Lock()
+	delete(b.entries, bindingKey{key: key, mods: mods & accelDefaultModMask})
+	b.lock.Unlock()
+}
+
+// Skip replaces the binding of the key and modifiers given with one which
+// stops the search for bindings, so that sets with a lower precedence do not
+// handle the key.
+func (b *BindingSet) Skip(key cdk.Key, mods cdk.ModMask) {
+	b.lock.Lock()
+	b.entries[bindingKey{key: key, mods: mods & accelDefaultModMask}] = &bindingEntry{skip: true}
+	b.lock.Unlock()
+}
+
+// Lookup returns the signals bound to the key and modifiers given. Skip is
+// TRUE if the key was unbound with Skip.
+func (b *BindingSet) Lookup(key cdk.Key, mods cdk.ModMask) (signals []*BindingSignal, skip, found bool) {
+	b.lock.RLock()
+	entry, ok := b.entries[bindingKey{key: key, mods: mods & accelDefaultModMask}]
+	parent := b.parent
+	b.lock.RUnlock()
+	if !ok {
+		if parent != nil && parent != b {
+			return parent.Lookup(key, mods)
+		}
+		return nil, false, false
+	}
+	return append([]*BindingSignal{}, entry.signals...), entry.skip, true
+}
+
+// Activate emits the signals bound to the key and modifiers given upon the
+// object given, returning TRUE if any of the signals was handled.
+func (b *BindingSet) Activate(key cdk.Key, mods cdk.ModMask, object Object) (handled bool) {
+	handled, _ = b.activate(key, mods, object)
+	return
+}
+
+func (b *BindingSet) activate(key cdk.Key, mods cdk.ModMask, object Object) (handled, stop bool) {
+	signals, skip, found := b.Lookup(key, mods)
+	if !found {
+		return false, false
+	}
+	if skip {
+		return false, true
+	}
+	for _, signal := range signals {
+		argv := append([]interface{}{object}, signal.Args...)
+		if f := object.Emit(signal.Signal, argv...); f == cdk.EVENT_STOP {
+			handled = true
+		}
+	}
+	return
+}
+
+// AddFromString parses the "bind" and "unbind" statements given, in the
+// syntax of the @binding-set css at-rule, and adds the bindings to the set.
+// A "bind" statement replaces any previous binding of the key.
+func (b *BindingSet) AddFromString(source string) (err error) {
+	var sc scanner.Scanner
+	sc.Init(strings.NewReader(source))
+	sc.Mode = scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanStrings | scanner.ScanComments | scanner.SkipComments
+	sc.IsIdentRune = func(ch rune, i int) bool {
+		return ch == '_' || unicode.IsLetter(ch) || (i > 0 && (ch == '-' || unicode.IsDigit(ch)))
+	}
+	sc.Error = func(s *scanner.Scanner, msg string) {
+		if err == nil {
+			err = fmt.Errorf("%v: %v", s.Position, msg)
+		}
+	}
+	for err == nil {
+		switch tok := sc.Scan(); tok {
+		case scanner.EOF:
+			return
+		case ';':
+			continue
+		case scanner.Ident:
+			switch statement := sc.TokenText(); statement {
+			case "bind", "unbind":
+				var key cdk.Key
+				var mods cdk.ModMask
+				if key, mods, err = scanBindingKey(&sc); err != nil {
+					return
+				}
+				if statement == "unbind" {
+					b.Skip(key, mods)
+					continue
+				}
+				var signals []*BindingSignal
+				if signals, err = scanBindingSignals(&sc); err != nil {
+					return
+				}
+				b.Remove(key, mods)
+				for _, signal := range signals {
+					b.AddSignal(key, mods, signal.Signal, signal.Args...)
+				}
+			default:
+				return fmt.Errorf("%v: expected bind or unbind, found %q", sc.Position, statement)
+			}
+		default:
+			return fmt.Errorf("%v: expected bind or unbind, found %q", sc.Position, sc.TokenText())
+		}
+	}
+	return
+}
+
+// String returns the bindings of the set as a @binding-set css at-rule.
+func (b *BindingSet) String() string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	var statements []string
+	for k, entry := range b.entries {
+		accelerator := strconv.Quote(accelName(k.key, k.mods))
+		if entry.skip {
+			statements = append(statements, "unbind "+accelerator+";")
+			continue
+		}
+		var signals []string
+		for _, signal := range entry.signals {
+			var args []string
+			for _, arg := range signal.Args {
+				args = append(args, formatBindingArg(arg))
+			}
+			signals = append(signals, fmt.Sprintf("%q (%v)", signal.Signal, strings.Join(args, ", ")))
+		}
+		statements = append(statements, "bind "+accelerator+" { "+strings.Join(signals, " ")+" };")
+	}
+	sort.Strings(statements)
+	return "@binding-set " + b.Name + " {" + strings.Join(statements, "") + "}"
+}
+
+// merge the bindings of the other set into this one, replacing the bindings
+// of the keys bound by both
+func (b *BindingSet) merge(other *BindingSet) {
+	other.lock.RLock()
+	defer other.lock.RUnlock()
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for k, entry := range other.entries {
+		b.entries[k] = &bindingEntry{
+			signals: append([]*BindingSignal{}, entry.signals...),
+			skip:    entry.skip,
+		}
+	}
+}
+
+// LoadBindingSetsFromFile loads the @binding-set at-rules of the css file at
+// the given path, as a user configuration overriding the bindings of the
+// application. The bindings of each set are added to the registered set with
+// the same name, replacing the bindings of the keys bound by both, so that a
+// set named after a type tag, such as "ctk-scrolled-viewport", changes the
+// bindings of the type and a set named "emacs" changes the emacs key theme.
+// Any other rules of the file are ignored.
+func LoadBindingSetsFromFile(path string) (err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+	sheet := NewStyleSheet()
+	if err = sheet.ParseString(string(data)); err != nil {
+		if se, ok := err.(*StyleSheetError); ok {
+			se.File = path
+		}
+		return
+	}
+	for _, set := range sheet.BindingSets {
+		target := FindBindingSet(set.Name)
+		if target == nil {
+			target = NewBindingSet(set.Name)
+		}
+		target.merge(set)
+	}
+	return
+}
+
+// BindingsActivate activates the binding sets of the object given for the key
+// and modifiers given, returning TRUE if the key was handled.
+func BindingsActivate(object Object, key cdk.Key, mods cdk.ModMask) (handled bool) {
+	for _, set := range bindingSetsFor(object) {
+		var stop bool
+		if handled, stop = set.activate(key, mods, object); handled || stop {
+			return
+		}
+	}
+	return false
+}
+
+// BindingsActivateEvent activates the binding sets of the object given for
+// the key event given, see BindingsActivate.
+func BindingsActivateEvent(object Object, event *cdk.EventKey) (handled bool) {
+	key, mods := accelEventKey(event)
+	if key == 0 {
+		return false
+	}
+	return BindingsActivate(object, key, mods)
+}
+
+// returns the binding sets of the object given, in order of precedence
+func bindingSetsFor(object Object) (sets []*BindingSet) {
+	if widget, ok := object.(Widget); ok {
+		if property := widget.GetComputedStyle().Get(PropertyKeyBindings); property != nil {
+			window := -1
+			if w := widget.GetWindow(); w != nil {
+				window = w.ObjectID()
+			}
+			names := strings.FieldsFunc(property.Value, func(r rune) bool {
+				return r == ',' || unicode.IsSpace(r)
+			})
+			for _, name := range names {
+				if set := lookupBindingSet(name, window); set != nil {
+					sets = append(sets, set)
+				} else {
+					widget.LogError("unknown binding set: %v", name)
+				}
+			}
+		}
+	}
+	if name := GetDefaultSettings().GetKeyThemeName(); name != "" {
+		if set := FindBindingSet(name); set != nil {
+			sets = append(sets, set)
+		}
+	}
+	if set := FindBindingSet(string(object.GetTypeTag().Tag())); set != nil {
+		sets = append(sets, set)
+	}
+	return
+}
+
+// returns the binding set with the given name defined by the StyleSheets
+// registered for the Window with the given ObjectID, the set of the highest
+// priority StyleSheet taking precedence, or the registered BindingSet with the
+// given name
+func lookupBindingSet(name string, window int) *BindingSet {
+	registrations := getStyleRegistrations(window)
+	for idx := len(registrations) - 1; idx >= 0; idx-- {
+		for _, set := range registrations[idx].sheet.BindingSets {
+			if set.Name == name {
+				return set
+			}
+		}
+	}
+	return FindBindingSet(name)
+}
+
+// scan the quoted accelerator of a bind or unbind statement
+func scanBindingKey(sc *scanner.Scanner) (key cdk.Key, mods cdk.ModMask, err error) {
+	if tok := sc.Scan(); tok != scanner.String {
+		return 0, 0, fmt.Errorf("%v: expected a quoted key, found %q", sc.Position, sc.TokenText())
+	}
+	accelerator, _ := strconv.Unquote(sc.TokenText())
+	if key, mods = accelParse(accelerator); key == 0 {
+		return 0, 0, fmt.Errorf("%v: invalid key: %q", sc.Position, accelerator)
+	}
+	return
+}
+
+// scan the braced list of signals of a bind statement, each signal being a
+// quoted name followed by the parenthesized arguments
+func scanBindingSignals(sc *scanner.Scanner) (signals []*BindingSignal, err error) {
+	if tok := sc.Scan(); tok != '{' {
+		return nil, fmt.Errorf("%v: expected '{', found %q", sc.Position, sc.TokenText())
+	}
+	for {
+		switch tok := sc.Scan(); tok {
+		case '}':
+			return
+		case ';':
+			continue
+		case scanner.String:
+			name, _ := strconv.Unquote(sc.TokenText())
+			signal := &BindingSignal{Signal: cdk.Signal(name)}
+			if signal.Args, err = scanBindingArgs(sc); err != nil {
+				return nil, err
+			}
+			signals = append(signals, signal)
+		default:
+			return nil, fmt.Errorf("%v: expected a quoted signal name, found %q", sc.Position, sc.TokenText())
+		}
+	}
+}
+
+// scan the parenthesized, comma separated arguments of a signal
+func scanBindingArgs(sc *scanner.Scanner) (args []interface{}, err error) {
+	if tok := sc.Scan(); tok != '(' {
+		return nil, fmt.Errorf("%v: expected '(', found %q", sc.Position, sc.TokenText())
+	}
+	negative := false
+	for {
+		tok := sc.Scan()
+		text := sc.TokenText()
+		switch tok {
+		case ')':
+			return
+		case ',':
+			continue
+		case '-':
+			negative = true
+			continue
+		case scanner.Int:
+			var v int64
+			if v, err = strconv.ParseInt(text, 0, 0); err != nil {
+				return nil, fmt.Errorf("%v: %v", sc.Position, err)
+			}
+			if negative {
+				v = -v
+			}
+			args = append(args, int(v))
+		case scanner.Float:
+			var v float64
+			if v, err = strconv.ParseFloat(text, 64); err != nil {
+				return nil, fmt.Errorf("%v: %v", sc.Position, err)
+			}
+			if negative {
+				v = -v
+			}
+			args = append(args, v)
+		case scanner.String:
+			v, _ := strconv.Unquote(text)
+			args = append(args, v)
+		case scanner.Ident:
+			v, ok := parseBindingArg(text)
+			if !ok {
+				return nil, fmt.Errorf("%v: unknown argument: %v", sc.Position, text)
+			}
+			args = append(args, v)
+		default:
+			return nil, fmt.Errorf("%v: unexpected %q in arguments", sc.Position, text)
+		}
+		negative = false
+	}
+}
+
+// the binding arguments by nickname, the enumerations used by the signals
+// emitted with key bindings
+var bindingArgNicks = map[string]interface{}{
+	"true":              true,
+	"false":             false,
+	"logical-positions": MOVEMENT_LOGICAL_POSITIONS,
+	"visual-positions":  MOVEMENT_VISUAL_POSITIONS,
+	"words":             MOVEMENT_WORDS,
+	"display-lines":     MOVEMENT_DISPLAY_LINES,
+	"display-line-ends": MOVEMENT_DISPLAY_LINE_ENDS,
+	"paragraphs":        MOVEMENT_PARAGRAPHS,
+	"paragraph-ends":    MOVEMENT_PARAGRAPH_ENDS,
+	"pages":             MOVEMENT_PAGES,
+	"buffer-ends":       MOVEMENT_BUFFER_ENDS,
+	"horizontal-pages":  MOVEMENT_HORIZONTAL_PAGES,
+	"jump":              SCROLL_JUMP,
+	"step-backward":     SCROLL_STEP_BACKWARD,
+	"step-forward":      SCROLL_STEP_FORWARD,
+	"page-backward":     SCROLL_PAGE_BACKWARD,
+	"page-forward":      SCROLL_PAGE_FORWARD,
+	"step-up":           SCROLL_STEP_UP,
+	"step-down":         SCROLL_STEP_DOWN,
+	"page-up":           SCROLL_PAGE_UP,
+	"page-down":         SCROLL_PAGE_DOWN,
+	"step-left":         SCROLL_STEP_LEFT,
+	"step-right":        SCROLL_STEP_RIGHT,
+	"page-left":         SCROLL_PAGE_LEFT,
+	"page-right":        SCROLL_PAGE_RIGHT,
+	"start":             SCROLL_START,
+	"end":               SCROLL_END,
+	"tab-forward":       DIR_TAB_FORWARD,
+	"tab-backward":      DIR_TAB_BACKWARD,
+	"up":                DIR_UP,
+	"down":              DIR_DOWN,
+	"left":              DIR_LEFT,
+	"right":             DIR_RIGHT,
+}
+
+// returns the argument with the given nickname, also accepting the names of
+// the enumeration constants, as in "MOVEMENT_DISPLAY_LINES" or
+// "GTK_SCROLL_STEP_UP"
+func parseBindingArg(name string) (value interface{}, ok bool) {
+	name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "gtk-"), "ctk-")
+	if value, ok = bindingArgNicks[name]; ok {
+		return
+	}
+	for _, prefix := range []string{"movement-", "scroll-", "dir-"} {
+		if strings.HasPrefix(name, prefix) {
+			if value, ok = bindingArgNicks[strings.TrimPrefix(name, prefix)]; ok {
+				return
+			}
+		}
+	}
+	return nil, false
+}
+
+// returns the argument given in the syntax parsed by AddFromString
+func formatBindingArg(arg interface{}) string {
+	switch v := arg.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return strconv.Quote(v)
+	case MovementStep, ScrollType, DirectionType:
+		for nick, value := range bindingArgNicks {
+			if value == arg {
+				return nick
+			}
+		}
+	}
+	return fmt.Sprintf("%v", arg)
+}
+
+// the navigation keys of the emacs and vi key themes, each bound to the
+// signals of the Widgets with a cursor, a slider or a scrolled child
+func init() {
+	type navigation struct {
+		accelerator string
+		step        MovementStep
+		count       int
+		slider      ScrollType
+		horizontal  bool
+	}
+	themes := map[string][]navigation{
+		"emacs": {
+			{"<Control>p", MOVEMENT_DISPLAY_LINES, -1, SCROLL_STEP_UP, false},
+			{"<Control>n", MOVEMENT_DISPLAY_LINES, 1, SCROLL_STEP_DOWN, false},
+			{"<Control>b", MOVEMENT_VISUAL_POSITIONS, -1, SCROLL_STEP_LEFT, true},
+			{"<Control>f", MOVEMENT_VISUAL_POSITIONS, 1, SCROLL_STEP_RIGHT, true},
+			{"<Alt>v", MOVEMENT_PAGES, -1, SCROLL_PAGE_UP, false},
+			{"<Control>v", MOVEMENT_PAGES, 1, SCROLL_PAGE_DOWN, false},
+			{"<Control>a", MOVEMENT_DISPLAY_LINE_ENDS, -1, SCROLL_START, true},
+			{"<Control>e", MOVEMENT_DISPLAY_LINE_ENDS, 1, SCROLL_END, true},
+			{"<Alt>less", MOVEMENT_BUFFER_ENDS, -1, SCROLL_START, false},
+			{"<Alt>greater", MOVEMENT_BUFFER_ENDS, 1, SCROLL_END, false},
+		},
+		"vi": {
+			{"k", MOVEMENT_DISPLAY_LINES, -1, SCROLL_STEP_UP, false},
+			{"j", MOVEMENT_DISPLAY_LINES, 1, SCROLL_STEP_DOWN, false},
+			{"h", MOVEMENT_VISUAL_POSITIONS, -1, SCROLL_STEP_LEFT, true},
+			{"l", MOVEMENT_VISUAL_POSITIONS, 1, SCROLL_STEP_RIGHT, true},
+			{"<Control>b", MOVEMENT_PAGES, -1, SCROLL_PAGE_UP, false},
+			{"<Control>f", MOVEMENT_PAGES, 1, SCROLL_PAGE_DOWN, false},
+			{"0", MOVEMENT_DISPLAY_LINE_ENDS, -1, SCROLL_START, true},
+			{"dollar", MOVEMENT_DISPLAY_LINE_ENDS, 1, SCROLL_END, true},
+			{"g", MOVEMENT_BUFFER_ENDS, -1, SCROLL_START, false},
+			{"<Shift>g", MOVEMENT_BUFFER_ENDS, 1, SCROLL_END, false},
+		},
+	}
+	for name, bindings := range themes {
+		set := NewBindingSet(name)
+		for _, binding := range bindings {
+			key, mods := accelParse(binding.accelerator)
+			set.AddSignal(key, mods, SignalMoveCursor, binding.step, binding.count, false, false)
+			// sliders have no line ends, only the start and end of the range
+			if !binding.horizontal || (binding.slider != SCROLL_START && binding.slider != SCROLL_END) {
+				set.AddSignal(key, mods, SignalMoveSlider, binding.slider)
+			}
+			set.AddSignal(key, mods, SignalScrollChild, binding.slider, binding.horizontal)
+		}
+	}
+}
+
+// The names of the binding sets applied to the Widget, separated by commas,
+// see BindingSet.
+const PropertyKeyBindings cdk.Property = "key-bindings"
@@ -0,0 +1,99 @@
+package ctk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBindingSet(t *testing.T) {
+	Convey("Testing BindingSets", t, func() {
+		Convey("parsing statements", func() {
+			set := newBindingSet("test")
+			So(set.AddFromString(`
+				bind "<Control>n" { "move-cursor" (display-lines, 1, false, false) "scroll-child" (GTK_SCROLL_STEP_DOWN, false) };
+				bind "F5" { "custom" ("text", -2, 0.5) }
+				unbind "<Shift>g";`), ShouldBeNil)
+			signals, skip, found := set.Lookup(cdk.Key('n'), cdk.ModCtrl)
+			So(found, ShouldBeTrue)
+			So(skip, ShouldBeFalse)
+			So(signals, ShouldHaveLength, 2)
+			So(signals[0].Signal, ShouldEqual, SignalMoveCursor)
+			So(signals[0].Args, ShouldResemble, []interface{}{MOVEMENT_DISPLAY_LINES, 1, false, false})
+			So(signals[1].Args, ShouldResemble, []interface{}{SCROLL_STEP_DOWN, false})
+			signals, _, _ = set.Lookup(cdk.KeyF5, cdk.ModNone)
+			So(signals[0].Args, ShouldResemble, []interface{}{"text", -2, 0.5})
+			_, skip, found = set.Lookup(cdk.Key('g'), cdk.ModShift)
+			So(found, ShouldBeTrue)
+			So(skip, ShouldBeTrue)
+			So(set.String(), ShouldContainSubstring, `bind "<Control>n" { "move-cursor" (display-lines, 1, false, false) "scroll-child" (step-down, false) };`)
+			So(set.String(), ShouldContainSubstring, `unbind "<Shift>g";`)
+			So(set.AddFromString(`bind "<Bogus>x" { "activate" () }`), ShouldNotBeNil)
+			So(set.AddFromString(`bind "x" { "move-cursor" (sideways) }`), ShouldNotBeNil)
+			So(set.AddFromString(`rebind "x"`), ShouldNotBeNil)
+		})
+		Convey("css binding sets and precedence", func() {
+			sheet := NewStyleSheet()
+			So(sheet.ParseString(`@binding-set custom { bind "F5" { "custom" (1) }; unbind "Tab"; } window { key-bindings: custom; }`), ShouldBeNil)
+			So(sheet.BindingSets, ShouldHaveLength, 1)
+			So(sheet.BindingSets[0].Name, ShouldEqual, "custom")
+			So(sheet.ParseString(`@binding-set broken { bind "F5" { "custom" (1) }`), ShouldNotBeNil)
+			provider := NewCssProvider()
+			So(provider.LoadFromString(`@binding-set custom { bind "F5" { "custom" (1) }; unbind "Tab"; } window { key-bindings: custom; }`), ShouldBeNil)
+			window := NewWindowWithTitle("bindings")
+			var args []interface{}
+			window.Connect("custom", "binding-set-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				args = argv[1:]
+				return cdk.EVENT_STOP
+			})
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyF5, 0, cdk.ModNone)), ShouldEqual, cdk.EVENT_PASS)
+			So(BindingsActivate(window, cdk.KeyTab, cdk.ModNone), ShouldBeTrue)
+			window.AddStyleProvider(provider, STYLE_PROVIDER_PRIORITY_APPLICATION)
+			defer window.RemoveStyleProvider(provider)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyF5, 0, cdk.ModNone)), ShouldEqual, cdk.EVENT_STOP)
+			So(args, ShouldResemble, []interface{}{1})
+			// unbound by the css binding set, the window type binding is ignored
+			So(BindingsActivate(window, cdk.KeyTab, cdk.ModNone), ShouldBeFalse)
+		})
+		Convey("key themes", func() {
+			viewport := NewScrolledViewport()
+			var scrolled []interface{}
+			viewport.Connect(SignalScrollChild, "binding-set-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				scrolled = argv[1:]
+				return cdk.EVENT_STOP
+			})
+			settings := GetDefaultSettings()
+			So(settings.SetKeyThemeName("missing"), ShouldNotBeNil)
+			So(BindingsActivateEvent(viewport, cdk.NewEventKey(cdk.KeyRune, 'j', cdk.ModNone)), ShouldBeFalse)
+			So(BindingsActivateEvent(viewport, cdk.NewEventKey(cdk.KeyUp, 0, cdk.ModNone)), ShouldBeTrue)
+			So(scrolled, ShouldResemble, []interface{}{SCROLL_STEP_UP, false})
+			So(settings.SetKeyThemeName("vi"), ShouldBeNil)
+			defer settings.SetKeyThemeName("")
+			So(BindingsActivateEvent(viewport, cdk.NewEventKey(cdk.KeyRune, 'j', cdk.ModNone)), ShouldBeTrue)
+			So(scrolled, ShouldResemble, []interface{}{SCROLL_STEP_DOWN, false})
+			So(BindingsActivateEvent(viewport, cdk.NewEventKey(cdk.KeyRune, 'G', cdk.ModNone)), ShouldBeTrue)
+			So(scrolled, ShouldResemble, []interface{}{SCROLL_END, false})
+			So(settings.SetKeyThemeName("emacs"), ShouldBeNil)
+			So(BindingsActivateEvent(viewport, cdk.NewEventKey(cdk.KeyCtrlP, 0, cdk.ModCtrl)), ShouldBeTrue)
+			So(scrolled, ShouldResemble, []interface{}{SCROLL_STEP_UP, false})
+		})
+		Convey("loading a configuration file", func() {
+			dir, err := ioutil.TempDir("", "ctk-bindings")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "bindings.css")
+			So(ioutil.WriteFile(path, []byte(`@binding-set ctk-test-widget { bind "<Alt>x" { "custom" () }; }`), 0644), ShouldBeNil)
+			So(LoadBindingSetsFromFile(path), ShouldBeNil)
+			set := FindBindingSet("ctk-test-widget")
+			So(set, ShouldNotBeNil)
+			_, _, found := set.Lookup(cdk.Key('x'), cdk.ModAlt)
+			So(found, ShouldBeTrue)
+			So(ioutil.WriteFile(path, []byte(`@binding-set ctk-test-widget { bind "x" }`), 0644), ShouldBeNil)
+			So(LoadBindingSetsFromFile(path), ShouldNotBeNil)
+		})
+	})
+}
@@ -424,7 +424,7 @@ func (b *CBox) Add(child Widget) {
 
 func (b *CBox) GetFocusChain() (focusableWidgets []interface{}, explicitlySet bool) {
 	if b.focusChainSet {
-		return b.focusChain, true
+		return explicitFocusChain(b, b.focusChain), true
 	}
 	var children []interface{}
 	for _, child := range b.getBoxChildren() {
@@ -3,6 +3,8 @@ package ctk
 import (
 	"encoding/xml"
 	"fmt"
+	"io/ioutil"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -44,7 +46,9 @@ type Builder interface {
 	GetWidgetsBuiltByType(tag cdk.CTypeTag) (widgets []interface{})
 	ParsePacking(packing *CBuilderElement) (expand, fill bool, padding int, packType PackType)
 	LoadFromString(raw string) (topElement *CBuilderElement, err error)
+	LoadFromFile(path string) (topElement *CBuilderElement, err error)
 	Build(element *CBuilderElement) (newObject interface{})
+	Destroy()
 }
 
 type CBuilder struct {
@@ -54,6 +58,14 @@ type CBuilder struct {
 	objects   []*CBuilderElement
 	buildable map[string]cdk.TypeTag
 	built     []interface{}
+	files     map[string]*CBuilderElement
+}
+
+// the owner of a file watched for hot reloading, a Builder may load any
+// number of files
+type builderFile struct {
+	builder *CBuilder
+	path    string
 }
 
 func NewBuilder() (builder Builder) {
@@ -70,6 +82,7 @@ func (b *CBuilder) Init() (already bool) {
 	b.buildable = cdk.TypesManager.GetBuildableInfo()
 	b.handlers = make(map[string]cdk.SignalListenerFn)
 	b.built = make([]interface{}, 0)
+	b.files = make(map[string]*CBuilderElement)
 	return
 }
 
@@ -138,6 +151,48 @@ func (b *CBuilder) ParsePacking(packing *CBuilderElement) (expand, fill bool, pa
 }
 
 func (b *CBuilder) LoadFromString(raw string) (topElement *CBuilderElement, err error) {
+	if topElement, err = b.parse(raw); err != nil {
+		return nil, err
+	}
+	_ = b.Build(topElement)
+	return topElement, nil
+}
+
+// Loads and builds the glade file at the given path, see LoadFromString. While
+// hot reloading is enabled, changes to the properties and style classes of
+// the objects within the file are applied to the objects built each time the
+// file changes, see EnableHotReload.
+func (b *CBuilder) LoadFromFile(path string) (topElement *CBuilderElement, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(path); err != nil {
+		return nil, err
+	}
+	if topElement, err = b.LoadFromString(string(data)); err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	b.Lock()
+	b.files[path] = topElement
+	b.Unlock()
+	watchHotReload(builderFile{b, path}, path, func() error { return b.reloadFile(path) })
+	return
+}
+
+// Releases the Builder, no longer watching the files it loaded for hot
+// reloading. The objects built are not destroyed.
+func (b *CBuilder) Destroy() {
+	b.Lock()
+	paths := make([]string, 0, len(b.files))
+	for path := range b.files {
+		paths = append(paths, path)
+	}
+	b.files = make(map[string]*CBuilderElement)
+	b.Unlock()
+	for _, path := range paths {
+		unwatchHotReload(builderFile{b, path})
+	}
+}
+
+func (b *CBuilder) parse(raw string) (topElement *CBuilderElement, err error) {
 	b.LogDebug("known buildable types: %v", b.buildable)
 	r := strings.NewReader(raw)
 	parser := xml.NewDecoder(r)
@@ -147,10 +202,88 @@ func (b *CBuilder) LoadFromString(raw string) (topElement *CBuilderElement, err
 	}
 	topElement = b.walkElements(n)
 	b.LogDebug("reporting:\n%v", b.report(0, topElement))
-	_ = b.Build(topElement)
 	return topElement, nil
 }
 
+// parse the file at the given path again and apply the properties and style
+// classes which changed to the objects built from it, matched by id. Objects
+// added, removed or given another class are not rebuilt, the reload is
+// rejected with an error noting the changes which require the application to
+// be restarted and the objects built are left unchanged
+func (b *CBuilder) reloadFile(path string) (err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+	var topElement *CBuilderElement
+	if topElement, err = b.parse(string(data)); err != nil {
+		return fmt.Errorf("%v: %v", path, err)
+	}
+	b.Lock()
+	previous := b.files[path]
+	b.Unlock()
+	built := make(map[string]*CBuilderElement)
+	builderElementsById(previous, built)
+	reloaded := make(map[string]*CBuilderElement)
+	builderElementsById(topElement, reloaded)
+	var unmatched []string
+	for id, element := range reloaded {
+		if before, ok := built[id]; !ok || before.Instance == nil || before.Attributes["class"] != element.Attributes["class"] {
+			unmatched = append(unmatched, id)
+		}
+	}
+	for id := range built {
+		if _, ok := reloaded[id]; !ok {
+			unmatched = append(unmatched, id)
+		}
+	}
+	if len(unmatched) > 0 {
+		sort.Strings(unmatched)
+		return fmt.Errorf("%v: objects added, removed or of another class require a restart: %v", path, strings.Join(unmatched, ", "))
+	}
+	for id, element := range reloaded {
+		before := built[id]
+		element.Instance = before.Instance
+		for k, v := range element.Properties {
+			if old, ok := before.Properties[k]; !ok || old != v {
+				element.ApplyProperty(k, v)
+			}
+		}
+		if widget, ok := element.Instance.(Widget); ok {
+			for _, class := range before.Classes {
+				widget.RemoveClass(class)
+			}
+			for _, class := range element.Classes {
+				widget.AddClass(class)
+			}
+		}
+		if object, ok := element.Instance.(Object); ok {
+			object.Invalidate()
+		}
+	}
+	b.Lock()
+	b.files[path] = topElement
+	b.Unlock()
+	requestStyleDraw()
+	return
+}
+
+// collect the object elements with an id attribute, found within the given
+// element, by id
+func builderElementsById(element *CBuilderElement, elements map[string]*CBuilderElement) {
+	if element == nil {
+		return
+	}
+	if element.TagName == "object" {
+		if id, ok := element.Attributes["id"]; ok {
+			elements[id] = element
+		}
+	}
+	for _, child := range element.Children {
+		builderElementsById(child, elements)
+	}
+}
+
 func (b *CBuilder) Build(element *CBuilderElement) (newObject interface{}) {
 	switch element.TagName {
 	case "requires":
@@ -167,17 +300,21 @@ func (b *CBuilder) Build(element *CBuilderElement) (newObject interface{}) {
 				ct, _ := cdk.TypesManager.GetType(tt)
 				newObject = ct.New()
 				b.built = append(b.built, newObject)
-				var newBuildable Buildable
-				var ok bool
-				if newBuildable, ok = newObject.(Buildable); !ok {
+				element.Instance = newObject
+				if newBuildable, ok := newObject.(Buildable); ok {
+					newBuildable.Show()
+					if err := newBuildable.Build(b, element); err != nil {
+						b.LogErr(err)
+					}
+				} else if newCtkObject, ok := newObject.(Object); ok {
+					// objects which are not widgets, such as actions
+					if err := newCtkObject.Build(b, element); err != nil {
+						b.LogErr(err)
+					}
+				} else {
 					b.LogError("new object is not a Buildable type: %v (%T)", newObject, newObject)
+					element.Instance = nil
 					newObject = nil
-					break
-				}
-				element.Instance = newObject
-				newBuildable.Show()
-				if err := newBuildable.Build(b, element); err != nil {
-					b.LogErr(err)
 				}
 			} else {
 				b.LogError("ctk class not implemented: %v", class)
@@ -293,6 +430,8 @@ func (b *CBuilder) walkObject(n BuilderNode) (be *CBuilderElement) {
 			}
 		case "child":
 			b.walkObjectChild(cn, be)
+		case "style":
+			b.walkObjectStyle(cn, be)
 		default:
 			b.LogError("ignoring unexpected tag: %v", cn.XMLName.Local)
 		}
@@ -300,8 +439,25 @@ func (b *CBuilder) walkObject(n BuilderNode) (be *CBuilderElement) {
 	return
 }
 
+func (b *CBuilder) walkObjectStyle(n BuilderNode, element *CBuilderElement) {
+	for _, cn := range n.Nodes {
+		switch cn.XMLName.Local {
+		case "class":
+			attrs := b.parseTagAttributes(cn.Attrs)
+			if name, ok := attrs["name"]; ok {
+				element.Classes = append(element.Classes, name)
+			} else {
+				b.LogError("missing name attribute on class tag")
+			}
+		default:
+			b.LogError("ignoring unexpected tag: %v", cn.XMLName.Local)
+		}
+	}
+}
+
 func (b *CBuilder) walkObjectChild(n BuilderNode, parentElement *CBuilderElement) {
 	var object *CBuilderElement
+	var accelerator string
 	packing := make(map[string]string)
 	for _, cn := range n.Nodes {
 		switch cn.XMLName.Local {
@@ -322,6 +478,8 @@ func (b *CBuilder) walkObjectChild(n BuilderNode, parentElement *CBuilderElement
 			}
 		case "placeholder":
 			return
+		case "accelerator":
+			accelerator = builderAccelerator(b.parseTagAttributes(cn.Attrs))
 		default:
 			b.LogError("ignoring unexpected tag: %v", cn.XMLName.Local)
 		}
@@ -330,12 +488,33 @@ func (b *CBuilder) walkObjectChild(n BuilderNode, parentElement *CBuilderElement
 		for k, v := range packing {
 			object.Packing[k] = v
 		}
+		if accelerator != "" {
+			object.Properties[PropertyAccelerator.String()] = accelerator
+		}
 	} else {
 		b.LogError("object not found in child tag children")
 	}
 	return
 }
 
+// the modifiers of accelerator tags by GDK modifier mask name
+var builderAcceleratorModifiers = map[string]string{
+	"GDK_CONTROL_MASK": "<Control>",
+	"GDK_SHIFT_MASK":   "<Shift>",
+	"GDK_MOD1_MASK":    "<Alt>",
+	"GDK_META_MASK":    "<Meta>",
+}
+
+// returns the accelerator of an accelerator tag such as <accelerator key="s"
+// modifiers="GDK_CONTROL_MASK | GDK_SHIFT_MASK"/>, in the format understood
+// by AccelGroup.AcceleratorParse
+func builderAccelerator(attrs map[string]string) (accelerator string) {
+	for _, modifier := range strings.Split(attrs["modifiers"], "|") {
+		accelerator += builderAcceleratorModifiers[strings.TrimSpace(modifier)]
+	}
+	return accelerator + attrs["key"]
+}
+
 func (b *CBuilder) parseTagAttributes(attrs []xml.Attr) (properties map[string]string) {
 	properties = make(map[string]string)
 	for _, attr := range attrs {
@@ -23,6 +23,7 @@ type CBuilderElement struct {
 	Properties map[string]string
 	Signals    map[string]string
 	Packing    map[string]string
+	Classes    []string
 	Children   []*CBuilderElement
 }
 
@@ -41,6 +42,7 @@ func (b *CBuilderElement) init() {
 	b.Properties = make(map[string]string)
 	b.Signals = make(map[string]string)
 	b.Packing = make(map[string]string)
+	b.Classes = make([]string, 0)
 	b.Children = make([]*CBuilderElement, 0)
 }
 
@@ -109,10 +111,12 @@ func (b *CBuilderElement) ApplyProperty(k, v string) (set bool) {
 				buildableWidget.UnsetFlags(CAN_FOCUS)
 			}
 		case "can-default", "can_default":
-			if utils.IsTrue(v) {
-				buildableWidget.SetFlags(CAN_DEFAULT)
-			} else {
-				buildableWidget.UnsetFlags(CAN_DEFAULT)
+			if bw, ok := b.Instance.(Widget); ok {
+				bw.SetCanDefault(utils.IsTrue(v))
+			}
+		case "receives-default", "receives_default":
+			if bw, ok := b.Instance.(Widget); ok {
+				bw.SetReceivesDefault(utils.IsTrue(v))
 			}
 		case "has-default", "has_default":
 			if utils.IsTrue(v) {
@@ -120,6 +124,24 @@ func (b *CBuilderElement) ApplyProperty(k, v string) (set bool) {
 			} else {
 				buildableWidget.UnsetFlags(HAS_DEFAULT)
 			}
+		case "related-action":
+			if bw, ok := b.Instance.(Widget); ok {
+				if action, ok := b.Builder.GetWidget(v).(Action); ok {
+					action.ConnectProxy(bw)
+				} else {
+					bw.LogError("failed to set related-action, unknown action id/name: %v", v)
+					return false
+				}
+			}
+		case "style":
+			if bw, ok := b.Instance.(Widget); ok {
+				style := bw.GetModifierStyle()
+				if err := style.SetDeclarations(v); err != nil {
+					bw.LogErr(err)
+					return false
+				}
+				bw.ModifyStyle(style)
+			}
 		case "app-paintable":
 			if utils.IsTrue(v) {
 				buildableWidget.SetFlags(APP_PAINTABLE)
@@ -145,6 +167,11 @@ func (b *CBuilderElement) ApplyProperty(k, v string) (set bool) {
 				return false
 			}
 		}
+	} else if object, ok := b.Instance.(Object); ok {
+		if err := object.SetPropertyFromString(cdk.Property(k), v); err != nil {
+			object.LogErr(err)
+			return false
+		}
 	}
 	return true
 }
@@ -1,7 +1,6 @@
 package ctk
 
 // TODO: new from stock id
-// TODO: mnemonics support, GtkAccel?
 
 import (
 	"fmt"
@@ -216,6 +215,8 @@ func (b *CButton) Init() (already bool) {
 	b.SetFlags(CAN_DEFAULT | RECEIVES_DEFAULT | CAN_FOCUS)
 	b.SetFlags(APP_PAINTABLE)
 	b.SetTheme(DefaultColorButtonTheme)
+	// a single line border with bookends
+	b.setStyleBoxDefaults(StyleSides{Left: 1, Right: 1}, BorderStyleSingle)
 	b.canvas = cdk.NewCanvas(cdk.MakePoint2I(0, 0), cdk.MakeRectangle(0, 0), b.GetTheme().Content.Normal)
 	handle := fmt.Sprintf("%v.focus-changed", b.ObjectName())
 	b.Connect(SignalLostFocus, handle, b.handleLostFocus)
@@ -310,7 +311,7 @@ func (b *CButton) GetRelief() (value ReliefStyle) {
 // 	widget and must not be modified or freed.
 func (b *CButton) GetLabel() (value string) {
 	if v, ok := b.GetChild().(Label); ok {
-		return v.GetText()
+		return v.GetLabel()
 	}
 	var err error
 	if value, err = b.GetStringProperty(PropertyButtonLabel); err != nil {
@@ -527,6 +528,11 @@ func (b *CButton) Remove(w Widget) {
 
 func (b *CButton) SetPressed(pressed bool) {
 	b.pressed = pressed
+	if pressed {
+		b.SetState(StateActive)
+	} else {
+		b.UnsetState(StateActive)
+	}
 	b.Invalidate()
 	if pressed {
 		b.Emit(SignalPressed)
@@ -715,24 +721,22 @@ func (b *CButton) GetThemeRequest() (theme cdk.Theme) {
 	return
 }
 
-func (b *CButton) getBorderRequest() (border bool) {
-	border = true
-	alloc := b.GetAllocation()
-	if alloc.W <= 2 || alloc.H <= 2 {
-		border = false
-	}
-	return
+// returns the box model fitted to the current allocation, small buttons drop
+// their padding and border
+func (b *CButton) getBoxRequest() (box styleBox) {
+	return b.getStyleBox().fit(b.GetAllocation())
 }
 
 func (b *CButton) GetSizeRequest() (width, height int) {
 	size := cdk.NewRectangle(b.CWidget.GetSizeRequest())
 	if child := b.GetChild(); child != nil {
 		labelSizeReq := cdk.NewRectangle(child.GetSizeRequest())
+		insets := b.GetCssInsets()
 		if size.W <= -1 && labelSizeReq.W > -1 {
-			size.W = 2 + labelSizeReq.W + 2 // borders and bookends
+			size.W = insets.Left + labelSizeReq.W + insets.Right
 		}
 		if size.H <= -1 && labelSizeReq.H > -1 {
-			size.H = labelSizeReq.H + 2 // borders
+			size.H = insets.Top + labelSizeReq.H + insets.Bottom
 		}
 	}
 	return size.W, size.H
@@ -747,16 +751,12 @@ func (b *CButton) Resize() cdk.EventFlag {
 			child.SetAllocation(cdk.MakeRectangle(0, 0))
 			return child.Resize()
 		}
-		x, y := 0, 0
 		origin := b.GetOrigin()
-		if alloc.W >= 3 && alloc.H >= 3 {
-			x, y = 1, 1
-			alloc.Sub(2, 2)
-		}
+		local, content := b.getBoxRequest().content(alloc)
+		x, y := local.X, local.Y
 		childSize := cdk.NewRectangle(child.GetSizeRequest())
-		childSize.W = alloc.W
-		childSize.H = alloc.H
-		childSize.Floor(0, 0)
+		childSize.W = content.W
+		childSize.H = content.H
 		if label, ok := child.(Label); ok {
 			maxChars, lineCount := label.GetPlainTextInfo()
 			xAlign := 0.5
@@ -809,18 +809,7 @@ func (b *CButton) Draw(canvas cdk.Canvas) cdk.EventFlag {
 	}
 
 	theme := b.GetThemeRequest()
-	border := b.getBorderRequest()
-
-	canvas.Box(
-		cdk.MakePoint2I(0, 0),
-		cdk.MakeRectangle(size.W, size.H),
-		border, true,
-		theme.Content.Overlay,
-		theme.Content.FillRune,
-		theme.Content.Normal,
-		theme.Border.Normal,
-		theme.Border.BorderRunes,
-	)
+	b.getBoxRequest().draw(canvas, b.GetStyle().GetEngine(), cdk.MakePoint2I(0, 0), size, true, theme)
 
 	if label == nil {
 		child.Draw(b.canvas)
@@ -93,6 +93,9 @@ type CContainer struct {
 	property      map[int][]*cdk.CProperty
 	focusChain    []interface{}
 	focusChainSet bool
+	focusChild    Widget
+	focusHAdjust  Adjustment
+	focusVAdjust  Adjustment
 }
 
 // Container object initialization. This must be called at least once to setup
@@ -134,7 +137,6 @@ func (c *CContainer) Init() (already bool) {
 //
 // Emits: SignalAdd, Argv=[Container instance, Widget instance]
 func (c *CContainer) Add(w Widget) {
-	// TODO: if can default and no default yet, set
 	if f := c.Emit(SignalAdd, c, w); f == cdk.EVENT_PASS {
 		cdk.DebugDF(1, "Container.Add(%v)", w)
 		w.SetParent(c)
@@ -143,6 +145,7 @@ func (c *CContainer) Add(w Widget) {
 		} else {
 			w.SetWindow(c.GetWindow())
 		}
+		claimWindowDefault(c.GetWindow(), w)
 		w.Connect(SignalLostFocus, c.fcHandle, c.handleLostFocus)
 		w.Connect(SignalGainedFocus, c.fcHandle, c.handleGainedFocus)
 		c.children = append(c.children, w)
@@ -151,6 +154,8 @@ func (c *CContainer) Add(w Widget) {
 			childProps[idx] = prop.Clone()
 		}
 		c.property[w.ObjectID()] = childProps
+		// sibling selectors may now match differently
+		c.InvalidateStyle()
 		c.Resize()
 	}
 }
@@ -171,6 +176,12 @@ func (c *CContainer) Remove(w Widget) {
 			if f := c.Emit(SignalRemove, c, child); f == cdk.EVENT_PASS {
 				_ = w.Disconnect(SignalLostFocus, c.fcHandle)
 				_ = w.Disconnect(SignalGainedFocus, c.fcHandle)
+				if window := c.GetWindow(); window != nil {
+					// the default widget leaves the window along with the child
+					if dw := window.GetDefaultWidget(); dw != nil && (dw.ObjectID() == w.ObjectID() || dw.IsAncestor(w)) {
+						window.SetDefault(nil)
+					}
+				}
 				w.SetParent(nil)
 				delete(c.property, w.ObjectID())
 				resize = true
@@ -187,6 +198,7 @@ func (c *CContainer) Remove(w Widget) {
 		c.children = children
 	}
 	if resize {
+		c.InvalidateStyle()
 		c.Resize()
 	}
 }
@@ -231,7 +243,7 @@ func (c *CContainer) GetChildren() (children []Widget) {
 // 	The child widget which will receive the focus inside container
 // 	when the container is focussed, or NULL if none is set.
 func (c *CContainer) GetFocusChild() (value Widget) {
-	return
+	return c.focusChild
 }
 
 // Sets, or unsets if child is NULL, the focused child of container . This
@@ -239,10 +251,57 @@ func (c *CContainer) GetFocusChild() (value Widget) {
 // Implementations of Container can override the default behaviour by
 // overriding the class closure of this signal. This is function is mostly
 // meant to be used by widgets. Applications can use WidgetGrabFocus
-// to manualy set the focus to a specific widget.
+// to manualy set the focus to a specific widget. Windows call this for
+// each ancestor of the widget receiving the focus. When the listeners return
+// EVENT_PASS, the focus adjustments of the container are scrolled to show the
+// focused widget, see SetFocusVAdjustment.
 // Parameters:
 // 	child	a Widget, or NULL.
-func (c *CContainer) SetFocusChild(child Widget) {}
+//
+// Emits: SignalSetFocusChild, Argv=[Container instance, child Widget]
+func (c *CContainer) SetFocusChild(child Widget) {
+	c.focusChild = child
+	if f := c.Emit(SignalSetFocusChild, c, child); f == cdk.EVENT_PASS && child != nil {
+		// scroll to the focused widget itself when it lies within the child
+		target := child
+		if w := c.GetWindow(); w != nil {
+			if focused, ok := w.GetFocus().(Widget); ok && focused.IsAncestor(child) {
+				target = focused
+			}
+		}
+		origin, offset, alloc := c.GetOrigin(), target.GetOrigin(), target.GetAllocation()
+		if c.focusVAdjust != nil {
+			scrollFocusAdjustment(c.focusVAdjust, offset.Y-origin.Y, alloc.H)
+		}
+		if c.focusHAdjust != nil {
+			scrollFocusAdjustment(c.focusHAdjust, offset.X-origin.X, alloc.W)
+		}
+	}
+}
+
+// scrolls the adjustment the least amount necessary for the given span, which
+// is relative to the start of the scrolled content, to be within the page
+func scrollFocusAdjustment(adjustment Adjustment, start, length int) {
+	value, lower, upper, _, _, pageSize := adjustment.Settings()
+	if pageSize <= 0 {
+		return
+	}
+	next := value
+	if start+length > next+pageSize {
+		next = start + length - pageSize
+	}
+	if start < next {
+		next = start
+	}
+	if next < lower {
+		next = lower
+	} else if next > upper {
+		next = upper
+	}
+	if next != value {
+		adjustment.SetValue(next)
+	}
+}
 
 // Retrieves the vertical focus adjustment for the container. See
 // SetFocusVAdjustment.
@@ -250,7 +309,7 @@ func (c *CContainer) SetFocusChild(child Widget) {}
 // 	the vertical focus adjustment, or NULL if none has been set.
 // 	[transfer none]
 func (c *CContainer) GetFocusVAdjustment() (value Adjustment) {
-	return nil
+	return c.focusVAdjust
 }
 
 // Hooks up an adjustment to focus handling in a container, so when a child
@@ -265,7 +324,9 @@ func (c *CContainer) GetFocusVAdjustment() (value Adjustment) {
 // 	adjustment	an adjustment which should be adjusted when the focus
 // is moved among the descendents of container
 //
-func (c *CContainer) SetFocusVAdjustment(adjustment Adjustment) {}
+func (c *CContainer) SetFocusVAdjustment(adjustment Adjustment) {
+	c.focusVAdjust = adjustment
+}
 
 // Retrieves the horizontal focus adjustment for the container. See
 // SetFocusHAdjustment.
@@ -273,7 +334,7 @@ func (c *CContainer) SetFocusVAdjustment(adjustment Adjustment) {}
 // 	the horizontal focus adjustment, or NULL if none has been set.
 // 	[transfer none]
 func (c *CContainer) GetFocusHAdjustment() (value Adjustment) {
-	return nil
+	return c.focusHAdjust
 }
 
 // Hooks up an adjustment to focus handling in a container, so when a child
@@ -288,7 +349,9 @@ func (c *CContainer) GetFocusHAdjustment() (value Adjustment) {
 // 	adjustment	an adjustment which should be adjusted when the focus is
 // moved among the descendents of container
 //
-func (c *CContainer) SetFocusHAdjustment(adjustment Adjustment) {}
+func (c *CContainer) SetFocusHAdjustment(adjustment Adjustment) {
+	c.focusHAdjust = adjustment
+}
 
 // func (c *CContainer) ResizeChildren() {}
 
@@ -445,7 +508,7 @@ func (c *CContainer) SetBorderWidth(borderWidth int) {
 // 	explicitlySet       TRUE if the focus chain has been set explicitly.
 func (c *CContainer) GetFocusChain() (focusableWidgets []interface{}, explicitlySet bool) {
 	if c.focusChainSet {
-		return c.focusChain, true
+		return explicitFocusChain(c, c.focusChain), true
 	}
 	for _, child := range c.children {
 		if cc, ok := child.(Container); ok {
@@ -479,10 +542,135 @@ func (c *CContainer) SetFocusChain(focusableWidgets []interface{}) {
 
 // Removes a focus chain explicitly set with SetFocusChain.
 func (c *CContainer) UnsetFocusChain() {
-	c.focusChainSet = true
+	c.focusChainSet = false
 	c.focusChain = []interface{}{}
 }
 
+// Moves the focus into the container, see WidgetChildFocus. When the “focus”
+// signal is not handled, the first widget of the focus chain able to take the
+// focus is focused, or the last one when moving backwards, up or left.
+// Parameters:
+// 	direction	direction of focus movement
+// Returns:
+// 	TRUE if focus ended up inside the container
+func (c *CContainer) ChildFocus(direction DirectionType) (value bool) {
+	if !c.IsVisible() || !c.IsSensitive() {
+		return false
+	}
+	if f := c.Emit(SignalFocus, c, direction); f == cdk.EVENT_STOP {
+		return true
+	}
+	fc, _ := c.GetFocusChain()
+	count := len(fc)
+	for idx := 0; idx < count; idx++ {
+		item := fc[idx]
+		switch direction {
+		case DIR_TAB_BACKWARD, DIR_UP, DIR_LEFT:
+			item = fc[count-1-idx]
+		}
+		if sw, ok := item.(Sensitive); ok && sw.CanFocus() && sw.IsSensitive() && sw.IsVisible() {
+			sw.GrabFocus()
+			return true
+		}
+	}
+	return false
+}
+
+// returns the widgets of an explicitly set focus chain which can take the
+// focus, skipping widgets which are hidden or not packed within the container.
+// Containers in the chain which cannot take the focus themselves are replaced
+// with their own focus chain
+func explicitFocusChain(container Widget, chain []interface{}) (focusableWidgets []interface{}) {
+	for _, item := range chain {
+		widget, ok := item.(Widget)
+		if !ok || !widget.IsVisible() || !widget.IsAncestor(container) {
+			continue
+		}
+		if widget.CanFocus() {
+			focusableWidgets = append(focusableWidgets, item)
+		} else if cc, ok := item.(Container); ok {
+			fc, _ := cc.GetFocusChain()
+			focusableWidgets = append(focusableWidgets, fc...)
+		}
+	}
+	return
+}
+
+// returns the widget of the focus chain nearest to the from widget in the
+// given direction, preferring widgets lined up with it. When wrapping, the
+// widget farthest away in the opposite direction is returned instead
+func focusInDirection(from Widget, chain []interface{}, direction DirectionType, wrap bool) (nearest Widget) {
+	fo, fa := from.GetOrigin(), from.GetAllocation()
+	var best [4]int
+	for _, item := range chain {
+		candidate, ok := item.(Widget)
+		if !ok || candidate.ObjectID() == from.ObjectID() || !candidate.IsSensitive() || !candidate.IsVisible() {
+			continue
+		}
+		co, ca := candidate.GetOrigin(), candidate.GetAllocation()
+		if ca.W <= 0 || ca.H <= 0 {
+			continue
+		}
+		var gap, across, centre int
+		switch direction {
+		case DIR_UP, DIR_DOWN:
+			if direction == DIR_DOWN {
+				gap = co.Y - (fo.Y + fa.H)
+			} else {
+				gap = fo.Y - (co.Y + ca.H)
+			}
+			across = spanDistance(fo.X, fa.W, co.X, ca.W)
+			centre = (fo.X + fa.W/2) - (co.X + ca.W/2)
+		case DIR_LEFT, DIR_RIGHT:
+			if direction == DIR_RIGHT {
+				gap = co.X - (fo.X + fa.W)
+			} else {
+				gap = fo.X - (co.X + ca.W)
+			}
+			across = spanDistance(fo.Y, fa.H, co.Y, ca.H)
+			centre = (fo.Y + fa.H/2) - (co.Y + ca.H/2)
+		default:
+			return nil
+		}
+		if gap < 0 && !wrap {
+			continue
+		}
+		if centre < 0 {
+			centre = -centre
+		}
+		misaligned := 0
+		if across > 0 {
+			misaligned = 1
+		}
+		// a negative gap is the distance beyond the from widget when wrapping
+		score := [4]int{misaligned, gap, across, centre}
+		if nearest == nil || lessFocusScore(score, best) {
+			nearest, best = candidate, score
+		}
+	}
+	return
+}
+
+// returns the distance between two spans, or zero if they overlap
+func spanDistance(a, aLength, b, bLength int) int {
+	if b >= a+aLength {
+		return b - (a + aLength) + 1
+	}
+	if a >= b+bLength {
+		return a - (b + bLength) + 1
+	}
+	return 0
+}
+
+func lessFocusScore(a, b [4]int) bool {
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return a[idx] < b[idx]
+		}
+	}
+	return false
+}
+
 // Finds a child property of a container by name.
 // Parameters:
 // 	 property		the name of the child property to find
@@ -564,6 +752,22 @@ func (c *CContainer) SetWindow(w Window) {
 		} else {
 			child.SetWindow(w)
 		}
+		claimWindowDefault(w, child)
+	}
+}
+
+// makes the widget the default widget of the window if the widget asked to be
+// the default before it was within the window, see WidgetGrabDefault, and the
+// window does not have a default widget yet. When the window already has
+// another default widget, the claim is refused and the HAS_DEFAULT flag of the
+// widget is unset
+func claimWindowDefault(window Window, widget Widget) {
+	if window != nil && widget.HasFlags(HAS_DEFAULT) && widget.HasFlags(CAN_DEFAULT) {
+		if dw := window.GetDefaultWidget(); dw == nil {
+			window.SetDefault(widget)
+		} else if dw.ObjectID() != widget.ObjectID() {
+			widget.UnsetFlags(HAS_DEFAULT)
+		}
 	}
 }
 
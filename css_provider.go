@@ -0,0 +1,172 @@
+package ctk
+
+import (
+	"io/ioutil"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for CssProvider objects
+const TypeCssProvider cdk.CTypeTag = "ctk-css-provider"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeCssProvider, func() interface{} { return MakeCssProvider() })
+}
+
+// CssProvider Hierarchy:
+//	Object
+//	  +- CssProvider
+//
+// CssProvider is an object implementing style information for CTK Widgets,
+// loaded from CSS source. A CssProvider is applied by registering it for all
+// Windows with AddProviderForDisplay or for a single Window with
+// Window.AddStyleProvider, at one of the STYLE_PROVIDER_PRIORITY levels.
+// Loading new CSS into a registered provider restyles all Widgets it applies
+// to. When loading fails, the parsing-error signal is emitted and the
+// previously loaded style information remains in effect.
+type CssProvider interface {
+	Object
+
+	Init() (already bool)
+	LoadFromData(data []byte) (err error)
+	LoadFromString(source string) (err error)
+	LoadFromFile(path string) (err error)
+	GetPath() (path string)
+	GetStyleSheet() (sheet *StyleSheet)
+	ToString() (value string)
+	Destroy()
+}
+
+// The CCssProvider structure implements the CssProvider interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with CssProvider objects
+type CCssProvider struct {
+	CObject
+
+	sheet *StyleSheet
+	path  string
+}
+
+// Default constructor for CssProvider objects
+func MakeCssProvider() *CCssProvider {
+	return NewCssProvider()
+}
+
+// Returns a newly created CssProvider, with no style information loaded.
+func NewCssProvider() (value *CCssProvider) {
+	c := new(CCssProvider)
+	c.Init()
+	return c
+}
+
+// CssProvider object initialization. This must be called at least once to
+// setup the necessary defaults and allocate any memory structures. Calling this
+// more than once is safe though unnecessary. Only the first call will result
+// in any effect upon the CssProvider instance
+func (c *CCssProvider) Init() (already bool) {
+	if c.InitTypeItem(TypeCssProvider, c) {
+		return true
+	}
+	c.CObject.Init()
+	c.sheet = NewStyleSheet()
+	return false
+}
+
+// Loads data into the CssProvider, making it clear any previously loaded
+// information. See LoadFromString.
+func (c *CCssProvider) LoadFromData(data []byte) (err error) {
+	return c.load(string(data), "")
+}
+
+// Loads the given CSS source into the CssProvider, making it clear any
+// previously loaded information. If the source fails to parse, the previously
+// loaded information is kept, the parsing-error signal is emitted and the
+// *StyleSheetError describing the line and column at fault is returned.
+//
+// Emits: SignalParsingError, Argv=[CssProvider instance, error]
+func (c *CCssProvider) LoadFromString(source string) (err error) {
+	return c.load(source, "")
+}
+
+// Loads the contents of the file at the given path into the CssProvider,
+// making it clear any previously loaded information. See LoadFromString. The
+// file is reloaded each time it changes while hot reloading is enabled, see
+// EnableHotReload.
+//
+// Emits: SignalParsingError, Argv=[CssProvider instance, error]
+func (c *CCssProvider) LoadFromFile(path string) (err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(path); err != nil {
+		c.Emit(SignalParsingError, c, err)
+		return
+	}
+	return c.load(string(data), path)
+}
+
+// Returns the path of the file last loaded with LoadFromFile, or an empty
+// string if the style information was not loaded from a file.
+func (c *CCssProvider) GetPath() (path string) {
+	return c.path
+}
+
+// Returns the StyleSheet holding the style information of the CssProvider.
+// Loading new style information replaces the StyleSheet, which is not to be
+// modified once returned.
+func (c *CCssProvider) GetStyleSheet() (sheet *StyleSheet) {
+	c.Lock()
+	defer c.Unlock()
+	return c.sheet
+}
+
+// Converts the provider into a string representation in CSS format.
+func (c *CCssProvider) ToString() (value string) {
+	return c.GetStyleSheet().String()
+}
+
+// Releases the CssProvider, unregistering it from the display and no longer
+// watching the file it was loaded from for hot reloading.
+func (c *CCssProvider) Destroy() {
+	unwatchHotReload(c)
+	RemoveProviderForDisplay(c)
+}
+
+func (c *CCssProvider) load(source, path string) (err error) {
+	parsed := NewStyleSheet()
+	if err = parsed.ParseString(source); err != nil {
+		if se, ok := err.(*StyleSheetError); ok {
+			se.File = path
+		}
+		c.Emit(SignalParsingError, c, err)
+		return
+	}
+	// the registered sheet may be in use by a cascade, swap the registrations
+	c.Lock()
+	previous := c.sheet
+	c.sheet, c.path = parsed, path
+	replaceStyleSheet(previous, parsed)
+	c.Unlock()
+	if path != "" {
+		watchHotReload(c, path, func() error { return c.LoadFromFile(path) })
+	} else {
+		unwatchHotReload(c)
+	}
+	return
+}
+
+// Registers the given CssProvider for all Widgets of all Windows at the given
+// priority. Registering a provider again changes the priority.
+func AddProviderForDisplay(provider CssProvider, priority StyleProviderPriority) {
+	addStyleSheet(provider.GetStyleSheet(), priority, -1)
+}
+
+// Unregisters a CssProvider added with AddProviderForDisplay.
+func RemoveProviderForDisplay(provider CssProvider) {
+	removeStyleSheet(provider.GetStyleSheet(), -1)
+}
+
+// Signaled when a parsing error occurs while loading CSS, the previously
+// loaded style information remains in effect.
+// Listener function arguments:
+// 	err error	the *StyleSheetError describing the problem
+const SignalParsingError cdk.Signal = "parsing-error"
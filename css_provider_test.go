@@ -0,0 +1,84 @@
+package ctk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCssProvider(t *testing.T) {
+	Convey("Testing CssProviders", t, func() {
+		Convey("loading and errors", func() {
+			provider := NewCssProvider()
+			So(provider.LoadFromString("button { color: red; }"), ShouldBeNil)
+			So(provider.GetStyleSheet().Rules, ShouldHaveLength, 1)
+			var signalled error
+			provider.Connect(SignalParsingError, "test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				if len(argv) > 1 {
+					signalled, _ = argv[1].(error)
+				}
+				return cdk.EVENT_PASS
+			})
+			err := provider.LoadFromString("label { bold: true; }\n\n  window >> button { }")
+			So(err, ShouldNotBeNil)
+			So(signalled, ShouldEqual, err)
+			se, ok := err.(*StyleSheetError)
+			So(ok, ShouldBeTrue)
+			So(se.Line, ShouldEqual, 3)
+			So(se.Column, ShouldEqual, 3)
+			// the previously loaded style remains
+			So(provider.GetStyleSheet().Rules, ShouldHaveLength, 1)
+			So(provider.GetStyleSheet().Rules[0].Selector, ShouldEqual, "button")
+			dir, err := ioutil.TempDir("", "ctk-css")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "style.css")
+			So(ioutil.WriteFile(path, []byte("label {\n  color: ;\n"), 0644), ShouldBeNil)
+			err = provider.LoadFromFile(path)
+			So(err, ShouldNotBeNil)
+			So(err.(*StyleSheetError).File, ShouldEqual, path)
+			So(provider.LoadFromFile(filepath.Join(dir, "missing.css")), ShouldNotBeNil)
+			So(ioutil.WriteFile(path, []byte("label { color: blue }"), 0644), ShouldBeNil)
+			So(provider.LoadFromFile(path), ShouldBeNil)
+			So(provider.GetPath(), ShouldEqual, path)
+		})
+		Convey("display and window registration", func() {
+			first, second := NewWindowWithTitle("first"), NewWindowWithTitle("second")
+			one, two := NewLabel("one"), NewLabel("two")
+			first.Add(one)
+			second.Add(two)
+			application, user := NewCssProvider(), NewCssProvider()
+			So(application.LoadFromString("#special { color: red; } label { bold: true; }"), ShouldBeNil)
+			So(user.LoadFromString("label { color: green; }"), ShouldBeNil)
+			AddProviderForDisplay(application, STYLE_PROVIDER_PRIORITY_APPLICATION)
+			AddProviderForDisplay(user, STYLE_PROVIDER_PRIORITY_USER)
+			defer RemoveProviderForDisplay(application)
+			defer RemoveProviderForDisplay(user)
+			one.SetName("special")
+			So(one.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "green")
+			So(two.GetComputedStyle().Get(PropertyBold).Value, ShouldEqual, "true")
+			AddProviderForDisplay(user, STYLE_PROVIDER_PRIORITY_FALLBACK)
+			So(one.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "red")
+			// loading replaces the registered sheet instead of modifying it
+			previous := user.GetStyleSheet()
+			So(user.LoadFromString("label { color: green; italic: true; }"), ShouldBeNil)
+			So(user.GetStyleSheet(), ShouldNotEqual, previous)
+			So(previous.Rules[0].Properties, ShouldHaveLength, 1)
+			So(two.GetComputedStyle().Get(PropertyItalic).Value, ShouldEqual, "true")
+			So(first.LoadStyleSheetFromString("label { underline: true }"), ShouldBeNil)
+			So(one.GetComputedStyle().Get(PropertyUnderline).Value, ShouldEqual, "true")
+			So(two.GetComputedStyle().Get(PropertyUnderline), ShouldBeNil)
+			So(first.LoadStyleSheetFromString("label { dim: true }"), ShouldBeNil)
+			So(one.GetComputedStyle().Get(PropertyUnderline), ShouldBeNil)
+			So(one.GetComputedStyle().Get(PropertyDim).Value, ShouldEqual, "true")
+			So(first.LoadStyleSheetFromString("label { dim: false"), ShouldNotBeNil)
+			So(one.GetComputedStyle().Get(PropertyDim).Value, ShouldEqual, "true")
+			first.RemoveStyleProvider(first.getStyleProvider())
+			So(one.GetComputedStyle().Get(PropertyDim), ShouldBeNil)
+		})
+	})
+}
@@ -11,18 +11,26 @@
 //
 // 	Object
 // 	  |- Adjustment
+// 	  |- CssProvider
+// 	  |- ListStore
+// 	  |- Settings
+// 	  |- SizeGroup
 // 	  `- Widget
 // 	     |- Container
 // 	     |  |- Bin
 // 	     |  |  |- Button
+// 	     |  |  |  `- LinkButton
 // 	     |  |  |- EventBox
 // 	     |  |  |- Frame
+// 	     |  |  |  `- AspectFrame
+// 	     |  |  |- HandleBox
 // 	     |  |  |- Viewport
 // 	     |  |  |  `- ScrolledViewport
 // 	     |  |  `- Window
-// 	     |  `- Box
-// 	     |     |- HBox
-// 	     |     `- VBox
+// 	     |  |- Box
+// 	     |  |  |- HBox
+// 	     |  |  `- VBox
+// 	     |  `- IconView
 // 	     |- Misc
 // 	     |  |- Arrow
 // 	     |  `- Label
@@ -33,7 +41,8 @@
 // 	     |  `- Scrollbar
 // 	     |     |- HScrollbar
 // 	     |     `- VScrollbar
-// 	     `- Sensitive
+// 	     |- Sensitive
+// 	     `- Terminal
 package ctk
 
 // TODO: refactor for more parity with Gtk version
@@ -43,11 +52,9 @@ package ctk
 // TODO: ObjectID as CSS id, name as CSS name attribute, etc
 // TODO: implement a "get selector" method which finds the parent-path to object
 // TODO: sensitive things can process events
-// TODO: windows can LoadStyleSheetFromString()
 // TODO: button focusOnClick raises issue of event processing vs focus handling
 // TODO: Arrow needs to use Misc features for alignment etc
 // TODO: style properties
 // TODO: current theme getters
 // TODO: widgets do not manipulate theme/style (no setters outside CSS)
-// TODO: states like :hover :focus and so on effect current theme
 // TODO: refactor enum types for BitFlags and so on, standardize convention
@@ -20,6 +20,7 @@ func init() {
 		}
 		return fmt.Errorf("dialog property translator not implemented")
 	}
+	BindingSetByType(TypeDialog).SetParent(BindingSetByType(TypeWindow))
 }
 
 // Dialog Hierarchy:
@@ -152,6 +153,7 @@ func (d *CDialog) Init() (already bool) {
 	d.SetFlags(APP_PAINTABLE)
 	d.parent = d
 	d.defResponse = ResponseNone
+	d.SetModal(d.dialogFlags&DialogModal != 0)
 	d.GetVBox().Show()
 	d.content = NewVBox(false, 0)
 	d.content.Show()
@@ -228,6 +230,9 @@ func (d *CDialog) Run() (response chan ResponseType) {
 		d.Resize()
 		dm.SetActiveWindow(d)
 	}
+	if d.GetModal() {
+		GrabAdd(d)
+	}
 	dm.RequestDraw()
 	dm.RequestSync()
 	go func() {
@@ -235,6 +240,7 @@ func (d *CDialog) Run() (response chan ResponseType) {
 		select {
 		case <-d.done:
 		}
+		GrabRemove(d)
 		response <- d.response
 		if isOverlay && parentId > -1 {
 			dm.RemoveWindowOverlay(parentId, d.ObjectID())
@@ -348,6 +354,11 @@ func (d *CDialog) AddSecondaryActionWidget(child Widget, responseId ResponseType
 // 	responseId	a response ID
 func (d *CDialog) SetDefaultResponse(responseId ResponseType) {
 	d.defResponse = responseId
+	if widgets := d.widgets[responseId]; len(widgets) > 0 {
+		if last := widgets[len(widgets)-1]; last.HasFlags(CAN_DEFAULT) {
+			last.GrabDefault()
+		}
+	}
 }
 
 // Calls WidgetSetSensitive (widget, setting ) for each widget in the
@@ -500,6 +511,26 @@ func (d *CDialog) Show() {
 			w.Show()
 		}
 	}
+	if d.GetModal() {
+		// the dialog itself holds the grab, not the embedded window
+		GrabAdd(d)
+	}
+}
+
+func (d *CDialog) ShowAll() {
+	d.CWindow.ShowAll()
+	if d.GetModal() {
+		GrabAdd(d)
+	}
+}
+
+// Sets the dialog modal or non-modal, see WindowSetModal. A visible modal
+// dialog holds a grab, receiving all key and mouse events.
+func (d *CDialog) SetModal(modal bool) {
+	d.CWindow.SetModal(modal)
+	if modal && d.IsVisible() {
+		GrabAdd(d)
+	}
 }
 
 func (d *CDialog) Destroy() {
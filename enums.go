@@ -307,6 +307,52 @@ const (
 	FILL
 )
 
+/* Border style */
+type BorderStyle uint64
+
+const (
+	BorderStyleNone BorderStyle = iota
+	BorderStyleSingle
+	BorderStyleDouble
+	BorderStyleRounded
+	BorderStyleHeavy
+	BorderStyleAscii
+)
+
+func (b BorderStyle) FromString(value string) (enum interface{}, err error) {
+	switch strings.ToLower(value) {
+	case "none", "hidden":
+		return BorderStyleNone, nil
+	case "single", "solid":
+		return BorderStyleSingle, nil
+	case "double":
+		return BorderStyleDouble, nil
+	case "rounded":
+		return BorderStyleRounded, nil
+	case "heavy":
+		return BorderStyleHeavy, nil
+	case "ascii":
+		return BorderStyleAscii, nil
+	}
+	return nil, fmt.Errorf("unknown value for BorderStyle.FromString(%v)", value)
+}
+
+func (b BorderStyle) String() string {
+	switch b {
+	case BorderStyleSingle:
+		return "single"
+	case BorderStyleDouble:
+		return "double"
+	case BorderStyleRounded:
+		return "rounded"
+	case BorderStyleHeavy:
+		return "heavy"
+	case BorderStyleAscii:
+		return "ascii"
+	}
+	return "none"
+}
+
 /* Button box style */
 type ButtonBoxStyle uint64
 
@@ -700,9 +746,11 @@ const (
 /* State type */
 type StateType uint64
 
+// StateType values are bit flags, a Widget can be in more than one state at
+// the same time, for example both prelight and active
 const (
-	StateNormal StateType = iota
-	StateActive
+	StateNormal StateType = 0
+	StateActive StateType = 1 << (iota - 1)
 	StatePrelight
 	StateSelected
 	StateInsensitive
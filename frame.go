@@ -81,6 +81,18 @@ func NewFrame(text string) *CFrame {
 	return f
 }
 
+// construct a new frame with a default label containing the given text, with
+// an underscore in front of the mnemonic character. The mnemonic moves the
+// focus to the first focusable widget within the frame
+func NewFrameWithMnemonic(text string) *CFrame {
+	f := NewFrame("")
+	if label, ok := f.GetLabelWidget().(Label); ok {
+		label.SetUseUnderline(true)
+	}
+	f.SetLabel(text)
+	return f
+}
+
 // construct a new frame with the given widget instead of the normal label
 func NewFrameWithWidget(w Widget) *CFrame {
 	f := new(CFrame)
@@ -101,6 +113,7 @@ func (f *CFrame) Init() (already bool) {
 	f.flags = NULL_WIDGET_FLAG
 	f.SetFlags(PARENT_SENSITIVE)
 	f.SetFlags(APP_PAINTABLE)
+	f.setStyleBoxDefaults(StyleSides{}, BorderStyleSingle)
 	_ = f.InstallProperty(PropertyLabel, cdk.StringProperty, true, nil)
 	_ = f.InstallProperty(PropertyLabelWidget, cdk.StructProperty, true, nil)
 	_ = f.InstallProperty(PropertyLabelXAlign, cdk.FloatProperty, true, 0.0)
@@ -110,6 +123,7 @@ func (f *CFrame) Init() (already bool) {
 	f.focusWithChild = false
 	f.labelCanvas = cdk.NewCanvas(cdk.Point2I{}, cdk.Rectangle{}, f.GetTheme().Content.Normal)
 	f.fFcHandle = fmt.Sprintf("%v-frame.focus-changed", f.ObjectName())
+	f.Connect(SignalMnemonicActivate, fmt.Sprintf("%v.mnemonic-activate", f.ObjectName()), f.handleMnemonicActivate)
 	return false
 }
 
@@ -270,6 +284,19 @@ func (f *CFrame) GrabFocus() {
 	}
 }
 
+// focus the first focusable widget within the frame when the mnemonic of the
+// frame label is activated
+func (f *CFrame) handleMnemonicActivate(data []interface{}, argv ...interface{}) cdk.EventFlag {
+	fc, _ := f.GetFocusChain()
+	for _, item := range fc {
+		if fw, ok := item.(Sensitive); ok && fw.IsSensitive() && fw.IsVisible() {
+			fw.GrabFocus()
+			return cdk.EVENT_STOP
+		}
+	}
+	return cdk.EVENT_PASS
+}
+
 func (f *CFrame) Add(w Widget) {
 	f.CBin.Add(w)
 	w.Connect(SignalLostFocus, f.fFcHandle, f.handleLostFocus)
@@ -338,14 +365,15 @@ func (f *CFrame) GetSizeRequest() (width, height int) {
 	size := cdk.NewRectangle(f.CWidget.GetSizeRequest())
 	if child := f.GetChild(); child != nil {
 		childSize := cdk.NewRectangle(child.GetSizeRequest())
+		insets := f.GetCssInsets()
 		if size.W <= -1 {
 			if childSize.W > -1 {
-				size.W = 1 + childSize.W + 1
+				size.W = insets.Left + childSize.W + insets.Right
 			}
 		}
 		if size.H <= -1 {
 			if childSize.H > -1 {
-				size.H = 1 + childSize.H + 1
+				size.H = insets.Top + childSize.H + insets.Bottom
 				if yAlign == 0.0 {
 					size.H += 1
 				}
@@ -374,10 +402,14 @@ func (f *CFrame) Resize() cdk.EventFlag {
 	}
 	alloc.Floor(0, 0)
 	origin := f.GetOrigin()
-	childOrigin := cdk.MakePoint2I(origin.X+1, origin.Y+1)
-	childAlloc := cdk.MakeRectangle(alloc.W-2, alloc.H-2)
-	labelOrigin := cdk.MakePoint2I(origin.X+2, origin.Y)
-	labelAlloc := cdk.MakeRectangle(alloc.W-4, 1)
+	box := f.getStyleBox().fit(alloc)
+	local, childAlloc := box.content(alloc)
+	childOrigin := cdk.MakePoint2I(origin.X+local.X, origin.Y+local.Y)
+	// the label is drawn over the top border, one cell in from the corner
+	edge := box.margin.Add(box.border)
+	labelOrigin := cdk.MakePoint2I(origin.X+edge.Left+1, origin.Y+box.margin.Top)
+	labelAlloc := cdk.MakeRectangle(alloc.W-edge.Width()-2, 1)
+	labelAlloc.Floor(0, 0)
 	xAlign, yAlign := f.GetLabelAlign()
 	if yAlign <= 0.0 {
 		yAlign = 0.0
@@ -391,6 +423,7 @@ func (f *CFrame) Resize() cdk.EventFlag {
 	} else {
 		yAlign = 0.5
 	}
+	childAlloc.Floor(0, 0)
 	if label != nil {
 		label.SetAlignment(xAlign, yAlign)
 		label.SetMaxWidthChars(labelAlloc.W)
@@ -428,7 +461,7 @@ func (f *CFrame) Draw(canvas cdk.Canvas) cdk.EventFlag {
 		boxOrigin.Y += 1
 		boxSize.H -= 1
 	}
-	canvas.BoxWithTheme(boxOrigin, boxSize, true, true, theme)
+	f.getStyleBox().fit(alloc).draw(canvas, f.GetStyle().GetEngine(), boxOrigin, boxSize, true, theme)
 
 	if widget := f.GetLabelWidget(); widget != nil {
 		if label, ok := widget.(Label); ok {
@@ -0,0 +1,133 @@
+package ctk
+
+import (
+	"sync"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+var (
+	grabStack     []Widget
+	grabStackLock = &sync.RWMutex{}
+)
+
+// GrabAdd makes the widget given the current grab widget, the only widget
+// receiving key and mouse events until it is removed with GrabRemove. Events
+// given to any Window are redirected to the grab widget, mouse events outside
+// of the grab widget are discarded and a click outside of a popup Window
+// dismisses the popup. Grabs are stacked, adding a widget already on the
+// stack moves it to the top and removing the top widget restores the grab of
+// the widget below. Modal Windows and Dialogs add themselves when shown and
+// remove themselves when hidden.
+//
+// Emits: SignalGrabNotify, Argv=[previous grab Widget, false], From=previous grab Widget
+func GrabAdd(widget Widget) {
+	if widget == nil {
+		return
+	}
+	grabStackLock.Lock()
+	previous := grabStackTop()
+	grabStackRemove(widget)
+	grabStack = append(grabStack, widget)
+	grabStackLock.Unlock()
+	if previous != nil && previous.ObjectID() != widget.ObjectID() {
+		previous.Emit(SignalGrabNotify, previous, false)
+	}
+}
+
+// GrabRemove removes the widget given from the grab stack, see GrabAdd. If
+// the widget was the current grab widget, the previous grab widget becomes
+// current again.
+//
+// Emits: SignalGrabNotify, Argv=[current grab Widget, true], From=current grab Widget
+func GrabRemove(widget Widget) {
+	if widget == nil {
+		return
+	}
+	grabStackLock.Lock()
+	top := grabStackTop()
+	removed := grabStackRemove(widget)
+	current := grabStackTop()
+	grabStackLock.Unlock()
+	if removed && current != nil && top.ObjectID() == widget.ObjectID() {
+		current.Emit(SignalGrabNotify, current, true)
+	}
+}
+
+// GrabGetCurrent returns the current grab widget, or nil if there is no grab.
+func GrabGetCurrent() (widget Widget) {
+	grabStackLock.RLock()
+	defer grabStackLock.RUnlock()
+	return grabStackTop()
+}
+
+// returns TRUE if the widget with the object id given is on the grab stack
+func grabStackHas(id int) bool {
+	grabStackLock.RLock()
+	defer grabStackLock.RUnlock()
+	for _, widget := range grabStack {
+		if widget.ObjectID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the topmost visible widget of the grab stack, skipping grab widgets
+// which are hidden, or nil if there is none
+func grabGetVisible() Widget {
+	grabStackLock.RLock()
+	defer grabStackLock.RUnlock()
+	for idx := len(grabStack) - 1; idx >= 0; idx-- {
+		if grabStack[idx].IsVisible() {
+			return grabStack[idx]
+		}
+	}
+	return nil
+}
+
+// returns TRUE if there is no grab widget given or the widget is the grab
+// widget or one of its descendants
+func grabContains(grab, widget Widget) bool {
+	if grab == nil {
+		return true
+	}
+	return widget.ObjectID() == grab.ObjectID() || widget.IsAncestor(grab)
+}
+
+// the grabStackLock must be held by the caller
+func grabStackTop() Widget {
+	if last := len(grabStack) - 1; last > -1 {
+		return grabStack[last]
+	}
+	return nil
+}
+
+// the grabStackLock must be held by the caller
+func grabStackRemove(widget Widget) (removed bool) {
+	for idx, grabbed := range grabStack {
+		if grabbed.ObjectID() == widget.ObjectID() {
+			grabStack = append(grabStack[:idx], grabStack[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// dismisses the popup Window holding the grab given, unless the handlers of
+// the grab-broken-event signal return EVENT_STOP. Returns TRUE if the popup
+// was dismissed.
+//
+// Emits: SignalGrabBrokenEvent, Argv=[popup Window instance]
+func dismissGrabPopup(grab Widget) (dismissed bool) {
+	popup, ok := grab.(Window)
+	if !ok || popup.GetWindowType() != WindowPopup {
+		return false
+	}
+	if f := popup.Emit(SignalGrabBrokenEvent, popup); f == cdk.EVENT_STOP {
+		return false
+	}
+	GrabRemove(popup)
+	popup.Hide()
+	return true
+}
@@ -0,0 +1,629 @@
+package ctk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for HandleBox objects
+const TypeHandleBox cdk.CTypeTag = "ctk-handle-box"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeHandleBox, func() interface{} { return MakeHandleBox() })
+	ctkBuilderTranslators[TypeHandleBox] = func(builder Builder, widget Widget, name, value string) error {
+		switch name {
+		case "handle-position", "snap-edge":
+			if hb, ok := widget.(HandleBox); ok {
+				var position PositionType
+				switch strings.ToLower(strings.TrimPrefix(strings.ToUpper(value), "GTK_POS_")) {
+				case "left":
+					position = POS_LEFT
+				case "right":
+					position = POS_RIGHT
+				case "top":
+					position = POS_TOP
+				case "bottom":
+					position = POS_BOTTOM
+				default:
+					return fmt.Errorf("invalid position type: %v", value)
+				}
+				if name == "snap-edge" {
+					hb.SetSnapEdge(position)
+				} else {
+					hb.SetHandlePosition(position)
+				}
+			}
+			return nil
+		}
+		return ErrFallthrough
+	}
+}
+
+// HandleBox Hierarchy:
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- HandleBox
+//
+// The HandleBox widget allows a portion of a window to be "torn off". It is
+// a bin widget which displays its child and a handle that the user can use
+// to detach the child from its parent window and make it float above the
+// window as an overlay. When the child is detached, the HandleBox remains
+// in place, displaying only the handle. Activating the handle with the mouse,
+// or with the Enter or Space keys while the handle has focus, toggles the
+// detached state of the child. While detached, the floating overlay accepts
+// all key events and pressing the Escape key re-docks the child.
+type HandleBox interface {
+	Bin
+	Buildable
+
+	Init() (already bool)
+	SetShadowType(shadowType ShadowType)
+	SetHandlePosition(position PositionType)
+	SetSnapEdge(edge PositionType)
+	GetHandlePosition() (value PositionType)
+	GetShadowType() (value ShadowType)
+	GetSnapEdge() (value PositionType)
+	GetChildDetached() (value bool)
+	Detach()
+	Attach()
+	Destroy()
+	GetChild() (value Widget)
+	ProcessEvent(evt cdk.Event) cdk.EventFlag
+	GetSizeRequest() (width, height int)
+	Resize() cdk.EventFlag
+	Draw(canvas cdk.Canvas) cdk.EventFlag
+	Invalidate() cdk.EventFlag
+}
+
+// The CHandleBox structure implements the HandleBox interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with HandleBox objects
+type CHandleBox struct {
+	CBin
+
+	canvas   *cdk.CCanvas
+	detached Widget
+	float    *CWindow
+	floatPid int
+}
+
+// Default constructor for HandleBox objects
+func MakeHandleBox() *CHandleBox {
+	return NewHandleBox()
+}
+
+// Create a new handle box.
+// Returns:
+// 	a new HandleBox.
+func NewHandleBox() (value *CHandleBox) {
+	h := new(CHandleBox)
+	h.Init()
+	return h
+}
+
+// HandleBox object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the HandleBox instance
+func (h *CHandleBox) Init() (already bool) {
+	if h.InitTypeItem(TypeHandleBox, h) {
+		return true
+	}
+	h.CBin.Init()
+	h.flags = NULL_WIDGET_FLAG
+	h.SetFlags(SENSITIVE | PARENT_SENSITIVE | CAN_FOCUS | APP_PAINTABLE)
+	h.canvas = cdk.NewCanvas(cdk.MakePoint2I(0, 0), cdk.MakeRectangle(0, 0), h.GetTheme().Content.Normal)
+	h.detached = nil
+	h.float = nil
+	h.floatPid = -1
+	_ = h.InstallBuildableProperty(PropertyChildDetached, cdk.BoolProperty, true, false)
+	_ = h.InstallBuildableProperty(PropertyHandlePosition, cdk.StructProperty, true, POS_LEFT)
+	_ = h.InstallBuildableProperty(PropertyShadowType, cdk.StructProperty, true, SHADOW_OUT)
+	_ = h.InstallBuildableProperty(PropertySnapEdge, cdk.StructProperty, true, POS_TOP)
+	_ = h.InstallBuildableProperty(PropertySnapEdgeSet, cdk.BoolProperty, true, false)
+	return false
+}
+
+// Sets the type of shadow to be drawn around the border of the handle box.
+// Parameters:
+// 	type	the shadow type.
+func (h *CHandleBox) SetShadowType(shadowType ShadowType) {
+	if err := h.SetStructProperty(PropertyShadowType, shadowType); err != nil {
+		h.LogErr(err)
+	}
+}
+
+// Sets the side of the handlebox where the handle is drawn.
+// Parameters:
+// 	position	the side of the handlebox where the handle should be drawn.
+func (h *CHandleBox) SetHandlePosition(position PositionType) {
+	if err := h.SetStructProperty(PropertyHandlePosition, position); err != nil {
+		h.LogErr(err)
+	} else {
+		h.Resize()
+	}
+}
+
+// Sets the snap edge of a handlebox. The snap edge is the edge of the
+// detached child that must be aligned with the corresponding edge of the
+// "ghost" left behind when the child was detached to reattach the torn-off
+// window. Within CTK, the snap edge determines on which side of the handle the
+// floating overlay is placed when the child is detached.
+// Parameters:
+// 	edge	the snap edge
+func (h *CHandleBox) SetSnapEdge(edge PositionType) {
+	if err := h.SetStructProperty(PropertySnapEdge, edge); err != nil {
+		h.LogErr(err)
+	}
+	if err := h.SetBoolProperty(PropertySnapEdgeSet, true); err != nil {
+		h.LogErr(err)
+	}
+}
+
+// Gets the handle position of the handle box. See SetHandlePosition.
+// Returns:
+// 	the current handle position.
+func (h *CHandleBox) GetHandlePosition() (value PositionType) {
+	var ok bool
+	if v, err := h.GetStructProperty(PropertyHandlePosition); err != nil {
+		h.LogErr(err)
+	} else if value, ok = v.(PositionType); !ok {
+		h.LogError("value stored in %v property is not of PositionType: %v (%T)", PropertyHandlePosition, v, v)
+	}
+	return
+}
+
+// Gets the type of shadow drawn around the handle box. See
+// SetShadowType.
+// Returns:
+// 	the type of shadow currently drawn around the handle box.
+func (h *CHandleBox) GetShadowType() (value ShadowType) {
+	var ok bool
+	if v, err := h.GetStructProperty(PropertyShadowType); err != nil {
+		h.LogErr(err)
+	} else if value, ok = v.(ShadowType); !ok {
+		h.LogError("value stored in %v property is not of ShadowType: %v (%T)", PropertyShadowType, v, v)
+	}
+	return
+}
+
+// Gets the edge used for determining reattachment of the handle box. See
+// SetSnapEdge. If the snap edge has not been set, the edge is derived from
+// the handle position.
+// Returns:
+// 	the edge used for determining reattachment
+func (h *CHandleBox) GetSnapEdge() (value PositionType) {
+	if set, err := h.GetBoolProperty(PropertySnapEdgeSet); err != nil {
+		h.LogErr(err)
+	} else if !set {
+		switch h.GetHandlePosition() {
+		case POS_TOP, POS_BOTTOM:
+			return POS_LEFT
+		}
+		return POS_TOP
+	}
+	var ok bool
+	if v, err := h.GetStructProperty(PropertySnapEdge); err != nil {
+		h.LogErr(err)
+	} else if value, ok = v.(PositionType); !ok {
+		h.LogError("value stored in %v property is not of PositionType: %v (%T)", PropertySnapEdge, v, v)
+	}
+	return
+}
+
+// Whether the handlebox's child is currently detached.
+// Returns:
+// 	TRUE if the child is currently detached, otherwise FALSE
+func (h *CHandleBox) GetChildDetached() (value bool) {
+	var err error
+	if value, err = h.GetBoolProperty(PropertyChildDetached); err != nil {
+		h.LogErr(err)
+	}
+	return
+}
+
+// Returns the child of the HandleBox, regardless of whether the child is
+// currently detached or not.
+func (h *CHandleBox) GetChild() (value Widget) {
+	if h.detached != nil {
+		return h.detached
+	}
+	return h.CBin.GetChild()
+}
+
+// Detach the child from the HandleBox and present it within a floating
+// overlay window, positioned next to the handle according to the snap edge.
+// This method emits a child-detached signal initially and if the listeners
+// return EVENT_PASS, the child is detached.
+//
+// Emits: SignalChildDetached, Argv=[HandleBox instance, child Widget]
+func (h *CHandleBox) Detach() {
+	child := h.CBin.GetChild()
+	if child == nil || h.detached != nil {
+		return
+	}
+	window := h.GetWindow()
+	if window == nil {
+		h.LogError("cannot detach child without a window")
+		return
+	}
+	if f := h.Emit(SignalChildDetached, h, child); f == cdk.EVENT_STOP {
+		return
+	}
+	h.CBin.Remove(child)
+	h.detached = child
+	h.float = NewWindow()
+	h.float.SetTheme(window.GetTheme())
+	h.float.SetTransientFor(window)
+	h.float.Add(child)
+	child.Show()
+	h.float.Show()
+	handle := fmt.Sprintf("%v.handle-box", h.ObjectName())
+	h.float.Connect(SignalResize, handle, h.handleFloatResize)
+	h.float.Connect(SignalEventKey, handle, h.handleFloatKey)
+	h.floatPid = window.ObjectID()
+	if err := h.SetBoolProperty(PropertyChildDetached, true); err != nil {
+		h.LogErr(err)
+	}
+	dm := cdk.GetDisplayManager()
+	if dm != nil {
+		dm.AddWindowOverlay(h.floatPid, h.float, h.getFloatRegion())
+	}
+	h.resizeFloat()
+	window.Resize()
+	if fc, _ := h.float.GetFocusChain(); len(fc) > 0 {
+		if sw, ok := fc[0].(Sensitive); ok {
+			sw.GrabFocus()
+		}
+	}
+	if dm != nil {
+		dm.RequestDraw()
+		dm.RequestShow()
+	}
+}
+
+// Re-dock a detached child into the HandleBox, closing the floating overlay.
+// This method emits a child-attached signal initially and if the listeners
+// return EVENT_PASS, the child is re-attached.
+//
+// Emits: SignalChildAttached, Argv=[HandleBox instance, child Widget]
+func (h *CHandleBox) Attach() {
+	if h.detached == nil {
+		return
+	}
+	if f := h.Emit(SignalChildAttached, h, h.detached); f == cdk.EVENT_STOP {
+		return
+	}
+	h.closeFloat()
+	if window := h.GetWindow(); window != nil {
+		window.Resize()
+	}
+	h.GrabFocus()
+	if dm := cdk.GetDisplayManager(); dm != nil {
+		dm.RequestDraw()
+		dm.RequestShow()
+	}
+}
+
+// Destroys the HandleBox, re-docking a detached child without emitting the
+// child-attached signal first so that the floating overlay is closed. See:
+// Widget.Destroy
+func (h *CHandleBox) Destroy() {
+	if h.detached != nil {
+		h.closeFloat()
+	}
+	h.CBin.Destroy()
+}
+
+// Toggles the detached state of the child when the handle is clicked or when
+// Enter or Space is pressed while the handle has focus.
+func (h *CHandleBox) ProcessEvent(evt cdk.Event) cdk.EventFlag {
+	switch e := evt.(type) {
+	case *cdk.EventMouse:
+		if e.State() == cdk.BUTTON_PRESS {
+			x, y := e.Position()
+			region := h.getHandleRegion()
+			if x >= region.X && x < region.X+region.W && y >= region.Y && y < region.Y+region.H {
+				h.GrabFocus()
+				h.toggle()
+				return cdk.EVENT_STOP
+			}
+		}
+	case *cdk.EventKey:
+		if !h.IsFocus() {
+			break
+		}
+		switch e.Key() {
+		case cdk.KeyRune:
+			if e.Rune() != ' ' {
+				break
+			}
+			fallthrough
+		case cdk.KeyEnter:
+			h.toggle()
+			return cdk.EVENT_STOP
+		}
+	}
+	return cdk.EVENT_PASS
+}
+
+func (h *CHandleBox) GetSizeRequest() (width, height int) {
+	size := cdk.NewRectangle(h.CWidget.GetSizeRequest())
+	childSize := cdk.NewRectangle(0, 1)
+	if child := h.CBin.GetChild(); child != nil {
+		childSize = cdk.NewRectangle(child.GetSizeRequest())
+		childSize.Floor(0, 1)
+	}
+	switch h.GetHandlePosition() {
+	case POS_TOP, POS_BOTTOM:
+		childSize.H += 1
+	default:
+		childSize.W += 1
+	}
+	if size.W <= -1 {
+		size.W = childSize.W
+	}
+	if size.H <= -1 {
+		size.H = childSize.H
+	}
+	return size.W, size.H
+}
+
+func (h *CHandleBox) Resize() cdk.EventFlag {
+	alloc := h.GetAllocation()
+	origin := h.GetOrigin()
+	if child := h.CBin.GetChild(); child != nil {
+		local := cdk.MakePoint2I(0, 0)
+		size := cdk.MakeRectangle(alloc.W, alloc.H)
+		switch h.GetHandlePosition() {
+		case POS_TOP:
+			local.Y = 1
+			size.H -= 1
+		case POS_BOTTOM:
+			size.H -= 1
+		case POS_RIGHT:
+			size.W -= 1
+		default:
+			local.X = 1
+			size.W -= 1
+		}
+		size.Floor(0, 0)
+		child.SetOrigin(origin.X+local.X, origin.Y+local.Y)
+		child.SetAllocation(size)
+		child.Resize()
+	}
+	if h.float != nil {
+		h.resizeFloat()
+	}
+	return h.Invalidate()
+}
+
+func (h *CHandleBox) Invalidate() cdk.EventFlag {
+	theme := h.GetThemeRequest()
+	if child := h.CBin.GetChild(); child != nil {
+		local := child.GetOrigin()
+		local.SubPoint(h.GetOrigin())
+		h.canvas.SetOrigin(local)
+		h.canvas.Resize(child.GetAllocation(), theme.Content.Normal)
+		return cdk.EVENT_STOP
+	}
+	h.canvas.SetOrigin(cdk.MakePoint2I(0, 0))
+	h.canvas.Resize(cdk.MakeRectangle(0, 0), theme.Content.Normal)
+	return cdk.EVENT_PASS
+}
+
+func (h *CHandleBox) Draw(canvas cdk.Canvas) cdk.EventFlag {
+	h.Lock()
+	defer h.Unlock()
+	alloc := h.GetAllocation()
+	if !h.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+		h.LogTrace("HandleBox.Draw(): not visible, zero width or zero height")
+		return cdk.EVENT_PASS
+	}
+	theme := h.GetThemeRequest()
+	canvas.Fill(theme)
+	state := h.GetState()
+	if h.IsFocus() {
+		state = StatePrelight
+	}
+	region := h.getHandleRegion()
+	origin := h.GetOrigin()
+	orientation := cdk.ORIENTATION_VERTICAL
+	if region.W > region.H {
+		orientation = cdk.ORIENTATION_HORIZONTAL
+	}
+	h.GetStyle().PaintHandle(canvas, state, SHADOW_NONE, cdk.Rectangle{}, h, "handlebox", region.X-origin.X, region.Y-origin.Y, region.W, region.H, orientation)
+	if child := h.CBin.GetChild(); child != nil && child.IsVisible() {
+		child.Draw(h.canvas)
+		if err := canvas.Composite(h.canvas); err != nil {
+			h.LogError("composite error: %v", err)
+		}
+	}
+	if debug, _ := h.GetBoolProperty(cdk.PropertyDebug); debug {
+		canvas.DebugBox(cdk.ColorSilver, h.ObjectInfo())
+	}
+	return cdk.EVENT_STOP
+}
+
+// removes the floating overlay from the display manager, destroys it and
+// re-adds the detached child to the HandleBox
+func (h *CHandleBox) closeFloat() {
+	child := h.detached
+	if h.float != nil {
+		handle := fmt.Sprintf("%v.handle-box", h.ObjectName())
+		_ = h.float.Disconnect(SignalResize, handle)
+		_ = h.float.Disconnect(SignalEventKey, handle)
+		if dm := cdk.GetDisplayManager(); dm != nil && h.floatPid > -1 {
+			dm.RemoveWindowOverlay(h.floatPid, h.float.ObjectID())
+		}
+		h.float.Remove(child)
+		h.float.Hide()
+		h.float.Destroy()
+		h.float = nil
+		h.floatPid = -1
+	}
+	h.detached = nil
+	if err := h.SetBoolProperty(PropertyChildDetached, false); err != nil {
+		h.LogErr(err)
+	}
+	h.CBin.Add(child)
+}
+
+func (h *CHandleBox) toggle() {
+	if h.detached != nil {
+		h.Attach()
+	} else {
+		h.Detach()
+	}
+}
+
+// returns the display region of the handle
+func (h *CHandleBox) getHandleRegion() (region cdk.Region) {
+	origin := h.GetOrigin()
+	alloc := h.GetAllocation()
+	switch h.GetHandlePosition() {
+	case POS_TOP:
+		region = cdk.MakeRegion(origin.X, origin.Y, alloc.W, 1)
+	case POS_BOTTOM:
+		region = cdk.MakeRegion(origin.X, origin.Y+alloc.H-1, alloc.W, 1)
+	case POS_RIGHT:
+		region = cdk.MakeRegion(origin.X+alloc.W-1, origin.Y, 1, alloc.H)
+	default:
+		region = cdk.MakeRegion(origin.X, origin.Y, 1, alloc.H)
+	}
+	return
+}
+
+// returns the display region of the floating overlay, which is placed next
+// to the handle on the side given by the snap edge, clamped to the display
+// when there is one
+func (h *CHandleBox) getFloatRegion() (region cdk.Region) {
+	origin := h.GetOrigin()
+	alloc := h.GetAllocation()
+	size := cdk.MakeRectangle(2, 3)
+	if h.detached != nil {
+		w, hh := h.detached.GetSizeRequest()
+		if w < 1 {
+			w = 1
+		}
+		if hh < 1 {
+			hh = 1
+		}
+		size = cdk.MakeRectangle(w+2, hh+2)
+	}
+	pos := cdk.MakePoint2I(origin.X, origin.Y)
+	switch h.GetSnapEdge() {
+	case POS_LEFT:
+		pos.X += alloc.W
+	case POS_RIGHT:
+		pos.X -= size.W
+	case POS_BOTTOM:
+		pos.Y -= size.H
+	default:
+		pos.Y += alloc.H
+	}
+	dm := cdk.GetDisplayManager()
+	if dm == nil || dm.Display() == nil {
+		return cdk.MakeRegion(pos.X, pos.Y, size.W, size.H)
+	}
+	dw, dh := dm.Display().Size()
+	if size.W > dw {
+		size.W = dw
+	}
+	if size.H > dh {
+		size.H = dh
+	}
+	if pos.X+size.W > dw {
+		pos.X = dw - size.W
+	}
+	if pos.Y+size.H > dh {
+		pos.Y = dh - size.H
+	}
+	if pos.X < 0 {
+		pos.X = 0
+	}
+	if pos.Y < 0 {
+		pos.Y = 0
+	}
+	return cdk.MakeRegion(pos.X, pos.Y, size.W, size.H)
+}
+
+func (h *CHandleBox) resizeFloat() {
+	if h.float == nil {
+		return
+	}
+	region := h.getFloatRegion()
+	h.float.SetOrigin(region.X, region.Y)
+	h.float.SetAllocation(region.Size())
+	if dm := cdk.GetDisplayManager(); dm != nil && h.floatPid > -1 {
+		dm.SetWindowOverlayRegion(h.floatPid, h.float.ObjectID(), region)
+	}
+	if child := h.float.GetChild(); child != nil {
+		child.SetOrigin(region.X+1, region.Y+1)
+		child.SetAllocation(cdk.MakeRectangle(region.W-2, region.H-2))
+		child.Resize()
+	}
+	h.float.Invalidate()
+}
+
+func (h *CHandleBox) handleFloatResize(_ []interface{}, _ ...interface{}) cdk.EventFlag {
+	h.resizeFloat()
+	return cdk.EVENT_STOP
+}
+
+func (h *CHandleBox) handleFloatKey(_ []interface{}, argv ...interface{}) cdk.EventFlag {
+	if len(argv) > 1 {
+		if e, ok := argv[1].(*cdk.EventKey); ok && e.Key() == cdk.KeyEscape {
+			h.Attach()
+			return cdk.EVENT_STOP
+		}
+	}
+	return cdk.EVENT_PASS
+}
+
+// The rune the default StyleEngine draws vertical (left or right) handles with
+var HandleBoxVerticalGrip = '┇'
+
+// The rune the default StyleEngine draws horizontal (top or bottom) handles
+// with
+var HandleBoxHorizontalGrip = '┅'
+
+// A boolean value indicating whether the handlebox's child is attached or
+// detached.
+// Flags: Read
+// Default value: FALSE
+const PropertyChildDetached cdk.Property = "child-detached"
+
+// Position of the handle relative to the child widget.
+// Flags: Read / Write
+// Default value: GTK_POS_LEFT
+const PropertyHandlePosition cdk.Property = "handle-position"
+
+// Side of the handlebox that's lined up with the docking point to dock the
+// handlebox.
+// Flags: Read / Write
+// Default value: GTK_POS_TOP
+const PropertySnapEdge cdk.Property = "snap-edge"
+
+// Whether to use the value from the snap_edge property or a value derived
+// from handle_position.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertySnapEdgeSet cdk.Property = "snap-edge-set"
+
+// This signal is emitted when the contents of the handlebox are reattached
+// to the main window.
+// Listener function arguments:
+// 	widget Widget	the child widget of the handlebox.
+const SignalChildAttached cdk.Signal = "child-attached"
+
+// This signal is emitted when the contents of the handlebox are detached
+// from the main window.
+// Listener function arguments:
+// 	widget Widget	the child widget of the handlebox.
+const SignalChildDetached cdk.Signal = "child-detached"
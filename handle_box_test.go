@@ -0,0 +1,88 @@
+package ctk
+
+import (
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHandleBox(t *testing.T) {
+	Convey("Testing HandleBoxes", t, func() {
+		window := NewWindowWithTitle("handle-box")
+		hb := NewHandleBox()
+		button := NewButtonWithLabel("child")
+		hb.Add(button)
+		window.GetVBox().PackStart(hb, false, false, 0)
+		window.ShowAll()
+		Convey("detach and attach", func() {
+			So(hb.GetChildDetached(), ShouldBeFalse)
+			hb.Detach()
+			So(hb.GetChildDetached(), ShouldBeTrue)
+			So(hb.GetChild(), ShouldEqual, button)
+			So(hb.CBin.GetChild(), ShouldBeNil)
+			float := hb.float
+			So(float, ShouldNotBeNil)
+			So(float.GetChild(), ShouldEqual, button)
+			destroyed := false
+			float.Connect(SignalDestroyEvent, "handle-box-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				destroyed = true
+				return cdk.EVENT_PASS
+			})
+			// escape within the floating overlay re-docks the child
+			float.ProcessEvent(cdk.NewEventKey(cdk.KeyEscape, 0, cdk.ModNone))
+			So(hb.GetChildDetached(), ShouldBeFalse)
+			So(hb.float, ShouldBeNil)
+			So(destroyed, ShouldBeTrue)
+			So(hb.CBin.GetChild(), ShouldEqual, button)
+		})
+		Convey("signals cancel detaching and attaching", func() {
+			hb.Connect(SignalChildDetached, "handle-box-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				return cdk.EVENT_STOP
+			})
+			hb.Detach()
+			So(hb.GetChildDetached(), ShouldBeFalse)
+			So(hb.float, ShouldBeNil)
+			So(hb.Disconnect(SignalChildDetached, "handle-box-test"), ShouldBeNil)
+			hb.Detach()
+			So(hb.GetChildDetached(), ShouldBeTrue)
+			hb.Connect(SignalChildAttached, "handle-box-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				return cdk.EVENT_STOP
+			})
+			hb.Attach()
+			So(hb.GetChildDetached(), ShouldBeTrue)
+			So(hb.Disconnect(SignalChildAttached, "handle-box-test"), ShouldBeNil)
+			hb.Attach()
+			So(hb.GetChildDetached(), ShouldBeFalse)
+			So(hb.GetChild(), ShouldEqual, button)
+		})
+		Convey("keyboard toggle", func() {
+			hb.GrabFocus()
+			So(hb.IsFocus(), ShouldBeTrue)
+			So(hb.ProcessEvent(cdk.NewEventKey(cdk.KeyEnter, 0, cdk.ModNone)), ShouldEqual, cdk.EVENT_STOP)
+			So(hb.GetChildDetached(), ShouldBeTrue)
+			hb.Attach()
+			So(hb.IsFocus(), ShouldBeTrue)
+			So(hb.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, ' ', cdk.ModNone)), ShouldEqual, cdk.EVENT_STOP)
+			So(hb.GetChildDetached(), ShouldBeTrue)
+			So(hb.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'x', cdk.ModNone)), ShouldEqual, cdk.EVENT_PASS)
+			// destroying a detached handle box closes the floating overlay
+			hb.Destroy()
+			So(hb.GetChildDetached(), ShouldBeFalse)
+			So(hb.float, ShouldBeNil)
+			So(hb.CBin.GetChild(), ShouldEqual, button)
+		})
+		Convey("builder properties", func() {
+			element := &CBuilderElement{Builder: NewBuilder(), Instance: hb}
+			So(hb.GetHandlePosition(), ShouldEqual, POS_LEFT)
+			So(hb.GetSnapEdge(), ShouldEqual, POS_TOP)
+			So(element.ApplyProperty("handle-position", "GTK_POS_TOP"), ShouldBeTrue)
+			So(hb.GetHandlePosition(), ShouldEqual, POS_TOP)
+			So(hb.GetSnapEdge(), ShouldEqual, POS_LEFT)
+			So(element.ApplyProperty("snap-edge", "bottom"), ShouldBeTrue)
+			So(hb.GetSnapEdge(), ShouldEqual, POS_BOTTOM)
+			So(element.ApplyProperty("handle-position", "middle"), ShouldBeFalse)
+			So(hb.GetHandlePosition(), ShouldEqual, POS_TOP)
+		})
+	})
+}
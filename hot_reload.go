@@ -0,0 +1,197 @@
+package ctk
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kckrinke/go-cdk"
+	"github.com/kckrinke/go-cdk/utils"
+)
+
+// Hot reloading re-reads the CSS files loaded by CssProviders and StyleThemes,
+// and the glade files loaded by Builders, whenever they change on disk. CSS
+// files replace the style information of their provider or theme, restyling
+// all Widgets without recreating them. Glade files update the properties and
+// style classes of the objects already built, matched by id, leaving any other
+// Widget state alone. Files are reloaded on the main loop. Errors reloading a
+// file are not fatal, the previously loaded content remains in effect and the
+// error is sent to the active Window as a cdk.EventError, emitting the
+// Window's error signal. Destroying a CssProvider or Builder stops watching
+// its files. This is an opt-in feature intended for development, see
+// EnableHotReload for details.
+
+var (
+	hotReloadFiles      = make(map[string]*hotReloadFile)
+	hotReloadEnabled    bool
+	hotReloadGeneration int
+	hotReloadLock       = &sync.Mutex{}
+	hotReloadOnce       = &sync.Once{}
+)
+
+// The interval at which watched files are checked for changes while hot
+// reloading is enabled
+var HotReloadInterval = time.Millisecond * 500
+
+type hotReloadFile struct {
+	path     string
+	modTime  time.Time
+	size     int64
+	handlers map[interface{}]func() error
+}
+
+func (f *hotReloadFile) update() (changed bool) {
+	var modTime time.Time
+	var size int64
+	if info, err := os.Stat(f.path); err == nil {
+		modTime, size = info.ModTime(), info.Size()
+	}
+	changed = !modTime.Equal(f.modTime) || size != f.size
+	f.modTime, f.size = modTime, size
+	return
+}
+
+// Starts checking the files loaded by CssProviders, StyleThemes and Builders
+// for changes every HotReloadInterval, reloading those which changed. Files
+// loaded before hot reloading is enabled are watched as well. The CTK_HOT_RELOAD
+// environment variable enables hot reloading when a Window is created.
+func EnableHotReload() {
+	hotReloadLock.Lock()
+	defer hotReloadLock.Unlock()
+	if hotReloadEnabled {
+		return
+	}
+	hotReloadEnabled = true
+	hotReloadGeneration++
+	generation := hotReloadGeneration
+	cdk.AddTimeout(HotReloadInterval, func() cdk.EventFlag {
+		hotReloadLock.Lock()
+		running := hotReloadEnabled && generation == hotReloadGeneration
+		hotReloadLock.Unlock()
+		if !running {
+			return cdk.EVENT_STOP
+		}
+		// timeouts do not run on the main loop, only reload from there
+		if _, handlers := checkHotReloadFiles(); len(handlers) > 0 {
+			queueMainCall(func() {
+				runHotReloadHandlers(handlers)
+			})
+		}
+		return cdk.EVENT_PASS
+	})
+}
+
+// Stops checking watched files for changes. Files loaded are still watched
+// and reloaded once hot reloading is enabled again.
+func DisableHotReload() {
+	hotReloadLock.Lock()
+	defer hotReloadLock.Unlock()
+	hotReloadEnabled = false
+}
+
+// Returns TRUE if watched files are being checked for changes
+func HotReloadEnabled() bool {
+	hotReloadLock.Lock()
+	defer hotReloadLock.Unlock()
+	return hotReloadEnabled
+}
+
+// Checks all watched files for changes once, reloading those which changed,
+// regardless of whether hot reloading is enabled. Applications with their own
+// means of noticing changes may call this directly, from the main loop. Returns
+// the paths of the files reloaded, sorted.
+func CheckHotReload() (reloaded []string) {
+	var handlers []func() error
+	reloaded, handlers = checkHotReloadFiles()
+	runHotReloadHandlers(handlers)
+	return
+}
+
+// returns the sorted paths of the watched files which changed since last
+// checked, along with the handlers reloading them
+func checkHotReloadFiles() (changed []string, handlers []func() error) {
+	hotReloadLock.Lock()
+	defer hotReloadLock.Unlock()
+	for path, file := range hotReloadFiles {
+		if file.update() {
+			changed = append(changed, path)
+			for _, fn := range file.handlers {
+				handlers = append(handlers, fn)
+			}
+		}
+	}
+	sort.Strings(changed)
+	return
+}
+
+// call the given reload handlers, reporting any errors. The handlers are
+// called without holding the lock, as handlers watch their files again
+func runHotReloadHandlers(handlers []func() error) {
+	for _, fn := range handlers {
+		if err := fn(); err != nil {
+			reportHotReloadError(err)
+		}
+	}
+}
+
+// watch the file at the given path on behalf of owner, calling fn each time
+// the file changes. An owner watches one file at a time, any file previously
+// watched by owner is no longer watched on its behalf
+func watchHotReload(owner interface{}, path string, fn func() error) {
+	hotReloadLock.Lock()
+	defer hotReloadLock.Unlock()
+	unwatchHotReloadLocked(owner)
+	file, ok := hotReloadFiles[path]
+	if !ok {
+		file = &hotReloadFile{path: path, handlers: make(map[interface{}]func() error)}
+		hotReloadFiles[path] = file
+		file.update()
+	}
+	file.handlers[owner] = fn
+}
+
+// stop watching the file watched on behalf of owner, if any, see
+// CssProvider.Destroy and Builder.Destroy
+func unwatchHotReload(owner interface{}) {
+	hotReloadLock.Lock()
+	defer hotReloadLock.Unlock()
+	unwatchHotReloadLocked(owner)
+}
+
+func unwatchHotReloadLocked(owner interface{}) {
+	for path, file := range hotReloadFiles {
+		if _, ok := file.handlers[owner]; ok {
+			delete(file.handlers, owner)
+			if len(file.handlers) == 0 {
+				delete(hotReloadFiles, path)
+			}
+		}
+	}
+}
+
+// enable hot reloading if requested by the environment, once
+func initHotReload() {
+	hotReloadOnce.Do(func() {
+		if utils.IsTrue(os.Getenv(HotReloadEnvironment)) {
+			EnableHotReload()
+		}
+	})
+}
+
+// send the error to the active Window, logging it when there is none. This
+// must be called from the main loop.
+func reportHotReloadError(err error) {
+	if dm := cdk.GetDisplayManager(); dm != nil {
+		if window, ok := dm.ActiveWindow().(Window); ok && window != nil {
+			window.ProcessEvent(cdk.NewEventError(fmt.Errorf("hot reload: %w", err)))
+			return
+		}
+	}
+	cdk.Error(fmt.Errorf("hot reload: %w", err))
+}
+
+// The environment variable which, set to a true value, enables hot reloading
+// when a Window is created
+const HotReloadEnvironment = "CTK_HOT_RELOAD"
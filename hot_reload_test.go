@@ -0,0 +1,115 @@
+package ctk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHotReload(t *testing.T) {
+	Convey("Testing Hot Reloading", t, func() {
+		dir, err := ioutil.TempDir("", "ctk-hot-reload")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		// change the file with a distinct modification time
+		rewrite := func(path, content string) {
+			So(ioutil.WriteFile(path, []byte(content), 0644), ShouldBeNil)
+			later := time.Now().Add(time.Minute)
+			So(os.Chtimes(path, later, later), ShouldBeNil)
+		}
+		Convey("enabling and disabling", func() {
+			So(HotReloadEnabled(), ShouldBeFalse)
+			EnableHotReload()
+			So(HotReloadEnabled(), ShouldBeTrue)
+			DisableHotReload()
+			So(HotReloadEnabled(), ShouldBeFalse)
+		})
+		Convey("css files", func() {
+			path := filepath.Join(dir, "style.css")
+			So(ioutil.WriteFile(path, []byte("label { color: red; }"), 0644), ShouldBeNil)
+			provider := NewCssProvider()
+			So(provider.LoadFromFile(path), ShouldBeNil)
+			AddProviderForDisplay(provider, STYLE_PROVIDER_PRIORITY_USER)
+			defer RemoveProviderForDisplay(provider)
+			window := NewWindowWithTitle("hot reload")
+			label := NewLabel("label")
+			window.Add(label)
+			So(label.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "red")
+			So(CheckHotReload(), ShouldNotContain, path)
+			rewrite(path, "label { color: green; }")
+			So(CheckHotReload(), ShouldContain, path)
+			So(label.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "green")
+			// errors keep the previous style
+			rewrite(path, "label { color: blue;")
+			So(CheckHotReload(), ShouldContain, path)
+			So(label.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "green")
+			rewrite(path, "label { color: blue; }")
+			So(CheckHotReload(), ShouldContain, path)
+			So(label.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "blue")
+			// loading from a string stops watching the file
+			So(provider.LoadFromString("label { color: yellow; }"), ShouldBeNil)
+			rewrite(path, "label { color: white; }")
+			So(CheckHotReload(), ShouldNotContain, path)
+			So(label.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "yellow")
+			// destroying the provider stops watching the file
+			So(provider.LoadFromFile(path), ShouldBeNil)
+			provider.Destroy()
+			rewrite(path, "label { color: black; }")
+			So(CheckHotReload(), ShouldNotContain, path)
+			So(provider.GetStyleSheet().Rules[0].Properties[0].Value, ShouldEqual, "white")
+		})
+		Convey("glade files", func() {
+			path := filepath.Join(dir, "interface.glade")
+			So(ioutil.WriteFile(path, []byte(`<interface>
+  <object class="GtkLabel" id="hot-label">
+    <property name="label">before</property>
+    <style>
+      <class name="old"/>
+    </style>
+  </object>
+</interface>`), 0644), ShouldBeNil)
+			builder := NewBuilder()
+			top, err := builder.LoadFromFile(path)
+			So(err, ShouldBeNil)
+			label, ok := top.Children[0].Instance.(*CLabel)
+			So(ok, ShouldBeTrue)
+			So(label.GetLabel(), ShouldEqual, "before")
+			So(label.HasClass("old"), ShouldBeTrue)
+			label.AddClass("extra")
+			rewrite(path, `<interface>
+  <object class="GtkLabel" id="hot-label">
+    <property name="label">after</property>
+    <style>
+      <class name="new"/>
+    </style>
+  </object>
+</interface>`)
+			So(CheckHotReload(), ShouldContain, path)
+			So(label.GetLabel(), ShouldEqual, "after")
+			So(label.ListClasses(), ShouldResemble, []string{"extra", "new"})
+			// structural changes are reported and nothing is applied
+			rewrite(path, `<interface>
+  <object class="GtkLabel" id="hot-label">
+    <property name="label">again</property>
+  </object>
+  <object class="GtkButton" id="hot-button"/>
+</interface>`)
+			So(builder.(*CBuilder).reloadFile(path), ShouldNotBeNil)
+			So(label.GetLabel(), ShouldEqual, "after")
+			So(builder.(*CBuilder).reloadFile(filepath.Join(dir, "missing.glade")), ShouldNotBeNil)
+			// destroying the builder stops watching the file
+			builder.Destroy()
+			rewrite(path, `<interface>
+  <object class="GtkLabel" id="hot-label">
+    <property name="label">destroyed</property>
+  </object>
+</interface>`)
+			So(CheckHotReload(), ShouldNotContain, path)
+			So(label.GetLabel(), ShouldEqual, "after")
+		})
+	})
+}
@@ -0,0 +1,161 @@
+package ctk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// Terminal hyperlinks are implemented using the OSC 8 escape sequence, which
+// is supported by a growing number of terminal emulators (VTE based terminals,
+// iTerm2, kitty, WezTerm, Windows Terminal, foot and others). CDK does not know
+// about hyperlinks, so CTK overlays the already rendered link text with the
+// escape sequences once the display has been updated. This is an opt-in
+// feature, see EnableHyperlinks for details.
+
+var (
+	hyperlinkWriter  io.Writer
+	hyperlinkPending []*hyperlinkSpan
+	hyperlinkQueued  bool
+	hyperlinkLock    = &sync.Mutex{}
+)
+
+// The delay between a draw cycle and the emission of hyperlink escape
+// sequences, giving the display a chance to flush its own output first
+var HyperlinkFlushDelay = time.Millisecond * 25
+
+type hyperlinkSpan struct {
+	x, y int
+	text string
+	uri  string
+}
+
+// Returns an OSC 8 terminal hyperlink, wrapping the given text with the
+// sequences necessary for the terminal to make the text clickable. The text
+// is not escaped or modified in any way.
+func HyperlinkSequence(uri, text string) string {
+	return fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", uri, text)
+}
+
+// Attempts to detect if the running terminal emulator supports OSC 8
+// hyperlinks. The CTK_HYPERLINKS environment variable takes precedence and
+// can be set to a true or false value. Otherwise a number of well-known
+// terminal environment variables are inspected.
+func HyperlinksSupported() bool {
+	if v, ok := os.LookupEnv("CTK_HYPERLINKS"); ok {
+		switch strings.ToLower(v) {
+		case "1", "t", "true", "y", "yes", "on":
+			return true
+		}
+		return false
+	}
+	if v := os.Getenv("VTE_VERSION"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 5000 {
+			return true
+		}
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "Hyper":
+		return true
+	}
+	if os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("WT_SESSION") != "" {
+		return true
+	}
+	switch os.Getenv("TERM") {
+	case "xterm-kitty", "foot", "foot-extra", "wezterm":
+		return true
+	}
+	return false
+}
+
+// Enables the emission of OSC 8 hyperlink escape sequences to the given
+// writer, which is expected to be the same terminal the Display is rendered
+// upon (typically os.Stdout). Passing a nil writer disables hyperlinks.
+func EnableHyperlinks(w io.Writer) {
+	hyperlinkLock.Lock()
+	defer hyperlinkLock.Unlock()
+	hyperlinkWriter = w
+	hyperlinkPending = nil
+}
+
+// Stops the emission of OSC 8 hyperlink escape sequences
+func DisableHyperlinks() {
+	EnableHyperlinks(nil)
+}
+
+// Returns TRUE if hyperlink escape sequences are being emitted
+func HyperlinksEnabled() bool {
+	hyperlinkLock.Lock()
+	defer hyperlinkLock.Unlock()
+	return hyperlinkWriter != nil
+}
+
+// queue a single line of link text, located at the given display position,
+// to be overlaid with an OSC 8 hyperlink after the current draw cycle
+func queueHyperlink(x, y int, text, uri string) {
+	hyperlinkLock.Lock()
+	defer hyperlinkLock.Unlock()
+	if hyperlinkWriter == nil || text == "" || uri == "" {
+		return
+	}
+	hyperlinkPending = append(hyperlinkPending, &hyperlinkSpan{x: x, y: y, text: text, uri: uri})
+	if !hyperlinkQueued {
+		hyperlinkQueued = true
+		cdk.AddTimeout(HyperlinkFlushDelay, flushHyperlinks)
+	}
+}
+
+// write all pending hyperlinks, saving and restoring the cursor (and
+// rendition) around each span so the display state is not disturbed
+func flushHyperlinks() cdk.EventFlag {
+	hyperlinkLock.Lock()
+	defer hyperlinkLock.Unlock()
+	hyperlinkQueued = false
+	if hyperlinkWriter == nil || len(hyperlinkPending) == 0 {
+		hyperlinkPending = nil
+		return cdk.EVENT_STOP
+	}
+	buf := &bytes.Buffer{}
+	for _, span := range hyperlinkPending {
+		buf.WriteString("\x1b7")
+		buf.WriteString(fmt.Sprintf("\x1b[%d;%dH", span.y+1, span.x+1))
+		buf.WriteString("\x1b[4m")
+		buf.WriteString(HyperlinkSequence(span.uri, span.text))
+		buf.WriteString("\x1b8")
+	}
+	hyperlinkPending = nil
+	if _, err := hyperlinkWriter.Write(buf.Bytes()); err != nil {
+		cdk.ErrorF("error writing hyperlinks: %v", err)
+	}
+	return cdk.EVENT_STOP
+}
+
+// A convenience function for launching the default handler for the given
+// URI. This is the default action taken when a link is activated and no
+// activate-link signal listener has handled it.
+func ShowUri(uri string) (err error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", uri)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", uri)
+	default:
+		cmd = exec.Command("xdg-open", uri)
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = nil, nil, nil
+	if err = cmd.Start(); err != nil {
+		return
+	}
+	go func() { _ = cmd.Wait() }()
+	return
+}
@@ -0,0 +1,1248 @@
+package ctk
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for IconView objects
+const TypeIconView cdk.CTypeTag = "ctk-icon-view"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeIconView, func() interface{} { return MakeIconView() })
+	bindings := BindingSetByType(TypeIconView)
+	moves := []struct {
+		key   cdk.Key
+		step  MovementStep
+		count int
+	}{
+		{cdk.KeyLeft, MOVEMENT_VISUAL_POSITIONS, -1},
+		{cdk.KeyRight, MOVEMENT_VISUAL_POSITIONS, 1},
+		{cdk.KeyUp, MOVEMENT_DISPLAY_LINES, -1},
+		{cdk.KeyDown, MOVEMENT_DISPLAY_LINES, 1},
+		{cdk.KeyPgUp, MOVEMENT_PAGES, -1},
+		{cdk.KeyPgDn, MOVEMENT_PAGES, 1},
+		{cdk.KeyHome, MOVEMENT_BUFFER_ENDS, -1},
+		{cdk.KeyEnd, MOVEMENT_BUFFER_ENDS, 1},
+	}
+	for _, move := range moves {
+		// shift extends the selection, control moves the cursor only
+		for _, mods := range []cdk.ModMask{cdk.ModNone, cdk.ModShift, cdk.ModCtrl, cdk.ModShift | cdk.ModCtrl} {
+			bindings.AddSignal(move.key, mods, SignalMoveCursor, move.step, move.count, mods.Has(cdk.ModShift), mods.Has(cdk.ModCtrl))
+		}
+	}
+	bindings.AddSignal(cdk.Key('a'), cdk.ModCtrl, SignalSelectAll)
+	bindings.AddSignal(cdk.Key(' '), cdk.ModNone, SignalToggleCursorItem)
+	bindings.AddSignal(cdk.Key(' '), cdk.ModCtrl, SignalToggleCursorItem)
+	bindings.AddSignal(cdk.KeyEnter, cdk.ModNone, SignalActivateCursorItem)
+}
+
+// IconView Hierarchy:
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- IconView
+//
+// IconView provides an alternative view on a list model. It displays the
+// model as a grid of items, each item being a single glyph above a caption.
+// Items flow from left to right and wrap onto as many rows as are needed to
+// fit the allocated width, reflowing whenever the IconView is resized. Like
+// the TreeView, it allows to select one or multiple items (depending on the
+// selection mode, see SetSelectionMode). The cursor is moved with the arrow
+// keys, Shift-arrows extend the selection from the anchor item, Ctrl-arrows
+// move the cursor without changing the selection and Space toggles the
+// selection of the cursor item. Pressing Enter emits the "item-activated"
+// signal for the cursor item.
+type IconView interface {
+	Container
+	Buildable
+
+	Init() (already bool)
+	SetModel(model TreeModel)
+	GetModel() (value TreeModel)
+	SetTextColumn(column int)
+	GetTextColumn() (value int)
+	SetGlyphColumn(column int)
+	GetGlyphColumn() (value int)
+	GetPathAtPos(x int, y int) (path TreePath)
+	SelectedForeach(fn IconViewForeachFunc)
+	SetSelectionMode(mode SelectionMode)
+	GetSelectionMode() (value SelectionMode)
+	SetColumns(columns int)
+	GetColumns() (value int)
+	SetItemWidth(itemWidth int)
+	GetItemWidth() (value int)
+	SetSpacing(spacing int)
+	GetSpacing() (value int)
+	SetRowSpacing(rowSpacing int)
+	GetRowSpacing() (value int)
+	SetColumnSpacing(columnSpacing int)
+	GetColumnSpacing() (value int)
+	SetMargin(margin int)
+	GetMargin() (value int)
+	SelectPath(path TreePath)
+	UnselectPath(path TreePath)
+	PathIsSelected(path TreePath) (value bool)
+	GetSelectedItems() (value []TreePath)
+	SelectAll()
+	UnselectAll()
+	ItemActivated(path TreePath)
+	SetCursor(path TreePath)
+	GetCursor() (path TreePath, ok bool)
+	GetItemRow(path TreePath) (value int)
+	GetItemColumn(path TreePath) (value int)
+	GetVisibleRange() (startPath TreePath, endPath TreePath, ok bool)
+	ScrollToPath(path TreePath)
+	ProcessEvent(evt cdk.Event) cdk.EventFlag
+	GetSizeRequest() (width, height int)
+	Resize() cdk.EventFlag
+	Draw(canvas cdk.Canvas) cdk.EventFlag
+}
+
+// Type of the callback passed to IconView.SelectedForeach, called once for
+// each selected item in the IconView.
+type IconViewForeachFunc = func(iconView IconView, path TreePath)
+
+// The CIconView structure implements the IconView interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with IconView objects
+type CIconView struct {
+	CContainer
+
+	selected    map[int]bool
+	cursor      int
+	anchor      int
+	topRow      int
+	layout      iconViewLayout
+	modelHandle string
+}
+
+// the computed geometry of the IconView grid
+type iconViewLayout struct {
+	items       int
+	columns     int
+	rows        int
+	itemWidth   int
+	itemHeight  int
+	visibleRows int
+}
+
+// Default constructor for IconView objects
+func MakeIconView() *CIconView {
+	return NewIconView()
+}
+
+// Creates a new IconView widget
+// Returns:
+// 	A newly created IconView widget
+func NewIconView() (value *CIconView) {
+	i := new(CIconView)
+	i.Init()
+	return i
+}
+
+// Creates a new IconView widget with the model model .
+// Parameters:
+// 	model	The model.
+// Returns:
+// 	A newly created IconView widget.
+func NewIconViewWithModel(model TreeModel) (value *CIconView) {
+	i := NewIconView()
+	i.SetModel(model)
+	return i
+}
+
+// IconView object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the IconView instance
+func (i *CIconView) Init() (already bool) {
+	if i.InitTypeItem(TypeIconView, i) {
+		return true
+	}
+	i.CContainer.Init()
+	i.flags = NULL_WIDGET_FLAG
+	i.SetFlags(SENSITIVE | PARENT_SENSITIVE | CAN_FOCUS | APP_PAINTABLE)
+	i.selected = make(map[int]bool)
+	i.cursor = -1
+	i.anchor = -1
+	i.topRow = 0
+	i.modelHandle = fmt.Sprintf("%v.model", i.ObjectName())
+	_ = i.InstallProperty(PropertyModel, cdk.StructProperty, true, nil)
+	_ = i.InstallBuildableProperty(PropertyTextColumn, cdk.IntProperty, true, -1)
+	_ = i.InstallBuildableProperty(PropertyGlyphColumn, cdk.IntProperty, true, -1)
+	_ = i.InstallBuildableProperty(PropertyIconViewSelectionMode, cdk.StructProperty, true, SELECTION_SINGLE)
+	_ = i.InstallBuildableProperty(PropertyColumns, cdk.IntProperty, true, -1)
+	_ = i.InstallBuildableProperty(PropertyItemWidth, cdk.IntProperty, true, -1)
+	_ = i.InstallBuildableProperty(PropertySpacing, cdk.IntProperty, true, 0)
+	_ = i.InstallBuildableProperty(PropertyRowSpacing, cdk.IntProperty, true, 1)
+	_ = i.InstallBuildableProperty(PropertyColumnSpacing, cdk.IntProperty, true, 1)
+	_ = i.InstallBuildableProperty(PropertyMargin, cdk.IntProperty, true, 0)
+	i.Connect(SignalMoveCursor, fmt.Sprintf("%v.move-cursor", i.ObjectName()), i.handleMoveCursor)
+	i.Connect(SignalSelectAll, fmt.Sprintf("%v.select-all", i.ObjectName()), func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+		if i.getNItems() == 0 {
+			return cdk.EVENT_PASS
+		}
+		i.SelectAll()
+		return cdk.EVENT_STOP
+	})
+	i.Connect(SignalToggleCursorItem, fmt.Sprintf("%v.toggle-cursor-item", i.ObjectName()), func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+		if i.getNItems() == 0 {
+			return cdk.EVENT_PASS
+		}
+		i.toggleCursorItem()
+		return cdk.EVENT_STOP
+	})
+	i.Connect(SignalActivateCursorItem, fmt.Sprintf("%v.activate-cursor-item", i.ObjectName()), func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+		if path, ok := i.GetCursor(); ok {
+			i.ItemActivated(path)
+			return cdk.EVENT_STOP
+		}
+		return cdk.EVENT_PASS
+	})
+	return false
+}
+
+// Sets the model for a IconView. If the icon_view already has a model set,
+// it will remove it before setting the new model. If model is NULL, then it
+// will unset the old model. The selection and cursor are reset.
+// Parameters:
+// 	model	The model.
+func (i *CIconView) SetModel(model TreeModel) {
+	if prev := i.GetModel(); prev != nil {
+		_ = prev.Disconnect(SignalRowInserted, i.modelHandle)
+		_ = prev.Disconnect(SignalRowDeleted, i.modelHandle)
+		_ = prev.Disconnect(SignalRowChanged, i.modelHandle)
+		_ = prev.Disconnect(SignalRowsReordered, i.modelHandle)
+	}
+	if err := i.SetStructProperty(PropertyModel, model); err != nil {
+		i.LogErr(err)
+		return
+	}
+	i.selected = make(map[int]bool)
+	i.cursor, i.anchor, i.topRow = -1, -1, 0
+	if model != nil {
+		model.Connect(SignalRowInserted, i.modelHandle, i.rowInserted)
+		model.Connect(SignalRowDeleted, i.modelHandle, i.rowDeleted)
+		model.Connect(SignalRowChanged, i.modelHandle, i.rowChanged)
+		model.Connect(SignalRowsReordered, i.modelHandle, i.rowsReordered)
+		if model.IterNChildren(nil) > 0 {
+			i.cursor, i.anchor = 0, 0
+		}
+	}
+	i.Resize()
+}
+
+// Returns the model the IconView is based on. Returns NULL if the model is
+// unset.
+// Returns:
+// 	A TreeModel, or NULL if none is currently being used.
+func (i *CIconView) GetModel() (value TreeModel) {
+	if v, err := i.GetStructProperty(PropertyModel); err != nil {
+		i.LogErr(err)
+	} else if v != nil {
+		var ok bool
+		if value, ok = v.(TreeModel); !ok {
+			i.LogError("value stored in %v property is not of TreeModel type: %v (%T)", PropertyModel, v, v)
+		}
+	}
+	return
+}
+
+// Sets the column with text for icon_view to be column . The text column
+// must be of type string, other values are rendered with their default
+// formatting.
+// Parameters:
+// 	column	A column in the currently used model, or -1 to display no text
+func (i *CIconView) SetTextColumn(column int) {
+	if err := i.SetIntProperty(PropertyTextColumn, column); err != nil {
+		i.LogErr(err)
+	} else {
+		i.Resize()
+	}
+}
+
+// Returns the column with text for icon_view .
+// Returns:
+// 	the text column, or -1 if it's unset.
+func (i *CIconView) GetTextColumn() (value int) {
+	var err error
+	if value, err = i.GetIntProperty(PropertyTextColumn); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// Sets the column with glyphs for icon_view to be column . The glyph column
+// must be of type rune or string, only the first character of a string is
+// displayed.
+// Parameters:
+// 	column	A column in the currently used model, or -1 to disable
+func (i *CIconView) SetGlyphColumn(column int) {
+	if err := i.SetIntProperty(PropertyGlyphColumn, column); err != nil {
+		i.LogErr(err)
+	} else {
+		i.Resize()
+	}
+}
+
+// Returns the column with glyphs for icon_view .
+// Returns:
+// 	the glyph column, or -1 if it's unset.
+func (i *CIconView) GetGlyphColumn() (value int) {
+	var err error
+	if value, err = i.GetIntProperty(PropertyGlyphColumn); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// Finds the path at the point (x , y ), relative to the IconView origin.
+// Parameters:
+// 	x	The x position to be identified
+// 	y	The y position to be identified
+// Returns:
+// 	The TreePath corresponding to the icon or NULL if no icon exists at that
+// 	position.
+func (i *CIconView) GetPathAtPos(x int, y int) (path TreePath) {
+	if idx := i.getIndexAtPos(x, y); idx >= 0 {
+		path = TreePath{idx}
+	}
+	return
+}
+
+// Calls a function for each selected icon. Note that the model or selection
+// cannot be modified from within this function.
+// Parameters:
+// 	fn	The function to call for each selected icon.
+func (i *CIconView) SelectedForeach(fn IconViewForeachFunc) {
+	for _, path := range i.GetSelectedItems() {
+		fn(i, path)
+	}
+}
+
+// Sets the selection mode of the icon_view . Switching to a mode allowing
+// fewer selected items than are currently selected, reduces the selection to
+// the cursor item.
+// Parameters:
+// 	mode	The selection mode
+func (i *CIconView) SetSelectionMode(mode SelectionMode) {
+	if err := i.SetStructProperty(PropertyIconViewSelectionMode, mode); err != nil {
+		i.LogErr(err)
+		return
+	}
+	switch mode {
+	case SELECTION_NONE:
+		i.UnselectAll()
+	case SELECTION_SINGLE, SELECTION_BROWSE:
+		if len(i.selected) > 1 || (mode == SELECTION_BROWSE && len(i.selected) == 0) {
+			i.selectOnly(i.cursor)
+		}
+	}
+}
+
+// Gets the selection mode of the icon_view .
+// Returns:
+// 	the current selection mode
+func (i *CIconView) GetSelectionMode() (value SelectionMode) {
+	var ok bool
+	if v, err := i.GetStructProperty(PropertyIconViewSelectionMode); err != nil {
+		i.LogErr(err)
+	} else if value, ok = v.(SelectionMode); !ok {
+		i.LogError("value stored in %v property is not of SelectionMode type: %v (%T)", PropertyIconViewSelectionMode, v, v)
+	}
+	return
+}
+
+// Sets the ::columns property which determines in how many columns the
+// icons are arranged. If columns is -1, the number of columns will be chosen
+// automatically to fill the available area.
+// Parameters:
+// 	columns	the number of columns
+func (i *CIconView) SetColumns(columns int) {
+	if err := i.SetIntProperty(PropertyColumns, columns); err != nil {
+		i.LogErr(err)
+	} else {
+		i.Resize()
+	}
+}
+
+// Returns the value of the ::columns property.
+// Returns:
+// 	the number of columns, or -1
+func (i *CIconView) GetColumns() (value int) {
+	var err error
+	if value, err = i.GetIntProperty(PropertyColumns); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// Sets the ::item-width property which specifies the width to use for each
+// item. If it is set to -1, the icon view will automatically determine a
+// suitable item size from the widest caption.
+// Parameters:
+// 	itemWidth	the width for each item
+func (i *CIconView) SetItemWidth(itemWidth int) {
+	if err := i.SetIntProperty(PropertyItemWidth, itemWidth); err != nil {
+		i.LogErr(err)
+	} else {
+		i.Resize()
+	}
+}
+
+// Returns the value of the ::item-width property.
+// Returns:
+// 	the width of a single item, or -1
+func (i *CIconView) GetItemWidth() (value int) {
+	var err error
+	if value, err = i.GetIntProperty(PropertyItemWidth); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// Sets the ::spacing property which specifies the number of rows between
+// the glyph and the caption of an item.
+// Parameters:
+// 	spacing	the spacing
+func (i *CIconView) SetSpacing(spacing int) {
+	if err := i.SetIntProperty(PropertySpacing, spacing); err != nil {
+		i.LogErr(err)
+	} else {
+		i.Resize()
+	}
+}
+
+// Returns the value of the ::spacing property.
+// Returns:
+// 	the space between cells
+func (i *CIconView) GetSpacing() (value int) {
+	var err error
+	if value, err = i.GetIntProperty(PropertySpacing); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// Sets the ::row-spacing property which specifies the space which is
+// inserted between the rows of the icon view.
+// Parameters:
+// 	rowSpacing	the row spacing
+func (i *CIconView) SetRowSpacing(rowSpacing int) {
+	if err := i.SetIntProperty(PropertyRowSpacing, rowSpacing); err != nil {
+		i.LogErr(err)
+	} else {
+		i.Resize()
+	}
+}
+
+// Returns the value of the ::row-spacing property.
+// Returns:
+// 	the space between rows
+func (i *CIconView) GetRowSpacing() (value int) {
+	var err error
+	if value, err = i.GetIntProperty(PropertyRowSpacing); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// Sets the ::column-spacing property which specifies the space which is
+// inserted between the columns of the icon view.
+// Parameters:
+// 	columnSpacing	the column spacing
+func (i *CIconView) SetColumnSpacing(columnSpacing int) {
+	if err := i.SetIntProperty(PropertyColumnSpacing, columnSpacing); err != nil {
+		i.LogErr(err)
+	} else {
+		i.Resize()
+	}
+}
+
+// Returns the value of the ::column-spacing property.
+// Returns:
+// 	the space between columns
+func (i *CIconView) GetColumnSpacing() (value int) {
+	var err error
+	if value, err = i.GetIntProperty(PropertyColumnSpacing); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// Sets the ::margin property which specifies the space which is inserted at
+// the top, bottom, left and right of the icon view.
+// Parameters:
+// 	margin	the margin
+func (i *CIconView) SetMargin(margin int) {
+	if err := i.SetIntProperty(PropertyMargin, margin); err != nil {
+		i.LogErr(err)
+	} else {
+		i.Resize()
+	}
+}
+
+// Returns the value of the ::margin property.
+// Returns:
+// 	the space at the borders
+func (i *CIconView) GetMargin() (value int) {
+	var err error
+	if value, err = i.GetIntProperty(PropertyMargin); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// Selects the row at path . In SELECTION_SINGLE and SELECTION_BROWSE modes
+// any previously selected item is unselected.
+// Parameters:
+// 	path	The TreePath to be selected.
+func (i *CIconView) SelectPath(path TreePath) {
+	idx := i.pathIndex(path)
+	if idx < 0 || i.selected[idx] {
+		return
+	}
+	switch i.GetSelectionMode() {
+	case SELECTION_NONE:
+		return
+	case SELECTION_MULTIPLE:
+		i.selected[idx] = true
+		i.selectionChanged()
+	default:
+		i.selectOnly(idx)
+	}
+}
+
+// Unselects the row at path . Items cannot be unselected in
+// SELECTION_BROWSE mode.
+// Parameters:
+// 	path	The TreePath to be unselected.
+func (i *CIconView) UnselectPath(path TreePath) {
+	idx := i.pathIndex(path)
+	if idx < 0 || !i.selected[idx] || i.GetSelectionMode() == SELECTION_BROWSE {
+		return
+	}
+	delete(i.selected, idx)
+	i.selectionChanged()
+}
+
+// Returns TRUE if the icon pointed to by path is currently selected. If
+// path does not point to a valid location, FALSE is returned.
+// Parameters:
+// 	path	A TreePath to check selection on.
+// Returns:
+// 	TRUE if path is selected.
+func (i *CIconView) PathIsSelected(path TreePath) (value bool) {
+	if idx := i.pathIndex(path); idx >= 0 {
+		value = i.selected[idx]
+	}
+	return
+}
+
+// Creates a list of paths of all selected items, in model order.
+// Returns:
+// 	A list containing a TreePath for each selected row.
+func (i *CIconView) GetSelectedItems() (value []TreePath) {
+	indices := make([]int, 0, len(i.selected))
+	for idx := range i.selected {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+	for _, idx := range indices {
+		value = append(value, TreePath{idx})
+	}
+	return
+}
+
+// Selects all the icons. icon_view must has its selection mode set to
+// SELECTION_MULTIPLE.
+func (i *CIconView) SelectAll() {
+	if i.GetSelectionMode() != SELECTION_MULTIPLE {
+		return
+	}
+	n := i.getNItems()
+	if len(i.selected) == n {
+		return
+	}
+	for idx := 0; idx < n; idx++ {
+		i.selected[idx] = true
+	}
+	i.selectionChanged()
+}
+
+// Unselects all the icons.
+func (i *CIconView) UnselectAll() {
+	if len(i.selected) == 0 {
+		return
+	}
+	i.selected = make(map[int]bool)
+	i.selectionChanged()
+}
+
+// Activates the item determined by path .
+// Emits: SignalItemActivated, Argv=[IconView instance, path]
+// Parameters:
+// 	path	The TreePath to be activated
+func (i *CIconView) ItemActivated(path TreePath) {
+	if i.pathIndex(path) >= 0 {
+		i.Emit(SignalItemActivated, i, path)
+	}
+}
+
+// Sets the current keyboard focus to be at path , and selects it. This is
+// useful when you want to focus the user's attention on a particular item.
+// Parameters:
+// 	path	A TreePath
+func (i *CIconView) SetCursor(path TreePath) {
+	if idx := i.pathIndex(path); idx >= 0 {
+		i.cursor, i.anchor = idx, idx
+		if i.GetSelectionMode() != SELECTION_NONE {
+			i.selectOnly(idx)
+		}
+		i.scrollToIndex(idx)
+		i.Invalidate()
+	}
+}
+
+// Returns the current cursor path. If the cursor isn't currently set, then
+// path will be NULL and ok will be FALSE.
+// Returns:
+// 	path	the current cursor path
+// 	ok	TRUE if the cursor is set.
+func (i *CIconView) GetCursor() (path TreePath, ok bool) {
+	if i.cursor >= 0 && i.cursor < i.getNItems() {
+		return TreePath{i.cursor}, true
+	}
+	return nil, false
+}
+
+// Gets the row in which the item path is currently displayed. Row numbers
+// start at 0.
+// Parameters:
+// 	path	the TreePath of the item
+// Returns:
+// 	The row in which the item is displayed, or -1
+func (i *CIconView) GetItemRow(path TreePath) (value int) {
+	if idx := i.pathIndex(path); idx >= 0 && i.layout.columns > 0 {
+		return idx / i.layout.columns
+	}
+	return -1
+}
+
+// Gets the column in which the item path is currently displayed. Column
+// numbers start at 0.
+// Parameters:
+// 	path	the TreePath of the item
+// Returns:
+// 	The column in which the item is displayed, or -1
+func (i *CIconView) GetItemColumn(path TreePath) (value int) {
+	if idx := i.pathIndex(path); idx >= 0 && i.layout.columns > 0 {
+		return idx % i.layout.columns
+	}
+	return -1
+}
+
+// Returns the first and last visible path. Note that there may be invisible
+// paths in between.
+// Returns:
+// 	startPath	start of region
+// 	endPath	end of region
+// 	ok	TRUE if valid paths were placed in startPath and endPath
+func (i *CIconView) GetVisibleRange() (startPath TreePath, endPath TreePath, ok bool) {
+	l := i.layout
+	if l.items == 0 || l.columns <= 0 || l.visibleRows <= 0 {
+		return nil, nil, false
+	}
+	start := i.topRow * l.columns
+	end := (i.topRow+l.visibleRows)*l.columns - 1
+	if end >= l.items {
+		end = l.items - 1
+	}
+	return TreePath{start}, TreePath{end}, true
+}
+
+// Scrolls the icon view so that the item at path is visible.
+// Parameters:
+// 	path	The path of the item to move to.
+func (i *CIconView) ScrollToPath(path TreePath) {
+	if idx := i.pathIndex(path); idx >= 0 {
+		i.scrollToIndex(idx)
+		i.Invalidate()
+	}
+}
+
+func (i *CIconView) ProcessEvent(evt cdk.Event) cdk.EventFlag {
+	switch e := evt.(type) {
+	case *cdk.EventMouse:
+		if e.State() != cdk.BUTTON_PRESS {
+			break
+		}
+		x, y := e.Position()
+		origin := i.GetOrigin()
+		idx := i.getIndexAtPos(x-origin.X, y-origin.Y)
+		if idx < 0 {
+			break
+		}
+		i.GrabFocus()
+		mods := e.Modifiers()
+		if mods.Has(cdk.ModShift) {
+			i.moveCursor(idx, true, false)
+		} else if mods.Has(cdk.ModCtrl) {
+			i.moveCursor(idx, false, true)
+			i.toggleCursorItem()
+		} else {
+			i.moveCursor(idx, false, false)
+		}
+		return cdk.EVENT_STOP
+	}
+	return cdk.EVENT_PASS
+}
+
+// moves the cursor for the "move-cursor" key bindings, the arrow keys and
+// the keys of the key themes
+func (i *CIconView) handleMoveCursor(data []interface{}, argv ...interface{}) cdk.EventFlag {
+	if len(argv) < 3 {
+		return cdk.EVENT_PASS
+	}
+	step, ok := argv[1].(MovementStep)
+	count, cok := argv[2].(int)
+	if !ok || !cok {
+		i.LogError("invalid move-cursor arguments: %v", argv[1:])
+		return cdk.EVENT_PASS
+	}
+	var extend, keep bool
+	if len(argv) > 3 {
+		extend, _ = argv[3].(bool)
+	}
+	if len(argv) > 4 {
+		keep, _ = argv[4].(bool)
+	}
+	n := i.getNItems()
+	if n == 0 {
+		return cdk.EVENT_PASS
+	}
+	cols := i.layout.columns
+	if cols < 1 {
+		cols = 1
+	}
+	page := i.layout.visibleRows * cols
+	if page < 1 {
+		page = cols
+	}
+	cursor := i.cursor
+	if cursor < 0 {
+		cursor = 0
+	}
+	switch step {
+	case MOVEMENT_LOGICAL_POSITIONS, MOVEMENT_VISUAL_POSITIONS:
+		return i.moveCursor(cursor+count, extend, keep)
+	case MOVEMENT_DISPLAY_LINES, MOVEMENT_PARAGRAPHS:
+		return i.moveCursor(cursor+count*cols, extend, keep)
+	case MOVEMENT_PAGES:
+		return i.moveCursor(cursor+count*page, extend, keep)
+	case MOVEMENT_DISPLAY_LINE_ENDS, MOVEMENT_PARAGRAPH_ENDS:
+		start := cursor - cursor%cols
+		if count < 0 {
+			return i.moveCursor(start, extend, keep)
+		}
+		return i.moveCursor(start+cols-1, extend, keep)
+	case MOVEMENT_BUFFER_ENDS:
+		if count < 0 {
+			return i.moveCursor(0, extend, keep)
+		}
+		return i.moveCursor(n-1, extend, keep)
+	}
+	return cdk.EVENT_PASS
+}
+
+// Returns the size requested by the IconView. Unless a number of columns is
+// configured, the width requested is that of a single item as the grid
+// reflows to fit the allocated width. The height requested is enough to show
+// all items at the current or requested width.
+func (i *CIconView) GetSizeRequest() (width, height int) {
+	size := cdk.NewRectangle(i.CWidget.GetSizeRequest())
+	margin := i.GetMargin()
+	itemWidth := i.getItemWidth(-1)
+	if size.W <= -1 {
+		columns := i.GetColumns()
+		if columns < 1 {
+			columns = 1
+		}
+		size.W = (columns * itemWidth) + ((columns - 1) * i.GetColumnSpacing()) + (margin * 2)
+	}
+	if size.H <= -1 {
+		alloc := i.GetAllocation()
+		w := size.W
+		if alloc.W > w {
+			w = alloc.W
+		}
+		l := i.computeLayout(w, 0)
+		size.H = (l.rows * l.itemHeight) + (margin * 2)
+		if l.rows > 1 {
+			size.H += (l.rows - 1) * i.GetRowSpacing()
+		}
+	}
+	return size.W, size.H
+}
+
+func (i *CIconView) Resize() cdk.EventFlag {
+	i.Lock()
+	alloc := i.GetAllocation()
+	i.layout = i.computeLayout(alloc.W, alloc.H)
+	if i.cursor >= i.layout.items {
+		i.cursor = i.layout.items - 1
+	}
+	i.Unlock()
+	i.scrollToIndex(i.cursor)
+	i.Invalidate()
+	return i.Emit(SignalResize, i)
+}
+
+func (i *CIconView) Draw(canvas cdk.Canvas) cdk.EventFlag {
+	i.Lock()
+	defer i.Unlock()
+	alloc := i.GetAllocation()
+	if !i.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+		i.LogTrace("IconView.Draw(): not visible, zero width or zero height")
+		return cdk.EVENT_PASS
+	}
+	theme := i.GetThemeRequest()
+	canvas.Fill(theme)
+	model := i.GetModel()
+	l := i.layout
+	if model == nil || l.items == 0 || l.columns <= 0 {
+		return cdk.EVENT_STOP
+	}
+	glyphColumn, textColumn := i.GetGlyphColumn(), i.GetTextColumn()
+	spacing := i.GetSpacing()
+	focused := i.IsFocus()
+	start := i.topRow * l.columns
+	end := (i.topRow + l.visibleRows) * l.columns
+	if end > l.items {
+		end = l.items
+	}
+	for idx := start; idx < end; idx++ {
+		iter, ok := model.IterNthChild(nil, idx)
+		if !ok {
+			continue
+		}
+		x, y := i.getItemOrigin(idx)
+		style := theme.Content.Normal
+		if i.selected[idx] && idx == i.cursor && focused {
+			style = theme.Content.Focused.Reverse(true)
+		} else if i.selected[idx] {
+			style = theme.Content.Active
+		} else if idx == i.cursor && focused {
+			style = theme.Content.Focused
+		}
+		for dy := 0; dy < l.itemHeight; dy++ {
+			for dx := 0; dx < l.itemWidth; dx++ {
+				_ = canvas.SetRune(x+dx, y+dy, ' ', style)
+			}
+		}
+		row := y
+		if glyphColumn > -1 {
+			if glyph := iconViewGlyph(model.GetValue(iter, glyphColumn)); glyph != 0 {
+				_ = canvas.SetRune(x+(l.itemWidth-1)/2, row, glyph, style)
+			}
+			row += 1 + spacing
+		}
+		if textColumn > -1 {
+			caption := []rune(iconViewText(model.GetValue(iter, textColumn)))
+			if len(caption) > l.itemWidth {
+				caption = append(caption[:l.itemWidth-1], '…')
+			}
+			offset := (l.itemWidth - len(caption)) / 2
+			for cx, r := range caption {
+				_ = canvas.SetRune(x+offset+cx, row, r, style)
+			}
+		}
+	}
+	if debug, _ := i.GetBoolProperty(cdk.PropertyDebug); debug {
+		canvas.DebugBox(cdk.ColorSilver, i.ObjectInfo())
+	}
+	return cdk.EVENT_STOP
+}
+
+// move the cursor to the given index, clamped to the items available. When
+// extend is set (and the selection mode is multiple) the selection becomes
+// the range between the anchor and the new cursor. When keep is set the
+// selection is left as is, otherwise the cursor item becomes the selection.
+func (i *CIconView) moveCursor(idx int, extend, keep bool) cdk.EventFlag {
+	n := i.getNItems()
+	if n == 0 {
+		return cdk.EVENT_PASS
+	}
+	if idx < 0 {
+		idx = 0
+	} else if idx >= n {
+		idx = n - 1
+	}
+	mode := i.GetSelectionMode()
+	i.cursor = idx
+	switch {
+	case extend && mode == SELECTION_MULTIPLE:
+		if i.anchor < 0 || i.anchor >= n {
+			i.anchor = idx
+		}
+		from, to := i.anchor, idx
+		if from > to {
+			from, to = to, from
+		}
+		i.selected = make(map[int]bool)
+		for s := from; s <= to; s++ {
+			i.selected[s] = true
+		}
+		i.selectionChanged()
+	case keep && mode == SELECTION_MULTIPLE:
+		i.anchor = idx
+	default:
+		i.anchor = idx
+		if mode != SELECTION_NONE {
+			i.selectOnly(idx)
+		}
+	}
+	i.scrollToIndex(idx)
+	i.Invalidate()
+	return cdk.EVENT_STOP
+}
+
+// toggle the selection state of the cursor item, honouring the constraints
+// of the selection mode
+func (i *CIconView) toggleCursorItem() {
+	if i.cursor < 0 || i.cursor >= i.getNItems() {
+		return
+	}
+	path := TreePath{i.cursor}
+	i.anchor = i.cursor
+	if i.selected[i.cursor] {
+		i.UnselectPath(path)
+	} else {
+		i.SelectPath(path)
+	}
+	i.Invalidate()
+}
+
+func (i *CIconView) selectOnly(idx int) {
+	if idx < 0 || idx >= i.getNItems() {
+		i.UnselectAll()
+		return
+	}
+	if len(i.selected) == 1 && i.selected[idx] {
+		return
+	}
+	i.selected = map[int]bool{idx: true}
+	i.selectionChanged()
+}
+
+func (i *CIconView) selectionChanged() {
+	i.Emit(SignalSelectionChanged, i)
+	i.Invalidate()
+}
+
+func (i *CIconView) scrollToIndex(idx int) {
+	l := i.layout
+	if idx < 0 || l.columns <= 0 || l.visibleRows <= 0 {
+		i.topRow = 0
+		return
+	}
+	row := idx / l.columns
+	if row < i.topRow {
+		i.topRow = row
+	} else if row >= i.topRow+l.visibleRows {
+		i.topRow = row - l.visibleRows + 1
+	}
+	if limit := l.rows - l.visibleRows; i.topRow > limit {
+		if limit < 0 {
+			limit = 0
+		}
+		i.topRow = limit
+	}
+}
+
+func (i *CIconView) getNItems() int {
+	if model := i.GetModel(); model != nil {
+		return model.IterNChildren(nil)
+	}
+	return 0
+}
+
+func (i *CIconView) pathIndex(path TreePath) int {
+	if len(path) == 1 && path[0] >= 0 && path[0] < i.getNItems() {
+		return path[0]
+	}
+	return -1
+}
+
+// the local coordinates of the top-left cell of the item at idx
+func (i *CIconView) getItemOrigin(idx int) (x, y int) {
+	l := i.layout
+	margin := i.GetMargin()
+	x = margin + (idx%l.columns)*(l.itemWidth+i.GetColumnSpacing())
+	y = margin + (idx/l.columns-i.topRow)*(l.itemHeight+i.GetRowSpacing())
+	return
+}
+
+func (i *CIconView) getIndexAtPos(x, y int) int {
+	l := i.layout
+	if l.columns <= 0 {
+		return -1
+	}
+	start := i.topRow * l.columns
+	end := (i.topRow + l.visibleRows) * l.columns
+	if end > l.items {
+		end = l.items
+	}
+	for idx := start; idx < end; idx++ {
+		ix, iy := i.getItemOrigin(idx)
+		if x >= ix && x < ix+l.itemWidth && y >= iy && y < iy+l.itemHeight {
+			return idx
+		}
+	}
+	return -1
+}
+
+// the configured item width, or the widest caption (and glyph) in the model,
+// limited to maxWidth when maxWidth is positive
+func (i *CIconView) getItemWidth(maxWidth int) (width int) {
+	if width = i.GetItemWidth(); width <= 0 {
+		width = 1
+		if model := i.GetModel(); model != nil {
+			if textColumn := i.GetTextColumn(); textColumn > -1 {
+				model.ForEach(func(model TreeModel, path TreePath, iter *TreeIter) bool {
+					if w := utf8.RuneCountInString(iconViewText(model.GetValue(iter, textColumn))); w > width {
+						width = w
+					}
+					return false
+				})
+			}
+		}
+	}
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	return
+}
+
+// compute the grid geometry for the given size, a height of zero or less
+// means all rows are considered visible
+func (i *CIconView) computeLayout(width, height int) (l iconViewLayout) {
+	margin := i.GetMargin()
+	l.items = i.getNItems()
+	l.itemWidth = i.getItemWidth(width - (margin * 2))
+	l.itemHeight = 0
+	if i.GetGlyphColumn() > -1 {
+		l.itemHeight += 1 + i.GetSpacing()
+	}
+	if i.GetTextColumn() > -1 || l.itemHeight == 0 {
+		l.itemHeight += 1
+	}
+	colSpacing, rowSpacing := i.GetColumnSpacing(), i.GetRowSpacing()
+	if l.columns = i.GetColumns(); l.columns <= 0 {
+		l.columns = (width - (margin * 2) + colSpacing) / (l.itemWidth + colSpacing)
+	}
+	if l.columns < 1 {
+		l.columns = 1
+	}
+	l.rows = (l.items + l.columns - 1) / l.columns
+	if height <= 0 {
+		l.visibleRows = l.rows
+	} else if l.visibleRows = (height - (margin * 2) + rowSpacing) / (l.itemHeight + rowSpacing); l.visibleRows < 1 {
+		l.visibleRows = 1
+	}
+	return
+}
+
+func (i *CIconView) rowInserted(data []interface{}, argv ...interface{}) cdk.EventFlag {
+	if len(argv) > 1 {
+		if path, ok := argv[1].(TreePath); ok && len(path) == 1 {
+			shifted := make(map[int]bool)
+			for idx := range i.selected {
+				if idx >= path[0] {
+					idx++
+				}
+				shifted[idx] = true
+			}
+			i.selected = shifted
+			if i.cursor < 0 {
+				i.cursor, i.anchor = path[0], path[0]
+			} else if i.cursor >= path[0] {
+				i.cursor++
+			}
+		}
+	}
+	i.Resize()
+	return cdk.EVENT_PASS
+}
+
+func (i *CIconView) rowDeleted(data []interface{}, argv ...interface{}) cdk.EventFlag {
+	if len(argv) > 1 {
+		if path, ok := argv[1].(TreePath); ok && len(path) == 1 {
+			changed := i.selected[path[0]]
+			shifted := make(map[int]bool)
+			for idx := range i.selected {
+				if idx > path[0] {
+					shifted[idx-1] = true
+				} else if idx < path[0] {
+					shifted[idx] = true
+				}
+			}
+			i.selected = shifted
+			if i.cursor > path[0] {
+				i.cursor--
+			}
+			if i.anchor >= path[0] {
+				i.anchor = i.cursor
+			}
+			if changed {
+				i.Emit(SignalSelectionChanged, i)
+			}
+		}
+	}
+	i.Resize()
+	return cdk.EVENT_PASS
+}
+
+func (i *CIconView) rowChanged(data []interface{}, argv ...interface{}) cdk.EventFlag {
+	i.Resize()
+	return cdk.EVENT_PASS
+}
+
+func (i *CIconView) rowsReordered(data []interface{}, argv ...interface{}) cdk.EventFlag {
+	if len(argv) > 3 {
+		if order, ok := argv[3].([]int); ok {
+			moved := make(map[int]bool)
+			cursor := i.cursor
+			for newIdx, oldIdx := range order {
+				if i.selected[oldIdx] {
+					moved[newIdx] = true
+				}
+				if oldIdx == i.cursor {
+					cursor = newIdx
+				}
+			}
+			i.selected = moved
+			i.cursor, i.anchor = cursor, cursor
+		}
+	}
+	i.Invalidate()
+	return cdk.EVENT_PASS
+}
+
+func iconViewText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case rune:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func iconViewGlyph(value interface{}) rune {
+	switch v := value.(type) {
+	case rune:
+		return v
+	case string:
+		if r, size := utf8.DecodeRuneInString(v); size > 0 {
+			return r
+		}
+	}
+	return 0
+}
+
+// The number of columns of the icon view. By default, -1, the number of
+// columns is chosen automatically to fill the available area.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertyColumns cdk.Property = "columns"
+
+// The glyph-column property contains the number of the model column
+// containing the glyphs which are displayed. The glyph column must be of
+// type rune or string. Setting this property to -1 turns off the display of
+// glyphs.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertyGlyphColumn cdk.Property = "glyph-column"
+
+// The item-width property specifies the width to use for each item. If it
+// is set to -1, the icon view will automatically determine a suitable item
+// size.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertyItemWidth cdk.Property = "item-width"
+
+// The margin property specifies the space which is inserted at the edges
+// of the icon view.
+// Flags: Read / Write
+// Allowed values: >= 0
+// Default value: 0
+const PropertyMargin cdk.Property = "margin"
+
+// The model for the icon view.
+// Flags: Read / Write
+const PropertyModel cdk.Property = "model"
+
+// The column-spacing property specifies the space which is inserted between
+// the columns of the icon view.
+// Flags: Read / Write
+// Allowed values: >= 0
+// Default value: 1
+const PropertyColumnSpacing cdk.Property = "column-spacing"
+
+// The row-spacing property specifies the space which is inserted between
+// the rows of the icon view.
+// Flags: Read / Write
+// Allowed values: >= 0
+// Default value: 1
+const PropertyRowSpacing cdk.Property = "row-spacing"
+
+// The ::selection-mode property specifies the selection mode of icon view.
+// If the mode is SELECTION_MULTIPLE, rubberband selection is not supported
+// and multiple items are selected with the keyboard or mouse modifiers.
+// Flags: Read / Write
+// Default value: GTK_SELECTION_SINGLE
+const PropertyIconViewSelectionMode cdk.Property = "selection-mode"
+
+// The ::text-column property contains the number of the model column
+// containing the texts which are displayed. Setting this property to -1
+// turns off the display of texts.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertyTextColumn cdk.Property = "text-column"
+
+// The ::item-activated signal is emitted when the method ItemActivated is
+// called or the user presses Enter on an item.
+// Listener function arguments:
+// 	path TreePath	the TreePath for the activated item
+const SignalItemActivated cdk.Signal = "item-activated"
+
+// The ::selection-changed signal is emitted when the selection changes.
+const SignalSelectionChanged cdk.Signal = "selection-changed"
+
+// The ::select-all signal is a keybinding signal which gets emitted when the
+// user selects all items. The default binding for this signal is Ctrl-a.
+const SignalSelectAll cdk.Signal = "select-all"
+
+// The ::toggle-cursor-item signal is a keybinding signal which gets emitted
+// when the user toggles whether the currently focused item is selected or
+// not. The default bindings for this signal are Space and Ctrl-Space.
+const SignalToggleCursorItem cdk.Signal = "toggle-cursor-item"
+
+// The ::activate-cursor-item signal is a keybinding signal which gets emitted
+// when the user activates the currently focused item. The default binding
+// for this signal is Enter.
+const SignalActivateCursorItem cdk.Signal = "activate-cursor-item"
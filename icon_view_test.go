@@ -0,0 +1,102 @@
+package ctk
+
+import (
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIconView(t *testing.T) {
+	Convey("Testing IconViews", t, func() {
+		Convey("list store basics", func() {
+			store := NewListStore(2)
+			So(store.GetNColumns(), ShouldEqual, 2)
+			store.AppendValues('a', "alpha")
+			store.AppendValues('c', "gamma")
+			iter := store.Insert(1)
+			store.Set(iter, 'b', "beta")
+			So(store.IterNChildren(nil), ShouldEqual, 3)
+			So(store.GetPath(iter).String(), ShouldEqual, "1")
+			first, ok := store.GetIterFirst()
+			So(ok, ShouldBeTrue)
+			So(store.GetValue(first, 1), ShouldEqual, "alpha")
+			So(store.IterNext(first), ShouldBeTrue)
+			So(store.GetValue(first, 1), ShouldEqual, "beta")
+			So(store.Remove(first), ShouldBeTrue)
+			So(store.GetValue(first, 1), ShouldEqual, "gamma")
+			So(store.IterNChildren(nil), ShouldEqual, 2)
+			path, err := NewTreePathFromString("0:2")
+			So(err, ShouldBeNil)
+			So(path.Compare(TreePath{0, 1}), ShouldEqual, 1)
+			_, err = NewTreePathFromString("a")
+			So(err, ShouldNotBeNil)
+		})
+		Convey("reflowing layout", func() {
+			store := NewListStore(2)
+			for _, name := range []string{"one", "two", "three", "four", "five"} {
+				store.AppendValues('*', name)
+			}
+			iv := NewIconViewWithModel(store)
+			iv.SetGlyphColumn(0)
+			iv.SetTextColumn(1)
+			iv.Show()
+			iv.SetOrigin(0, 0)
+			iv.SetAllocation(cdk.MakeRectangle(17, 10))
+			iv.Resize()
+			// items are 5 wide with a single column of spacing
+			So(iv.GetItemColumn(TreePath{2}), ShouldEqual, 2)
+			So(iv.GetItemRow(TreePath{3}), ShouldEqual, 1)
+			So(iv.GetPathAtPos(7, 0).String(), ShouldEqual, "1")
+			So(iv.GetPathAtPos(5, 0), ShouldBeNil)
+			iv.SetAllocation(cdk.MakeRectangle(11, 10))
+			iv.Resize()
+			So(iv.GetItemColumn(TreePath{2}), ShouldEqual, 0)
+			So(iv.GetItemRow(TreePath{4}), ShouldEqual, 2)
+			w, h := iv.GetSizeRequest()
+			So(w, ShouldEqual, 5)
+			So(h, ShouldEqual, 8)
+		})
+		Convey("selection and activation", func() {
+			store := NewListStore(1)
+			for _, name := range []string{"a", "b", "c", "d"} {
+				store.AppendValues(name)
+			}
+			iv := NewIconViewWithModel(store)
+			iv.SetTextColumn(0)
+			iv.SetAllocation(cdk.MakeRectangle(20, 4))
+			iv.Resize()
+			path, ok := iv.GetCursor()
+			So(ok, ShouldBeTrue)
+			So(path.String(), ShouldEqual, "0")
+			iv.SelectPath(TreePath{1})
+			iv.SelectPath(TreePath{2})
+			So(iv.GetSelectedItems(), ShouldResemble, []TreePath{{2}})
+			iv.SetSelectionMode(SELECTION_MULTIPLE)
+			iv.SelectPath(TreePath{3})
+			So(iv.GetSelectedItems(), ShouldResemble, []TreePath{{2}, {3}})
+			iv.SetCursor(TreePath{0})
+			iv.moveCursor(2, true, false)
+			So(iv.GetSelectedItems(), ShouldResemble, []TreePath{{0}, {1}, {2}})
+			iv.moveCursor(3, false, true)
+			iv.toggleCursorItem()
+			So(iv.PathIsSelected(TreePath{3}), ShouldBeTrue)
+			first, _ := store.GetIterFirst()
+			store.Remove(first)
+			So(iv.GetSelectedItems(), ShouldResemble, []TreePath{{0}, {1}, {2}})
+			iv.UnselectAll()
+			So(iv.GetSelectedItems(), ShouldHaveLength, 0)
+			activated := ""
+			iv.Connect(SignalItemActivated, "test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				if len(argv) > 1 {
+					if p, ok := argv[1].(TreePath); ok {
+						activated = p.String()
+					}
+				}
+				return cdk.EVENT_PASS
+			})
+			iv.ItemActivated(TreePath{1})
+			So(activated, ShouldEqual, "1")
+		})
+	})
+}
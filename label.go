@@ -1,8 +1,11 @@
 package ctk
 
 import (
+	"fmt"
+	"html"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/kckrinke/go-cdk"
 	"github.com/kckrinke/go-cdk/utils"
@@ -98,6 +101,7 @@ type Label interface {
 	Resize() cdk.EventFlag
 	Draw(canvas cdk.Canvas) cdk.EventFlag
 	Invalidate() cdk.EventFlag
+	ProcessEvent(evt cdk.Event) cdk.EventFlag
 }
 
 // The CLabel structure implements the Label interface and is
@@ -110,7 +114,28 @@ type CLabel struct {
 	text    string
 	tbuffer cdk.TextBuffer
 	tbStyle cdk.Style
+	tbShown bool
 	canvas  *cdk.CCanvas
+
+	mnemonicKey    rune
+	mnemonicTarget Widget
+	mnemonicWindow Window
+
+	links       []*labelLink
+	visited     map[string]bool
+	focusedLink int
+	hoverLink   int
+	linkFocus   bool
+	textOrigin  cdk.Point2I
+}
+
+// labelLink tracks a single <a href> hyperlink within the label markup and
+// where it was last rendered on the label canvas
+type labelLink struct {
+	uri   string
+	title string
+	text  string
+	cells []cdk.Point2I
 }
 
 // Default constructor for Label objects
@@ -186,6 +211,16 @@ func (l *CLabel) Init() (already bool) {
 	_ = l.InstallProperty(PropertyWrapMode, cdk.StructProperty, true, cdk.WRAP_WORD)
 	l.text = ""
 	l.tbuffer = nil
+	l.links = nil
+	l.visited = make(map[string]bool)
+	l.focusedLink = -1
+	l.hoverLink = -1
+	l.linkFocus = false
+	handle := fmt.Sprintf("%v.links", l.ObjectName())
+	l.Connect(SignalGainedFocus, handle, l.handleLinkGainedFocus)
+	l.Connect(SignalLostFocus, handle, l.handleLinkLostFocus)
+	l.Connect(SignalFocus, handle, l.handleLinkFocus)
+	l.Connect(SignalLeave, handle, l.handleLinkLeave)
 	// _ = l.SetBoolProperty(PropertyDebug, true)
 	l.canvas = cdk.NewCanvas(cdk.Point2I{}, cdk.Rectangle{}, l.GetTheme().Content.Normal)
 	l.Invalidate()
@@ -220,7 +255,11 @@ func (l *CLabel) SetText(text string) {
 	defer l.Unlock()
 	l.SetUseMarkup(false)
 	l.text = text
-	l.tbuffer = cdk.NewTextBuffer(text, l.GetTheme().Content.Normal, l.GetUseUnderline())
+	if err := l.SetStringProperty(PropertyLabel, text); err != nil {
+		l.LogErr(err)
+	}
+	l.setLinks(nil)
+	l.tbuffer = cdk.NewTextBuffer(l.bufferText(text, false), l.GetTheme().Content.Normal, l.GetUseUnderline())
 	l.Invalidate()
 }
 
@@ -238,17 +277,27 @@ func (l *CLabel) SetAttributes(attrs cdk.Style) {
 // the label's text and attribute list based on the parse results. If the str
 // is external data, you may need to escape it with g_markup_escape_text or
 // g_markup_printf_escaped:
+//
+// The markup may contain hyperlinks in the form of <a href="uri">text</a>,
+// optionally with a title attribute. Labels with links become focusable, the
+// Tab key cycles through the links and Enter (or a mouse click) emits the
+// activate-link signal for the current link.
 // Parameters:
 // 	str	a markup string (see Pango markup format)
 func (l *CLabel) SetMarkup(text string) (parseError error) {
 	l.Lock()
 	defer l.Unlock()
 	var m cdk.Tango
-	if m, parseError = cdk.NewMarkup(text, l.GetTheme().Content.Normal); parseError != nil {
+	markup, links := parseLabelLinks(text)
+	if m, parseError = cdk.NewMarkup(l.bufferText(markup, true), l.GetTheme().Content.Normal); parseError != nil {
 		return parseError
 	}
 	l.SetUseMarkup(true)
 	l.text = text
+	if err := l.SetStringProperty(PropertyLabel, text); err != nil {
+		l.LogErr(err)
+	}
+	l.setLinks(links)
 	l.tbuffer = m.TextBuffer(l.GetUseUnderline())
 	l.Invalidate()
 	return nil
@@ -344,13 +393,7 @@ func (l *CLabel) SetLineWrapMode(wrapMode cdk.WrapMode) {
 // 	GDK keyval usable for accelerators, or GDK_VoidSymbol
 func (l *CLabel) GetMnemonicKeyVal() (value rune) {
 	if l.GetUseUnderline() {
-		label := l.GetClearText()
-		if rxLabelMnemonic.MatchString(label) {
-			m := rxLabelMnemonic.FindStringSubmatch(label)
-			if len(m) > 1 {
-				return rune(strings.ToLower(m[1])[0])
-			}
-		}
+		value, _ = scanLabelMnemonics(l.text, l.GetUseMarkup())
 	}
 	return
 }
@@ -389,13 +432,15 @@ func (l *CLabel) SelectRegion(startOffset int, endOffset int) {}
 // SetMarkupWithMnemonic, SetTextWithMnemonic,
 // NewWithMnemonic or the "use_underline" property) the label
 // can be associated with a widget that is the target of the mnemonic. When
-// the label is inside a widget (like a Button or a Notebook tab) it is
-// automatically associated with the correct widget, but sometimes (i.e. when
-// the target is a Entry next to the label) you need to set it explicitly
-// using this function. The target widget will be accelerated by emitting the
-// Widget::mnemonic-activate signal on it. The default handler for this
-// signal will activate the widget if there are no mnemonic collisions and
-// toggle focus between the colliding widgets otherwise.
+// the label is inside a widget (like a Button, LinkButton, Frame or
+// AspectFrame) it is automatically associated with the correct widget, but
+// sometimes (i.e. when the target is a Entry next to the label) you need to
+// set it explicitly using this function. The target widget will be accelerated
+// by emitting the Widget::mnemonic-activate signal on it. The default handler
+// for this signal will activate the widget if there are no mnemonic collisions
+// and toggle focus between the colliding widgets otherwise. Only mnemonics of
+// Labels are registered, text drawn without a Label (such as the titles of
+// Windows and Dialogs) has no mnemonics.
 // Parameters:
 // 	widget	the target Widget.
 func (l *CLabel) SetMnemonicWidget(widget Widget) {
@@ -637,6 +682,9 @@ func (l *CLabel) SetUseMarkup(setting bool) {
 func (l *CLabel) SetUseUnderline(setting bool) {
 	if err := l.SetBoolProperty(PropertyUseUnderline, setting); err != nil {
 		l.LogErr(err)
+	} else if l.tbuffer != nil {
+		// rebuild the text buffer with or without the mnemonics
+		l.SetLabel(l.GetLabel())
 	} else {
 		l.Invalidate()
 	}
@@ -662,6 +710,12 @@ func (l *CLabel) SetSingleLineMode(singleLineMode bool) {
 // 	the currently active URI. The string is owned by CTK and must
 // 	not be freed or modified.
 func (l *CLabel) GetCurrentUri() (value string) {
+	if l.hoverLink >= 0 && l.hoverLink < len(l.links) {
+		return l.links[l.hoverLink].uri
+	}
+	if l.focusedLink >= 0 && l.focusedLink < len(l.links) {
+		return l.links[l.focusedLink].uri
+	}
 	return ""
 }
 
@@ -703,9 +757,54 @@ func (l *CLabel) SetTheme(theme cdk.Theme) {
 
 var (
 	rxLabelPlainText = regexp.MustCompile(`(?msi)(_)([a-z])`)
-	rxLabelMnemonic  = regexp.MustCompile(`(?msi)_([a-z])`)
 )
 
+// returns the lowercase letter of the first mnemonic within the label text and
+// the text with the underscores of all mnemonics removed. A double underscore
+// is a literal underscore. Markup tags are left as-is when markup is TRUE
+func scanLabelMnemonics(text string, markup bool) (keyval rune, stripped string) {
+	var sb strings.Builder
+	runes := []rune(text)
+	inTag := false
+	for idx := 0; idx < len(runes); idx++ {
+		r := runes[idx]
+		switch {
+		case markup && r == '<':
+			inTag = true
+		case markup && r == '>':
+			inTag = false
+		case !inTag && r == '_' && idx+1 < len(runes) && runes[idx+1] == '_':
+			idx++
+		case !inTag && r == '_' && idx+1 < len(runes) && runes[idx+1] < unicode.MaxASCII && unicode.IsLetter(runes[idx+1]):
+			if keyval == 0 {
+				keyval = unicode.ToLower(runes[idx+1])
+			}
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return keyval, sb.String()
+}
+
+// returns TRUE if the mnemonic underlines are to be drawn, see
+// Window.GetMnemonicsShown
+func (l *CLabel) mnemonicsShown() bool {
+	if w := l.GetWindow(); w != nil {
+		return w.GetMnemonicsShown()
+	}
+	return true
+}
+
+// returns the text or markup given to the text buffer, which has the mnemonic
+// underscores removed while the mnemonic underlines are not to be drawn
+func (l *CLabel) bufferText(text string, markup bool) string {
+	l.tbShown = l.mnemonicsShown()
+	if l.GetUseUnderline() && !l.tbShown {
+		_, text = scanLabelMnemonics(text, markup)
+	}
+	return text
+}
+
 func (l *CLabel) GetClearText() (text string) {
 	if l.tbuffer == nil {
 		return ""
@@ -761,9 +860,15 @@ func (l *CLabel) GetPlainTextInfoAtWidth(width int) (maxWidth, lineCount int) {
 // TODO: label size request handling is wonky
 // TODO: scrolled viewport resize issues
 
+// Returns the size request of the label. Explicit requests, including those
+// of a SizeGroup, are reported as is. The natural size is the size of the text
+// plus the padding, border and margin of the label.
 func (l *CLabel) GetSizeRequest() (width, height int) {
 	size := cdk.NewRectangle(l.CWidget.GetSizeRequest())
-	if size.W <= -1 {
+	// add padding, border and margin to the natural size only
+	insets := l.getStyleBox().insets()
+	naturalW, naturalH := size.W <= -1, size.H <= -1
+	if naturalW {
 		if wc := l.GetWidthChars(); wc <= -1 {
 			if mwc := l.GetMaxWidthChars(); mwc <= -1 {
 				alloc := l.GetAllocation()
@@ -779,14 +884,17 @@ func (l *CLabel) GetSizeRequest() (width, height int) {
 			size.W = wc
 		}
 	}
-	if size.H <= -1 {
-		_, size.H = l.GetPlainTextInfoAtWidth(size.W)
+	if naturalH {
+		textW := size.W
+		if !naturalW {
+			textW -= insets.Width()
+		}
+		_, size.H = l.GetPlainTextInfoAtWidth(textW)
+		size.H += insets.Height()
+	}
+	if naturalW {
+		size.W += insets.Width()
 	}
-	// add padding
-	xPadding, yPadding := l.GetPadding()
-	size.W += xPadding * 2
-	size.H += yPadding * 2
-	// min size of 3 according to GTK
 	return size.W, size.H
 }
 
@@ -798,36 +906,34 @@ func (l *CLabel) Resize() cdk.EventFlag {
 		l.LogTrace("Label.Resize(): not visible, zero width or zero height")
 		return cdk.EVENT_PASS
 	}
-	var pos cdk.Point2I
-	size := cdk.NewRectangle(alloc.W, alloc.H)
+	// the text is laid out within the content area of the style box
+	pos, content := l.getStyleBox().fit(alloc).content(alloc)
+	size := cdk.NewRectangle(content.W, content.H)
 
 	req := l.CWidget.SizeRequest()
 	if req.W <= -1 {
-		size.W, size.H = l.GetPlainTextInfoAtWidth(alloc.W)
+		size.W, size.H = l.GetPlainTextInfoAtWidth(content.W)
 	} else {
 		size.W, size.H = l.GetPlainTextInfoAtWidth(req.W)
 	}
 	if req.H > -1 {
 		size.H = req.H
 	}
-	size.Clamp(0, 0, alloc.W, alloc.H)
+	size.Clamp(0, 0, content.W, content.H)
 
 	xAlign, yAlign := l.GetAlignment()
 
-	if size.W < alloc.W {
-		if size.W < alloc.W {
-			delta := alloc.W - size.W
-			pos.X += int(float64(delta) * xAlign)
-		}
+	if size.W < content.W {
+		delta := content.W - size.W
+		pos.X += int(float64(delta) * xAlign)
 	}
 
-	if size.H < alloc.H {
-		if size.H < alloc.H {
-			delta := alloc.H - size.H
-			pos.Y += int(float64(delta) * yAlign)
-		}
+	if size.H < content.H {
+		delta := content.H - size.H
+		pos.Y += int(float64(delta) * yAlign)
 	}
 
+	l.textOrigin = pos
 	l.canvas.SetOrigin(pos)
 	l.canvas.Resize(*size, l.getStyleRequest())
 	l.Invalidate()
@@ -844,11 +950,21 @@ func (l *CLabel) Draw(canvas cdk.Canvas) cdk.EventFlag {
 		return cdk.EVENT_PASS
 	}
 
+	if box := l.getStyleBox().fit(alloc); box.border != (StyleSides{}) {
+		box.draw(canvas, l.GetStyle().GetEngine(), cdk.MakePoint2I(0, 0), alloc, false, l.GetThemeRequest())
+	}
+
+	if l.GetUseUnderline() && l.tbShown != l.mnemonicsShown() {
+		// the mnemonic modifier was pressed or released
+		l.Invalidate()
+	}
+
 	if l.tbuffer != nil {
 		// if l.GetTheme().String() != l.GetThemeRequest().String() {
 		// 	l.Invalidate()
 		// }
 		l.tbuffer.Draw(l.canvas, l.GetSingleLineMode(), l.GetLineWrapMode(), l.GetEllipsize(), l.GetJustify(), cdk.ALIGN_TOP)
+		l.drawLinks()
 		if err := canvas.Composite(l.canvas); err != nil {
 			l.LogError("composite error: %v", err)
 		}
@@ -890,46 +1006,411 @@ func (l *CLabel) getStyleRequest() (style cdk.Style) {
 }
 
 func (l *CLabel) refreshBufferWithStyle(style cdk.Style) error {
-	if l.tbStyle.String() != style.String() {
+	if l.tbStyle.String() != style.String() || l.tbShown != l.mnemonicsShown() {
 		l.tbStyle = style
 		if l.GetUseMarkup() {
-			if m, err := cdk.NewMarkup(l.text, style); err != nil {
+			markup, _ := parseLabelLinks(l.text)
+			if m, err := cdk.NewMarkup(l.bufferText(markup, true), style); err != nil {
 				return err
 			} else {
 				l.tbuffer = m.TextBuffer(l.GetUseUnderline())
 			}
 		} else if l.tbuffer != nil {
-			l.tbuffer = cdk.NewTextBuffer(l.text, style, l.GetUseUnderline())
+			l.tbuffer = cdk.NewTextBuffer(l.bufferText(l.text, false), style, l.GetUseUnderline())
 		}
 	}
 	return nil
 }
 
 func (l *CLabel) refreshMnemonics() {
-	if w := l.GetWindow(); w != nil {
-		if widget := l.GetMnemonicWidget(); widget != nil {
-			w.RemoveWidgetMnemonics(widget)
-		} else {
-			if parent := l.GetParent(); parent != nil {
-				w.RemoveWidgetMnemonics(parent)
+	var keyval rune
+	var target Widget
+	w := l.GetWindow()
+	if w != nil && l.GetUseUnderline() {
+		if keyval = l.GetMnemonicKeyVal(); keyval > 0 {
+			target = l.getMnemonicTarget()
+		}
+	}
+	if l.mnemonicWindow != nil && l.mnemonicTarget != nil {
+		if w != nil && keyval == l.mnemonicKey && target != nil && target.ObjectID() == l.mnemonicTarget.ObjectID() && w.ObjectID() == l.mnemonicWindow.ObjectID() {
+			return
+		}
+		l.mnemonicWindow.RemoveMnemonic(l.mnemonicKey, l.mnemonicTarget)
+	}
+	l.mnemonicKey, l.mnemonicTarget, l.mnemonicWindow = 0, nil, nil
+	if target != nil {
+		w.AddMnemonic(keyval, target)
+		l.mnemonicKey, l.mnemonicTarget, l.mnemonicWindow = keyval, target, w
+	}
+}
+
+// returns the widget activated by the mnemonic of the label, which is the
+// mnemonic widget if set or the first ancestor of the label that can focus or
+// is a Frame, falling back to the parent of the label
+func (l *CLabel) getMnemonicTarget() Widget {
+	if widget := l.GetMnemonicWidget(); widget != nil {
+		return widget
+	}
+	parent := l.GetParent()
+	if parent == nil {
+		return nil
+	}
+	for ancestor := parent; ancestor != nil; ancestor = ancestor.GetParent() {
+		if _, ok := ancestor.(Window); ok {
+			break
+		}
+		if _, ok := ancestor.(Frame); ok || ancestor.CanFocus() {
+			return ancestor
+		}
+	}
+	return parent
+}
+
+// Processes key and mouse events for labels containing hyperlinks. Tab and
+// Shift+Tab move the focus between the links within the label before moving
+// on to the next widget, Enter activates the focused link and clicking on a
+// link activates it directly.
+//
+// Emits: SignalActivateCurrentLink, Argv=[Label instance]
+// Emits: SignalActivateLink, Argv=[Label instance, uri]
+func (l *CLabel) ProcessEvent(evt cdk.Event) cdk.EventFlag {
+	if len(l.links) == 0 {
+		return l.CMisc.ProcessEvent(evt)
+	}
+	switch e := evt.(type) {
+	case *cdk.EventKey:
+		if !l.IsFocus() {
+			break
+		}
+		switch e.Key() {
+		case cdk.KeyTab:
+			if l.focusLink(e.Modifiers().Has(cdk.ModShift)) {
+				return cdk.EVENT_STOP
+			}
+		case cdk.KeyBacktab:
+			if l.focusLink(!e.Modifiers().Has(cdk.ModShift)) {
+				return cdk.EVENT_STOP
+			}
+		case cdk.KeyEnter:
+			if l.focusedLink >= 0 {
+				if f := l.Emit(SignalActivateCurrentLink, l); f == cdk.EVENT_PASS {
+					l.activateLink(l.focusedLink)
+				}
+				return cdk.EVENT_STOP
+			}
+		}
+	case *cdk.EventMouse:
+		index := l.getLinkAt(cdk.NewPoint2I(e.Position()))
+		switch e.State() {
+		case cdk.MOUSE_MOVE, cdk.DRAG_MOVE:
+			l.hoverLink = index
+		case cdk.BUTTON_PRESS:
+			if index >= 0 {
+				if l.CanFocus() {
+					l.GrabFocus()
+				}
+				l.focusedLink = index
+				l.activateLink(index)
+				return cdk.EVENT_STOP
 			}
 		}
 	}
-	if l.GetUseUnderline() {
-		if w := l.GetWindow(); w != nil {
-			if keyval := l.GetMnemonicKeyVal(); keyval > 0 {
-				if widget := l.GetMnemonicWidget(); widget != nil {
-					w.AddMnemonic(keyval, widget)
-				} else {
-					if parent := l.GetParent(); parent != nil {
-						if pw, ok := parent.(Widget); ok && pw.IsSensitive() && pw.IsVisible() {
-							w.AddMnemonic(keyval, parent)
-						}
-					}
+	return l.CMisc.ProcessEvent(evt)
+}
+
+var (
+	rxLabelLink     = regexp.MustCompile(`(?msi)<a\s+([^>]*)>(.*?)</a>`)
+	rxLabelLinkAttr = regexp.MustCompile(`(?msi)([a-z]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	rxLabelLinkTags = regexp.MustCompile(`(?ms)<[^>]*>`)
+)
+
+// parseLabelLinks extracts all <a href> tags from the given markup, returning
+// the markup with each link replaced by underlined text and the list of links
+// found, in order of appearance
+func parseLabelLinks(markup string) (text string, links []*labelLink) {
+	text = rxLabelLink.ReplaceAllStringFunc(markup, func(match string) string {
+		sub := rxLabelLink.FindStringSubmatch(match)
+		link := &labelLink{}
+		for _, attr := range rxLabelLinkAttr.FindAllStringSubmatch(sub[1], -1) {
+			value := attr[2]
+			if value == "" {
+				value = attr[3]
+			}
+			switch strings.ToLower(attr[1]) {
+			case "href":
+				link.uri = html.UnescapeString(value)
+			case "title":
+				link.title = html.UnescapeString(value)
+			}
+		}
+		link.text = html.UnescapeString(rxLabelLinkTags.ReplaceAllString(sub[2], ""))
+		links = append(links, link)
+		return "<u>" + sub[2] + "</u>"
+	})
+	return
+}
+
+func (l *CLabel) setLinks(links []*labelLink) {
+	l.links = links
+	l.focusedLink = -1
+	l.hoverLink = -1
+	if len(links) > 0 {
+		if !l.CanFocus() {
+			l.SetFlags(CAN_FOCUS)
+			l.linkFocus = true
+		}
+	} else if l.linkFocus {
+		l.UnsetFlags(CAN_FOCUS)
+		l.linkFocus = false
+	}
+}
+
+// move the link focus forwards or backwards, returning FALSE if there are no
+// more links in the given direction
+func (l *CLabel) focusLink(backward bool) bool {
+	next := l.focusedLink + 1
+	if backward {
+		next = l.focusedLink - 1
+	}
+	if next < 0 || next >= len(l.links) {
+		l.focusedLink = -1
+		return false
+	}
+	l.focusedLink = next
+	return true
+}
+
+func (l *CLabel) activateLink(index int) {
+	if index < 0 || index >= len(l.links) {
+		return
+	}
+	link := l.links[index]
+	if f := l.Emit(SignalActivateLink, l, link.uri); f == cdk.EVENT_PASS {
+		if err := ShowUri(link.uri); err != nil {
+			l.LogErr(err)
+		}
+	}
+	if l.GetTrackVisitedLinks() {
+		l.visited[link.uri] = true
+	}
+}
+
+// returns the index of the link rendered at the given display point, or -1
+func (l *CLabel) getLinkAt(p *cdk.Point2I) int {
+	origin := l.GetOrigin()
+	x := p.X - origin.X - l.textOrigin.X
+	y := p.Y - origin.Y - l.textOrigin.Y
+	for idx, link := range l.links {
+		for _, cell := range link.cells {
+			if cell.X == x && cell.Y == y {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+func (l *CLabel) getLinkStyle(index int) (style cdk.Style) {
+	theme := l.GetThemeRequest()
+	style = theme.Content.Normal
+	if index == l.focusedLink && l.IsFocus() {
+		style = theme.Content.Focused.Reverse(true)
+	} else if index == l.hoverLink {
+		style = theme.Content.Focused
+	} else if l.GetTrackVisitedLinks() && l.visited[l.links[index].uri] {
+		style = style.Dim(true)
+	}
+	return style.Underline(true)
+}
+
+// locate the rendered text of each link on the label canvas, restyle those
+// cells according to the link state and queue the terminal hyperlinks
+func (l *CLabel) drawLinks() {
+	if len(l.links) == 0 {
+		return
+	}
+	grid := l.getCanvasRunes()
+	var flat []rune
+	var points []cdk.Point2I
+	for y, row := range grid {
+		if y > 0 {
+			flat = append(flat, '\n')
+			points = append(points, cdk.MakePoint2I(-1, -1))
+		}
+		for x, r := range row {
+			flat = append(flat, r)
+			points = append(points, cdk.MakePoint2I(x, y))
+		}
+	}
+	origin := l.GetOrigin()
+	from := 0
+	for idx, link := range l.links {
+		link.cells = nil
+		start, end := labelLinkMatch(flat, []rune(link.text), from)
+		if start < 0 {
+			continue
+		}
+		from = end
+		style := l.getLinkStyle(idx)
+		var span []rune
+		var spanStart cdk.Point2I
+		for i := start; i < end; i++ {
+			p := points[i]
+			if p.X < 0 {
+				continue
+			}
+			if isLabelLinkSpace(flat[i]) {
+				if i+1 >= end || points[i+1].Y != p.Y || isLabelLinkSpace(flat[i+1]) {
+					continue
+				}
+			}
+			link.cells = append(link.cells, p)
+			r := flat[i]
+			if r == 0 {
+				r = ' '
+			}
+			if err := l.canvas.SetRune(p.X, p.Y, r, style); err != nil {
+				l.LogErr(err)
+			}
+			if len(span) > 0 && spanStart.Y != p.Y {
+				queueHyperlink(origin.X+l.textOrigin.X+spanStart.X, origin.Y+l.textOrigin.Y+spanStart.Y, string(span), link.uri)
+				span = nil
+			}
+			if len(span) == 0 {
+				spanStart = p
+			}
+			span = append(span, r)
+		}
+		if len(span) > 0 {
+			queueHyperlink(origin.X+l.textOrigin.X+spanStart.X, origin.Y+l.textOrigin.Y+spanStart.Y, string(span), link.uri)
+		}
+	}
+}
+
+// returns the runes rendered on the label canvas, by row and column
+func (l *CLabel) getCanvasRunes() (grid [][]rune) {
+	size := l.canvas.GetSize()
+	grid = make([][]rune, size.H)
+	for y := range grid {
+		grid[y] = make([]rune, size.W)
+	}
+	l.canvas.ForEach(func(x, y int, cell cdk.TextCell) cdk.EventFlag {
+		if y >= 0 && y < len(grid) && x >= 0 && x < len(grid[y]) {
+			grid[y][x] = cell.Value()
+		}
+		return cdk.EVENT_PASS
+	})
+	return
+}
+
+// queue a terminal hyperlink to the given uri for each line of text rendered
+// on the label canvas, spanning the visible text of the line as aligned and
+// truncated when last drawn
+func (l *CLabel) queueTextHyperlinks(uri string) {
+	l.Lock()
+	defer l.Unlock()
+	origin := l.GetOrigin()
+	for y, row := range l.getCanvasRunes() {
+		first, last := -1, -1
+		for x, r := range row {
+			if !isLabelLinkSpace(r) {
+				if first < 0 {
+					first = x
 				}
+				last = x
+			}
+		}
+		if first < 0 {
+			continue
+		}
+		span := make([]rune, 0, last-first+1)
+		for _, r := range row[first : last+1] {
+			if r == 0 {
+				r = ' '
 			}
+			span = append(span, r)
+		}
+		queueHyperlink(origin.X+l.textOrigin.X+first, origin.Y+l.textOrigin.Y+y, string(span), uri)
+	}
+}
+
+func (l *CLabel) handleLinkGainedFocus(_ []interface{}, _ ...interface{}) cdk.EventFlag {
+	if len(l.links) > 0 && l.focusedLink < 0 {
+		l.focusedLink = 0
+	}
+	return cdk.EVENT_PASS
+}
+
+func (l *CLabel) handleLinkLostFocus(_ []interface{}, _ ...interface{}) cdk.EventFlag {
+	l.focusedLink = -1
+	return cdk.EVENT_PASS
+}
+
+// focus moving into the label selects the first link, or the last one when
+// moving backwards
+func (l *CLabel) handleLinkFocus(_ []interface{}, argv ...interface{}) cdk.EventFlag {
+	if len(l.links) == 0 || !l.CanFocus() || len(argv) < 2 {
+		return cdk.EVENT_PASS
+	}
+	l.focusedLink = 0
+	if direction, ok := argv[1].(DirectionType); ok {
+		switch direction {
+		case DIR_TAB_BACKWARD, DIR_UP, DIR_LEFT:
+			l.focusedLink = len(l.links) - 1
+		}
+	}
+	l.GrabFocus()
+	return cdk.EVENT_STOP
+}
+
+func (l *CLabel) handleLinkLeave(_ []interface{}, _ ...interface{}) cdk.EventFlag {
+	if l.hoverLink >= 0 {
+		l.hoverLink = -1
+		l.Invalidate()
+	}
+	return cdk.EVENT_PASS
+}
+
+func isLabelLinkSpace(r rune) bool {
+	return r == 0 || unicode.IsSpace(r)
+}
+
+// labelLinkMatch finds the needle within the haystack, starting at the given
+// offset, where any run of whitespace within the needle matches any run of
+// whitespace (including line breaks and padding) within the haystack
+func labelLinkMatch(haystack, needle []rune, from int) (start, end int) {
+	if len(needle) == 0 {
+		return -1, -1
+	}
+	for start = from; start < len(haystack); start++ {
+		if end = labelLinkMatchAt(haystack, needle, start); end > start {
+			return
+		}
+	}
+	return -1, -1
+}
+
+func labelLinkMatchAt(haystack, needle []rune, at int) int {
+	hi := at
+	for ni := 0; ni < len(needle); ni++ {
+		if unicode.IsSpace(needle[ni]) {
+			for ni+1 < len(needle) && unicode.IsSpace(needle[ni+1]) {
+				ni++
+			}
+			if hi >= len(haystack) || !isLabelLinkSpace(haystack[hi]) {
+				return -1
+			}
+			for hi < len(haystack) && isLabelLinkSpace(haystack[hi]) {
+				hi++
+			}
+			continue
+		}
+		if hi >= len(haystack) || haystack[hi] != needle[ni] {
+			return -1
 		}
+		hi++
 	}
+	return hi
 }
 
 // A list of style attributes to apply to the text of the label.
@@ -84,5 +84,135 @@ func TestLabel(t *testing.T) {
 			// 	return cdk.EVENT_PASS
 			// })
 		})
+		Convey("links: markup parsing", func() {
+			text, links := parseLabelLinks(`Go to <a href="https://example.com" title="Example">the <b>site</b></a> or <a href='mailto:a@b.c'>mail</a>`)
+			So(text, ShouldEqual, `Go to <u>the <b>site</b></u> or <u>mail</u>`)
+			So(links, ShouldHaveLength, 2)
+			So(links[0].uri, ShouldEqual, "https://example.com")
+			So(links[0].title, ShouldEqual, "Example")
+			So(links[0].text, ShouldEqual, "the site")
+			So(links[1].uri, ShouldEqual, "mailto:a@b.c")
+			So(links[1].text, ShouldEqual, "mail")
+			start, end := labelLinkMatch([]rune("see the   \nsite now"), []rune("the site"), 0)
+			So(start, ShouldEqual, 4)
+			So(end, ShouldEqual, 15)
+			start, _ = labelLinkMatch([]rune("nothing here"), []rune("site"), 0)
+			So(start, ShouldEqual, -1)
+		})
+		Convey("links: focus and current uri", func() {
+			l := NewLabel("")
+			So(l.CanFocus(), ShouldBeFalse)
+			So(l.SetMarkup(`<a href="one">1</a> <a href="two">2</a>`), ShouldBeNil)
+			So(l.CanFocus(), ShouldBeTrue)
+			So(l.GetCurrentUri(), ShouldEqual, "")
+			So(l.focusLink(false), ShouldBeTrue)
+			So(l.GetCurrentUri(), ShouldEqual, "one")
+			So(l.focusLink(false), ShouldBeTrue)
+			So(l.GetCurrentUri(), ShouldEqual, "two")
+			So(l.focusLink(false), ShouldBeFalse)
+			So(l.GetCurrentUri(), ShouldEqual, "")
+			activated := ""
+			l.Connect(SignalActivateLink, "test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				activated, _ = argv[1].(string)
+				return cdk.EVENT_STOP
+			})
+			l.activateLink(1)
+			So(activated, ShouldEqual, "two")
+			So(l.visited["two"], ShouldBeTrue)
+			l.SetText("plain")
+			So(l.CanFocus(), ShouldBeFalse)
+		})
+		Convey("links: focus direction and hover", func() {
+			l := NewLabel("")
+			So(l.SetMarkup(`<a href="one">1</a> <a href="two">2</a>`), ShouldBeNil)
+			So(l.Emit(SignalFocus, l, DIR_TAB_BACKWARD), ShouldEqual, cdk.EVENT_STOP)
+			So(l.GetCurrentUri(), ShouldEqual, "two")
+			So(l.Emit(SignalFocus, l, DIR_TAB_FORWARD), ShouldEqual, cdk.EVENT_STOP)
+			So(l.GetCurrentUri(), ShouldEqual, "one")
+			l.focusedLink = -1
+			l.hoverLink = 1
+			So(l.GetCurrentUri(), ShouldEqual, "two")
+			l.Emit(SignalLeave)
+			So(l.hoverLink, ShouldEqual, -1)
+			So(l.GetCurrentUri(), ShouldEqual, "")
+		})
+		Convey("mnemonics: parsing", func() {
+			keyval, stripped := scanLabelMnemonics("_File and _Edit", false)
+			So(keyval, ShouldEqual, 'f')
+			So(stripped, ShouldEqual, "File and Edit")
+			keyval, stripped = scanLabelMnemonics(`<span font_weight="bold">Sa_ve</span>`, true)
+			So(keyval, ShouldEqual, 'v')
+			So(stripped, ShouldEqual, `<span font_weight="bold">Save</span>`)
+			keyval, _ = scanLabelMnemonics("no_1 mnemonic", false)
+			So(keyval, ShouldEqual, 0)
+			keyval, stripped = scanLabelMnemonics("__init__ and _Go", false)
+			So(keyval, ShouldEqual, 'g')
+			So(stripped, ShouldEqual, "_init_ and Go")
+			l := NewLabelWithMnemonic("_Quit")
+			So(l.GetMnemonicKeyVal(), ShouldEqual, 'q')
+			So(l.GetLabel(), ShouldEqual, "_Quit")
+			So(l.GetText(), ShouldEqual, "Quit")
+		})
+		Convey("mnemonics: activation and visibility", func() {
+			window := NewWindowWithTitle("mnemonics")
+			vbox := window.GetVBox()
+			save := NewButtonWithMnemonic("_Save")
+			open := NewButtonWithMnemonic("_Open")
+			other := NewButtonWithMnemonic("_Other")
+			inner := NewButtonWithLabel("inner")
+			frame := NewFrameWithMnemonic("_Details")
+			frame.Add(inner)
+			for _, w := range []Widget{save, open, other, frame, inner} {
+				w.Show()
+			}
+			vbox.PackStart(save, false, false, 0)
+			vbox.PackStart(open, false, false, 0)
+			vbox.PackStart(other, false, false, 0)
+			vbox.PackStart(frame, true, true, 0)
+			window.Show()
+			window.SetAllocation(cdk.MakeRectangle(40, 20))
+			window.Resize()
+			saved := 0
+			save.Connect(SignalActivate, "mnemonic-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				saved++
+				return cdk.EVENT_STOP
+			})
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 's', cdk.ModAlt)), ShouldEqual, cdk.EVENT_STOP)
+			So(saved, ShouldEqual, 1)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'S', cdk.ModAlt|cdk.ModShift)), ShouldEqual, cdk.EVENT_STOP)
+			So(saved, ShouldEqual, 2)
+			// widgets sharing a mnemonic are focused in turn
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'o', cdk.ModAlt))
+			So(open.IsFocus(), ShouldBeTrue)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'o', cdk.ModAlt))
+			So(other.IsFocus(), ShouldBeTrue)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'o', cdk.ModAlt))
+			So(open.IsFocus(), ShouldBeTrue)
+			// frames move the focus to their content
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'd', cdk.ModAlt))
+			So(inner.IsFocus(), ShouldBeTrue)
+			// the mnemonic modifier
+			window.SetMnemonicModifier(cdk.ModCtrl)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 's', cdk.ModAlt)), ShouldEqual, cdk.EVENT_PASS)
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlS, 0, cdk.ModCtrl)), ShouldEqual, cdk.EVENT_STOP)
+			So(saved, ShouldEqual, 3)
+			// underlines are shown while the modifier is held
+			So(window.GetMnemonicsShown(), ShouldBeTrue)
+			window.SetMnemonicsVisible(false)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'x', cdk.ModNone))
+			So(window.GetMnemonicsShown(), ShouldBeFalse)
+			label, _ := save.GetChild().(*CLabel)
+			So(label, ShouldNotBeNil)
+			label.Invalidate()
+			So(label.tbShown, ShouldBeFalse)
+			So(label.GetText(), ShouldEqual, "Save")
+			// a matching mnemonic is activated without showing the underlines
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlS, 0, cdk.ModCtrl)), ShouldEqual, cdk.EVENT_STOP)
+			So(window.GetMnemonicsShown(), ShouldBeFalse)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyCtrlX, 0, cdk.ModCtrl))
+			So(window.GetMnemonicsShown(), ShouldBeTrue)
+			label.Invalidate()
+			So(label.tbShown, ShouldBeTrue)
+		})
 	})
 }
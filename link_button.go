@@ -0,0 +1,267 @@
+package ctk
+
+import (
+	"fmt"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for LinkButton objects
+const TypeLinkButton cdk.CTypeTag = "ctk-link-button"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeLinkButton, func() interface{} { return MakeLinkButton() })
+}
+
+// LinkButton Hierarchy:
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Button
+//	          +- LinkButton
+//
+// A LinkButton is a Button with a hyperlink, similar to the one used by web
+// browsers, which triggers an action when clicked. It is useful to show quick
+// links to resources. The URI bound to a LinkButton can be set specifically
+// using SetUri, and retrieved using GetUri. By default, LinkButton calls
+// ShowUri when the button is clicked. This behaviour can be overridden by
+// connecting to the activate-link signal and returning EVENT_STOP from the
+// signal handler.
+type LinkButton interface {
+	Button
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	GetUri() (value string)
+	SetUri(uri string)
+	GetVisited() (value bool)
+	SetVisited(visited bool)
+	GetThemeRequest() (theme cdk.Theme)
+	GetSizeRequest() (width, height int)
+	Resize() cdk.EventFlag
+	Draw(canvas cdk.Canvas) cdk.EventFlag
+}
+
+// The CLinkButton structure implements the LinkButton interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with LinkButton objects
+type CLinkButton struct {
+	CButton
+}
+
+// Default constructor for LinkButton objects
+func MakeLinkButton() *CLinkButton {
+	return NewLinkButton("")
+}
+
+// Creates a new LinkButton with the URI as its text.
+// Parameters:
+// 	uri	a valid URI
+// Returns:
+// 	a new link button widget.
+func NewLinkButton(uri string) (value *CLinkButton) {
+	return NewLinkButtonWithLabel(uri, uri)
+}
+
+// Creates a new LinkButton containing a label.
+// Parameters:
+// 	uri	a valid URI
+// 	label	the text of the button
+// Returns:
+// 	a new link button widget.
+func NewLinkButtonWithLabel(uri string, label string) (value *CLinkButton) {
+	b := new(CLinkButton)
+	b.Init()
+	l := NewLabel(label)
+	b.Add(l)
+	l.UnsetFlags(CAN_FOCUS)
+	l.UnsetFlags(CAN_DEFAULT)
+	l.UnsetFlags(RECEIVES_DEFAULT)
+	l.SetLineWrap(false)
+	l.SetLineWrapMode(cdk.WRAP_NONE)
+	l.SetSingleLineMode(true)
+	l.SetAlignment(0.0, 0.0)
+	l.Show()
+	b.SetUri(uri)
+	return b
+}
+
+// LinkButton object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the LinkButton instance
+func (b *CLinkButton) Init() (already bool) {
+	if b.InitTypeItem(TypeLinkButton, b) {
+		return true
+	}
+	b.CButton.Init()
+	b.SetTheme(cdk.DefaultColorTheme)
+	b.setStyleBoxDefaults(StyleSides{}, BorderStyleNone)
+	_ = b.InstallBuildableProperty(PropertyUri, cdk.StringProperty, true, "")
+	_ = b.InstallBuildableProperty(PropertyVisited, cdk.BoolProperty, true, false)
+	b.Connect(SignalClicked, fmt.Sprintf("%v.activate-link", b.ObjectName()), b.handleClicked)
+	return false
+}
+
+func (b *CLinkButton) Build(builder Builder, element *CBuilderElement) error {
+	if err := b.CButton.Build(builder, element); err != nil {
+		return err
+	}
+	if b.GetLabel() == "" {
+		b.SetLabel(b.GetUri())
+	}
+	return nil
+}
+
+// Retrieves the URI set using SetUri.
+// Returns:
+// 	a valid URI.
+func (b *CLinkButton) GetUri() (value string) {
+	var err error
+	if value, err = b.GetStringProperty(PropertyUri); err != nil {
+		b.LogErr(err)
+	}
+	return
+}
+
+// Sets uri as the URI where the LinkButton points. As a side-effect this
+// unsets the 'visited' state of the button.
+// Parameters:
+// 	uri	a valid URI
+func (b *CLinkButton) SetUri(uri string) {
+	if err := b.SetStringProperty(PropertyUri, uri); err != nil {
+		b.LogErr(err)
+	}
+	b.SetVisited(false)
+}
+
+// Retrieves the 'visited' state of the URI where the LinkButton points. The
+// button becomes visited when it is clicked. If the URI is changed on the
+// button, the 'visited' state is unset again. The state may also be changed
+// using SetVisited.
+// Returns:
+// 	TRUE if the link has been visited, FALSE otherwise
+func (b *CLinkButton) GetVisited() (value bool) {
+	var err error
+	if value, err = b.GetBoolProperty(PropertyVisited); err != nil {
+		b.LogErr(err)
+	}
+	return
+}
+
+// Sets the 'visited' state of the URI where the LinkButton points. See
+// GetVisited for more details.
+// Parameters:
+// 	visited	the new 'visited' state
+func (b *CLinkButton) SetVisited(visited bool) {
+	if err := b.SetBoolProperty(PropertyVisited, visited); err != nil {
+		b.LogErr(err)
+	}
+	b.Invalidate()
+}
+
+// Returns the current theme, adjusted for the pressed, focused and visited
+// states of the LinkButton. The content is always underlined.
+func (b *CLinkButton) GetThemeRequest() (theme cdk.Theme) {
+	theme = b.CButton.GetThemeRequest()
+	if b.GetVisited() && !b.IsFocused() && !b.GetPressed() {
+		theme.Content.Normal = theme.Content.Normal.Dim(true)
+	}
+	theme.Content.Normal = theme.Content.Normal.Underline(true)
+	theme.Content.Focused = theme.Content.Focused.Underline(true)
+	theme.Content.Active = theme.Content.Active.Underline(true)
+	return
+}
+
+// LinkButtons are drawn without a border, the size request is that of the
+// label child plus any margin, border or padding declared by a stylesheet
+func (b *CLinkButton) GetSizeRequest() (width, height int) {
+	size := cdk.NewRectangle(b.CWidget.GetSizeRequest())
+	if child := b.GetChild(); child != nil {
+		childSize := cdk.NewRectangle(child.GetSizeRequest())
+		insets := b.GetCssInsets()
+		if size.W <= -1 {
+			size.W = childSize.W + insets.Width()
+		}
+		if size.H <= -1 {
+			size.H = childSize.H + insets.Height()
+		}
+	}
+	return size.W, size.H
+}
+
+func (b *CLinkButton) Resize() cdk.EventFlag {
+	if child := b.GetChild(); child != nil {
+		alloc := b.GetAllocation()
+		origin := b.GetOrigin()
+		local, content := b.getBoxRequest().content(alloc)
+		theme := b.GetThemeRequest()
+		b.canvas.SetOrigin(local)
+		b.canvas.Resize(content, theme.Content.Normal)
+		child.SetTheme(theme)
+		child.SetOrigin(origin.X+local.X, origin.Y+local.Y)
+		child.SetAllocation(content)
+		child.Resize()
+	}
+	b.Invalidate()
+	return cdk.EVENT_PASS
+}
+
+func (b *CLinkButton) Draw(canvas cdk.Canvas) cdk.EventFlag {
+	b.Lock()
+	defer b.Unlock()
+	size := b.GetAllocation()
+	if !b.IsVisible() || size.W <= 0 || size.H <= 0 {
+		b.LogTrace("Draw(%v): not visible, zero width or zero height", canvas)
+		return cdk.EVENT_PASS
+	}
+	theme := b.GetThemeRequest()
+	canvas.Fill(theme)
+	box := b.getBoxRequest()
+	box.draw(canvas, b.GetStyle().GetEngine(), cdk.MakePoint2I(0, 0), size, false, theme)
+	if child := b.GetChild(); child != nil {
+		child.SetTheme(theme)
+		child.Draw(b.canvas)
+		if err := canvas.Composite(b.canvas); err != nil {
+			b.LogError("composite error: %v", err)
+		}
+		if uri := b.GetUri(); uri != "" {
+			if label, ok := child.(*CLabel); ok {
+				// link only the text as the label has aligned and truncated it
+				label.queueTextHyperlinks(uri)
+			} else {
+				origin := b.GetOrigin()
+				local, _ := box.content(size)
+				queueHyperlink(origin.X+local.X, origin.Y+local.Y, b.GetLabel(), uri)
+			}
+		}
+	}
+	if debug, _ := b.GetBoolProperty(cdk.PropertyDebug); debug {
+		canvas.DebugBox(cdk.ColorSilver, b.ObjectInfo())
+	}
+	return cdk.EVENT_STOP
+}
+
+func (b *CLinkButton) handleClicked(_ []interface{}, _ ...interface{}) cdk.EventFlag {
+	uri := b.GetUri()
+	if f := b.Emit(SignalActivateLink, b, uri); f == cdk.EVENT_PASS {
+		if err := ShowUri(uri); err != nil {
+			b.LogErr(err)
+		}
+	}
+	b.SetVisited(true)
+	return cdk.EVENT_PASS
+}
+
+// The URI bound to this button.
+// Flags: Read / Write
+// Default value: NULL
+const PropertyUri cdk.Property = "uri"
+
+// The 'visited' state of this button. A visited link is drawn in a different
+// color.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyVisited cdk.Property = "visited"
@@ -0,0 +1,324 @@
+package ctk
+
+import (
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for ListStore objects
+const TypeListStore cdk.CTypeTag = "ctk-list-store"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeListStore, func() interface{} { return MakeListStore() })
+}
+
+// ListStore Hierarchy:
+//	Object
+//	  +- ListStore
+//
+// The ListStore object is a list model for use with model-driven widgets
+// such as IconView. It implements the TreeModel interface. Each row holds a
+// fixed number of columns, with each column holding any value type. Unlike
+// GTK, column types are not enforced and widgets are expected to convert the
+// values they read as appropriate.
+type ListStore interface {
+	TreeModel
+
+	Init() (already bool)
+	SetValue(iter *TreeIter, column int, value interface{})
+	Set(iter *TreeIter, values ...interface{})
+	Remove(iter *TreeIter) (valid bool)
+	Insert(position int) (iter *TreeIter)
+	Prepend() (iter *TreeIter)
+	Append() (iter *TreeIter)
+	AppendValues(values ...interface{}) (iter *TreeIter)
+	Clear()
+	IterIsValid(iter *TreeIter) (valid bool)
+	Swap(a *TreeIter, b *TreeIter)
+}
+
+// The CListStore structure implements the ListStore interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with ListStore objects
+type CListStore struct {
+	CObject
+
+	nColumns int
+	rows     []*listStoreRow
+	stamp    int
+}
+
+type listStoreRow struct {
+	values []interface{}
+}
+
+// Default constructor for ListStore objects
+func MakeListStore() *CListStore {
+	return NewListStore(1)
+}
+
+// Creates a new list store with the given number of columns.
+// Parameters:
+// 	nColumns	number of columns in the list store
+// Returns:
+// 	a new ListStore
+func NewListStore(nColumns int) *CListStore {
+	l := new(CListStore)
+	l.Init()
+	if nColumns < 1 {
+		nColumns = 1
+	}
+	l.nColumns = nColumns
+	return l
+}
+
+// ListStore object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the ListStore instance
+func (l *CListStore) Init() (already bool) {
+	if l.InitTypeItem(TypeListStore, l) {
+		return true
+	}
+	l.CObject.Init()
+	l.nColumns = 1
+	l.rows = make([]*listStoreRow, 0)
+	l.stamp = l.ObjectID()
+	return false
+}
+
+// Returns a set of flags supported by this interface. ListStore iterators
+// persist for as long as the row exists and the model is a flat list.
+func (l *CListStore) GetFlags() (value TreeModelFlags) {
+	return TREE_MODEL_ITERS_PERSIST | TREE_MODEL_LIST_ONLY
+}
+
+// Returns the number of columns supported by the model.
+func (l *CListStore) GetNColumns() (value int) {
+	return l.nColumns
+}
+
+// Returns an iterator pointing to the given path, and TRUE if the path is
+// valid.
+func (l *CListStore) GetIter(path TreePath) (iter *TreeIter, ok bool) {
+	if len(path) != 1 || path[0] < 0 || path[0] >= len(l.rows) {
+		return nil, false
+	}
+	return l.makeIter(l.rows[path[0]]), true
+}
+
+// Returns an iterator pointing to the first row, and FALSE if the list is
+// empty.
+func (l *CListStore) GetIterFirst() (iter *TreeIter, ok bool) {
+	return l.GetIter(TreePath{0})
+}
+
+// Returns the TreePath referenced by the given iterator, or nil if the
+// iterator is not valid.
+func (l *CListStore) GetPath(iter *TreeIter) (value TreePath) {
+	if idx := l.indexOf(iter); idx >= 0 {
+		value = TreePath{idx}
+	}
+	return
+}
+
+// Returns the value stored at the given column of the row referenced by
+// iter, or nil if the iter or column are not valid.
+func (l *CListStore) GetValue(iter *TreeIter, column int) (value interface{}) {
+	if row := l.getRow(iter); row != nil && column >= 0 && column < len(row.values) {
+		value = row.values[column]
+	}
+	return
+}
+
+// Moves the iterator to the next row, returning FALSE and invalidating the
+// iterator if there is no next row.
+func (l *CListStore) IterNext(iter *TreeIter) (ok bool) {
+	if idx := l.indexOf(iter); idx >= 0 && idx+1 < len(l.rows) {
+		iter.UserData = l.rows[idx+1]
+		return true
+	}
+	if iter != nil {
+		iter.Stamp = 0
+		iter.UserData = nil
+	}
+	return false
+}
+
+// Returns the first row of the list when parent is nil, lists do not have
+// any other children.
+func (l *CListStore) IterChildren(parent *TreeIter) (iter *TreeIter, ok bool) {
+	if parent != nil {
+		return nil, false
+	}
+	return l.GetIterFirst()
+}
+
+// Lists have no child rows, always returns FALSE.
+func (l *CListStore) IterHasChild(iter *TreeIter) (value bool) {
+	return false
+}
+
+// Returns the number of rows in the list when iter is nil, or zero
+// otherwise.
+func (l *CListStore) IterNChildren(iter *TreeIter) (value int) {
+	if iter == nil {
+		return len(l.rows)
+	}
+	return 0
+}
+
+// Returns the nth row of the list when parent is nil.
+func (l *CListStore) IterNthChild(parent *TreeIter, n int) (iter *TreeIter, ok bool) {
+	if parent != nil {
+		return nil, false
+	}
+	return l.GetIter(TreePath{n})
+}
+
+// Lists have no parent rows, always returns FALSE.
+func (l *CListStore) IterParent(child *TreeIter) (iter *TreeIter, ok bool) {
+	return nil, false
+}
+
+// Calls fn on each row in the list, in order, until fn returns TRUE.
+func (l *CListStore) ForEach(fn TreeModelForeachFunc) {
+	rows := make([]*listStoreRow, len(l.rows))
+	copy(rows, l.rows)
+	for idx, row := range rows {
+		if fn(l, TreePath{idx}, l.makeIter(row)) {
+			return
+		}
+	}
+}
+
+// Sets the data in the cell specified by iter and column.
+// Emits: SignalRowChanged, Argv=[ListStore instance, path, iter]
+func (l *CListStore) SetValue(iter *TreeIter, column int, value interface{}) {
+	if row := l.getRow(iter); row != nil {
+		if column < 0 || column >= l.nColumns {
+			l.LogError("invalid column: %v", column)
+			return
+		}
+		row.values[column] = value
+		l.Emit(SignalRowChanged, l, l.GetPath(iter), iter)
+	}
+}
+
+// Sets the values of the row referenced by iter, in column order.
+// Emits: SignalRowChanged, Argv=[ListStore instance, path, iter]
+func (l *CListStore) Set(iter *TreeIter, values ...interface{}) {
+	if row := l.getRow(iter); row != nil {
+		for column, value := range values {
+			if column < l.nColumns {
+				row.values[column] = value
+			}
+		}
+		l.Emit(SignalRowChanged, l, l.GetPath(iter), iter)
+	}
+}
+
+// Removes the given row from the list store. After being removed, iter is
+// set to be the next valid row, or invalidated if it pointed to the last row
+// in the list store.
+// Emits: SignalRowDeleted, Argv=[ListStore instance, path]
+func (l *CListStore) Remove(iter *TreeIter) (valid bool) {
+	idx := l.indexOf(iter)
+	if idx < 0 {
+		return false
+	}
+	l.rows = append(l.rows[:idx], l.rows[idx+1:]...)
+	l.Emit(SignalRowDeleted, l, TreePath{idx})
+	if idx < len(l.rows) {
+		iter.UserData = l.rows[idx]
+		return true
+	}
+	iter.Stamp = 0
+	iter.UserData = nil
+	return false
+}
+
+// Creates a new row at position. If position is larger than the number of
+// rows on the list, then the new row will be appended to the list.
+// Emits: SignalRowInserted, Argv=[ListStore instance, path, iter]
+func (l *CListStore) Insert(position int) (iter *TreeIter) {
+	if position < 0 || position > len(l.rows) {
+		position = len(l.rows)
+	}
+	row := &listStoreRow{values: make([]interface{}, l.nColumns)}
+	l.rows = append(l.rows, nil)
+	copy(l.rows[position+1:], l.rows[position:])
+	l.rows[position] = row
+	iter = l.makeIter(row)
+	l.Emit(SignalRowInserted, l, TreePath{position}, iter)
+	return
+}
+
+// Prepends a new row to the list store.
+func (l *CListStore) Prepend() (iter *TreeIter) {
+	return l.Insert(0)
+}
+
+// Appends a new row to the list store.
+func (l *CListStore) Append() (iter *TreeIter) {
+	return l.Insert(-1)
+}
+
+// Convenience method to append a new row with the given values.
+func (l *CListStore) AppendValues(values ...interface{}) (iter *TreeIter) {
+	iter = l.Append()
+	l.Set(iter, values...)
+	return
+}
+
+// Removes all rows from the list store.
+func (l *CListStore) Clear() {
+	for len(l.rows) > 0 {
+		iter := l.makeIter(l.rows[len(l.rows)-1])
+		l.Remove(iter)
+	}
+}
+
+// Checks if the given iter is a valid iter for this ListStore.
+func (l *CListStore) IterIsValid(iter *TreeIter) (valid bool) {
+	return l.indexOf(iter) >= 0
+}
+
+// Swaps a and b in the store.
+// Emits: SignalRowsReordered, Argv=[ListStore instance, path, iter, newOrder]
+func (l *CListStore) Swap(a *TreeIter, b *TreeIter) {
+	ai, bi := l.indexOf(a), l.indexOf(b)
+	if ai < 0 || bi < 0 || ai == bi {
+		return
+	}
+	l.rows[ai], l.rows[bi] = l.rows[bi], l.rows[ai]
+	order := make([]int, len(l.rows))
+	for idx := range order {
+		order[idx] = idx
+	}
+	order[ai], order[bi] = bi, ai
+	l.Emit(SignalRowsReordered, l, TreePath{}, nil, order)
+}
+
+func (l *CListStore) makeIter(row *listStoreRow) *TreeIter {
+	return &TreeIter{Stamp: l.stamp, UserData: row}
+}
+
+func (l *CListStore) getRow(iter *TreeIter) *listStoreRow {
+	if iter == nil || iter.Stamp != l.stamp {
+		return nil
+	}
+	row, _ := iter.UserData.(*listStoreRow)
+	return row
+}
+
+func (l *CListStore) indexOf(iter *TreeIter) int {
+	if row := l.getRow(iter); row != nil {
+		for idx, r := range l.rows {
+			if r == row {
+				return idx
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,47 @@
+package ctk
+
+import (
+	"sync"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK runs the event handling and drawing of the active Window on the main
+// loop, signal handlers and widget methods are not safe to call from other
+// goroutines. Background work (reading from a child process, watching files
+// for changes and the like) hands its results over to the main loop with
+// queueMainCall instead.
+
+var (
+	mainCalls     []func()
+	mainCallsLock = &sync.Mutex{}
+)
+
+// queue the given function to be called from the main loop, before the active
+// Window next processes an event or draws, and request a draw cycle to have
+// the main loop wake up. Without a display manager, there is no main loop and
+// the function is called immediately.
+func queueMainCall(fn func()) {
+	dm := cdk.GetDisplayManager()
+	if dm == nil {
+		fn()
+		return
+	}
+	mainCallsLock.Lock()
+	mainCalls = append(mainCalls, fn)
+	mainCallsLock.Unlock()
+	dm.RequestDraw()
+	dm.RequestShow()
+}
+
+// call all functions queued with queueMainCall, in the order they were queued.
+// This must only be called from the main loop, without holding any locks.
+func runMainCalls() {
+	mainCallsLock.Lock()
+	calls := mainCalls
+	mainCalls = nil
+	mainCallsLock.Unlock()
+	for _, fn := range calls {
+		fn()
+	}
+}
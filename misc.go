@@ -101,6 +101,14 @@ func (m *CMisc) GetPadding() (xPad int, yPad int) {
 	return
 }
 
+// the x-pad and y-pad properties are added to any css padding
+func (m *CMisc) getStyleBox() (box styleBox) {
+	box = m.CWidget.getStyleBox()
+	xPad, yPad := m.GetPadding()
+	box.padding = box.padding.Add(StyleSides{Top: yPad, Right: xPad, Bottom: yPad, Left: xPad})
+	return
+}
+
 // The horizontal alignment, from 0 (left) to 1 (right). Reversed for RTL
 // layouts.
 // Flags: Read / Write
@@ -16,6 +16,7 @@ package ctk
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kckrinke/go-cdk"
 )
@@ -70,6 +71,19 @@ type CObject struct {
 	allocation    *cdk.Rectangle
 	textDirection TextDirection
 	css           map[cdk.Property]*cdk.CProperty
+	self          interface{}
+}
+
+// Registers the Object instance with the CDK type of the given tag, see
+// cdk.CObject.InitTypeItem. The first instance given is that of the outermost
+// type embedding this Object, as each Init method registers its own type
+// before initializing the types it embeds, and is kept as the instance that
+// methods of embedded types dispatch through.
+func (o *CObject) InitTypeItem(tag cdk.TypeTag, thing interface{}) (already bool) {
+	if o.self == nil {
+		o.self = thing
+	}
+	return o.CObject.InitTypeItem(tag, thing)
 }
 
 // CTK object initialization. This must be called at least once to setup the
@@ -109,6 +123,10 @@ func (o *CObject) Init() (already bool) {
 	_ = o.InstallCssProperty(PropertyBorderBottomLeftContent, cdk.StringProperty, true, "+")
 	_ = o.InstallCssProperty(PropertyBorderBottomContent, cdk.StringProperty, true, "-")
 	_ = o.InstallCssProperty(PropertyBorderBottomRightContent, cdk.StringProperty, true, "+")
+	_ = o.InstallCssProperty(PropertyMargin, cdk.StringProperty, true, "")
+	_ = o.InstallCssProperty(PropertyPadding, cdk.StringProperty, true, "")
+	_ = o.InstallCssProperty(PropertyBorderWidth, cdk.StringProperty, true, "")
+	_ = o.InstallCssProperty(PropertyBorderStyle, cdk.StringProperty, true, "")
 	return false
 }
 
@@ -136,6 +154,11 @@ func (o *CObject) Build(builder Builder, element *CBuilderElement) error {
 	} else {
 		element.ApplyProperties()
 	}
+	if widget, ok := element.Instance.(Widget); ok {
+		for _, class := range element.Classes {
+			widget.AddClass(class)
+		}
+	}
 	element.ApplySignals()
 	return nil
 }
@@ -264,6 +287,11 @@ func (o *CObject) CssSelector() (selector string) {
 	if name != "" {
 		selector += "#" + name
 	}
+	if classes, err := o.GetCssString(PropertyClass); err == nil {
+		for _, class := range strings.Fields(classes) {
+			selector += "." + class
+		}
+	}
 	return
 }
 
@@ -417,3 +445,5 @@ const PropertyBorderBottomRightContent cdk.Property = "border-bottom-right-conte
 const PropertyBorderLeftContent cdk.Property = "border-left-content"
 const PropertyBorderRightContent cdk.Property = "border-right-content"
 const PropertyBorderBottomContent cdk.Property = "border-bottom-content"
+const PropertyPadding cdk.Property = "padding"
+const PropertyBorderStyle cdk.Property = "border-style"
@@ -0,0 +1,51 @@
+// +build linux
+
+package ctk
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// open a new pseudo-terminal pair, returning the master side and the path to
+// the slave device
+func openPty() (master *os.File, slavePath string, err error) {
+	if master, err = os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0); err != nil {
+		return nil, "", err
+	}
+	var unlock int32
+	if err = ptyIoctl(master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); err != nil {
+		_ = master.Close()
+		return nil, "", fmt.Errorf("unlockpt: %v", err)
+	}
+	var number uint32
+	if err = ptyIoctl(master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&number))); err != nil {
+		_ = master.Close()
+		return nil, "", fmt.Errorf("ptsname: %v", err)
+	}
+	return master, fmt.Sprintf("/dev/pts/%d", number), nil
+}
+
+// set the window size of the pseudo-terminal, the kernel delivers SIGWINCH
+// to the foreground process group of the terminal
+func setPtySize(f *os.File, rows, cols int) error {
+	ws := struct {
+		Row, Col, X, Y uint16
+	}{Row: uint16(rows), Col: uint16(cols)}
+	return ptyIoctl(f.Fd(), syscall.TIOCSWINSZ, uintptr(unsafe.Pointer(&ws)))
+}
+
+// the process attributes needed for the child to acquire the slave side of
+// the pseudo-terminal as its controlling terminal
+func ptySysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true, Setctty: true}
+}
+
+func ptyIoctl(fd, request, arg uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, arg); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+// +build !linux
+
+package ctk
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+)
+
+func openPty() (master *os.File, slavePath string, err error) {
+	return nil, "", fmt.Errorf("pseudo-terminals are not supported on %v", runtime.GOOS)
+}
+
+func setPtySize(f *os.File, rows, cols int) error {
+	return fmt.Errorf("pseudo-terminals are not supported on %v", runtime.GOOS)
+}
+
+func ptySysProcAttr() *syscall.SysProcAttr {
+	return nil
+}
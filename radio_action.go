@@ -0,0 +1,265 @@
+package ctk
+
+import (
+	"fmt"
+
+	"github.com/kckrinke/go-cdk"
+	"github.com/kckrinke/go-cdk/utils"
+)
+
+// CDK type-tag for RadioAction objects
+const TypeRadioAction cdk.CTypeTag = "ctk-radio-action"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeRadioAction, func() interface{} { return MakeRadioAction() })
+}
+
+// RadioAction Hierarchy:
+//	Object
+//	  +- Action
+//	    +- ToggleAction
+//	      +- RadioAction
+//
+// A RadioAction is similar to RadioMenuItem. A number of radio actions can
+// be linked together so that only one may be active at any one time.
+// Activating a radio action makes it the active action of its group,
+// activating the active action has no effect on the group.
+type RadioAction interface {
+	ToggleAction
+
+	Init() (already bool)
+	GetGroup() (value []RadioAction)
+	SetGroup(group []RadioAction)
+	JoinGroup(groupSource RadioAction)
+	GetValue() (value int)
+	SetValue(value int)
+	GetCurrentValue() (value int)
+	SetCurrentValue(currentValue int)
+}
+
+// The CRadioAction structure implements the RadioAction interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with RadioAction objects
+type CRadioAction struct {
+	CToggleAction
+
+	group *radioActionGroup
+}
+
+// the members of a group of radio actions, shared by all members
+type radioActionGroup struct {
+	members []*CRadioAction
+}
+
+// Default constructor for RadioAction objects
+func MakeRadioAction() *CRadioAction {
+	return NewRadioAction("", "", "", "", 0)
+}
+
+// Creates a new RadioAction object. To add the action to a ActionGroup
+// and set the accelerator for the action, call
+// ActionGroup.AddActionWithAccel.
+// Parameters:
+// 	name	A unique name for the action
+// 	label	the label displayed in menu items and on buttons, or empty
+// 	tooltip	a tooltip for this action, or empty
+// 	stockId	the stock icon to display in widgets representing this
+// action, or empty
+// 	value	The value which GetCurrentValue should return if this action
+// is selected.
+func NewRadioAction(name string, label string, tooltip string, stockId StockID, value int) (v *CRadioAction) {
+	r := new(CRadioAction)
+	r.Init()
+	r.initAction(name, label, tooltip, stockId)
+	r.SetValue(value)
+	return r
+}
+
+// RadioAction object initialization. This must be called at least once to
+// setup the necessary defaults and allocate any memory structures. Calling this
+// more than once is safe though unnecessary. Only the first call will result in
+// any effect upon the RadioAction instance
+func (r *CRadioAction) Init() (already bool) {
+	if r.InitTypeItem(TypeRadioAction, r) {
+		return true
+	}
+	r.CToggleAction.Init()
+	_ = r.InstallBuildableProperty(PropertyValue, cdk.IntProperty, true, 0)
+	r.group = &radioActionGroup{members: []*CRadioAction{r}}
+	// activating a radio action selects it instead of toggling it
+	_ = r.Disconnect(SignalActivate, r.toggleHandle)
+	r.toggleHandle = fmt.Sprintf("%v.radio-action", r.ObjectName())
+	r.Connect(SignalActivate, r.toggleHandle, func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+		r.SetActive(true)
+		return cdk.EVENT_PASS
+	})
+	return false
+}
+
+// Builds the RadioAction from a Builder element. The "group" property names
+// the id of a radio action built earlier whose group the action joins.
+func (r *CRadioAction) Build(builder Builder, element *CBuilderElement) error {
+	e := *element
+	e.Properties = make(map[string]string)
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyGroup, PropertyActive:
+		default:
+			e.Properties[k] = v
+		}
+	}
+	if err := r.CAction.Build(builder, &e); err != nil {
+		return err
+	}
+	if v, ok := element.Properties[PropertyGroup.String()]; ok {
+		if source, ok := builder.GetWidget(v).(RadioAction); ok {
+			r.JoinGroup(source)
+		} else {
+			r.LogError("failed to set group, unknown radio action id/name: %v", v)
+		}
+	}
+	if v, ok := element.Properties[PropertyActive.String()]; ok {
+		r.SetActive(utils.IsTrue(v))
+	}
+	return nil
+}
+
+// Returns the list representing the radio group for this object, including
+// the action itself.
+func (r *CRadioAction) GetGroup() (value []RadioAction) {
+	for _, member := range r.group.members {
+		value = append(value, member)
+	}
+	return
+}
+
+// Sets the radio group for the radio action object. The action leaves its
+// current group and joins the group of the first member given, or forms a
+// group of its own if the group is empty.
+// Parameters:
+// 	group	a list representing a radio group
+func (r *CRadioAction) SetGroup(group []RadioAction) {
+	if len(group) > 0 {
+		r.JoinGroup(group[0])
+	} else {
+		r.JoinGroup(nil)
+	}
+}
+
+// Joins a radio action object to the group of another radio action object.
+// Passing nil makes the action leave its group. An active action joining a
+// group with an active member is deactivated.
+// Parameters:
+// 	groupSource	a radio action object whose group we are joining, or
+// nil to remove the radio action from its group
+func (r *CRadioAction) JoinGroup(groupSource RadioAction) {
+	for idx, member := range r.group.members {
+		if member == r {
+			r.group.members = append(r.group.members[:idx], r.group.members[idx+1:]...)
+			break
+		}
+	}
+	r.group = &radioActionGroup{}
+	if source, ok := groupSource.(*CRadioAction); ok && source != nil {
+		r.group = source.group
+	} else if groupSource != nil {
+		r.LogError("radio group source is not a *CRadioAction: %T", groupSource)
+	}
+	if r.GetActive() {
+		for _, member := range r.group.members {
+			if member.GetActive() {
+				r.CToggleAction.SetActive(false)
+				break
+			}
+		}
+	}
+	r.group.members = append(r.group.members, r)
+}
+
+// Sets the checked state on the radio action. Activating the action
+// deactivates the other members of its group, emitting the "changed" signal
+// on each member of the group.
+// Parameters:
+// 	isActive	whether the action should be checked or not
+func (r *CRadioAction) SetActive(isActive bool) {
+	if r.GetActive() == isActive {
+		return
+	}
+	if isActive {
+		for _, member := range r.group.members {
+			if member != r {
+				member.CToggleAction.SetActive(false)
+			}
+		}
+	}
+	r.CToggleAction.SetActive(isActive)
+	if isActive {
+		for _, member := range r.GetGroup() {
+			member.Emit(SignalChanged, member, r)
+		}
+	}
+}
+
+// Returns the value which GetCurrentValue returns while this action is the
+// active action of its group.
+func (r *CRadioAction) GetValue() (value int) {
+	var err error
+	if value, err = r.GetIntProperty(PropertyValue); err != nil {
+		r.LogErr(err)
+	}
+	return
+}
+
+// Sets the value which GetCurrentValue returns while this action is the
+// active action of its group.
+// Parameters:
+// 	value	the value of the action
+func (r *CRadioAction) SetValue(value int) {
+	if err := r.SetIntProperty(PropertyValue, value); err != nil {
+		r.LogErr(err)
+	}
+}
+
+// Obtains the value property of the currently active member of the group to
+// which action belongs, or zero if no member is active.
+func (r *CRadioAction) GetCurrentValue() (value int) {
+	for _, member := range r.group.members {
+		if member.GetActive() {
+			return member.GetValue()
+		}
+	}
+	return 0
+}
+
+// Sets the currently active group member to the member with value property
+// current_value .
+// Parameters:
+// 	currentValue	the new value
+func (r *CRadioAction) SetCurrentValue(currentValue int) {
+	for _, member := range r.group.members {
+		if member.GetValue() == currentValue {
+			member.SetActive(true)
+			return
+		}
+	}
+	r.LogError("no radio action with value %d in the group", currentValue)
+}
+
+// Sets a new group for a radio action.
+// Flags: Write
+const PropertyGroup cdk.Property = "group"
+
+// The value is an arbitrary integer which can be used as a convenient way to
+// determine which action in the group is currently active, see
+// GetCurrentValue.
+// Flags: Read / Write
+// Default value: 0
+// const PropertyValue cdk.Property = "value"
+
+// The "changed" signal is emitted on every member of a radio group when the
+// active member is changed. The signal gets emitted after the "activate"
+// signals for the previous and current active members.
+// Listener function arguments:
+// 	current RadioAction	the member of action's group which has just been activated
+// const SignalChanged cdk.Signal = "changed"
@@ -1,7 +1,195 @@
 package ctk
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// RcStyle holds the style modifications of a Widget, see Widget.ModifyStyle.
+// Colors are set per StateType and each may be either set or unset, unset
+// colors leave the style of the Widget untouched. Colors of the StateNormal
+// state always apply, colors of the other states only apply while the Widget
+// is in that state. The modifications are applied on top of all StyleSheets
+// and the inline style of the Widget, making them the highest priority layer
+// of the cascade.
+//
+// The foreground (RC_FG) and text (RC_TEXT) colors are both applied as the
+// css "color" property, the background (RC_BG) and base (RC_BASE) colors as
+// the "background-color" property. CTK Widgets draw text and background with
+// the same properties, so the text and base colors take precedence over the
+// foreground and background colors. Any other css declarations, as given with
+// the "style" property of a glade object, are applied before the colors.
 type RcStyle interface {
+	Copy() (value RcStyle)
+	IsEmpty() (empty bool)
+	GetColorFlags(state StateType) (flags RcFlags)
+	GetColor(component RcFlags, state StateType) (color cdk.Color, set bool)
+	SetColor(component RcFlags, state StateType, color cdk.Color)
+	UnsetColor(component RcFlags, state StateType)
+	GetCursorColors() (primary, secondary cdk.Color, set bool)
+	SetCursorColors(primary, secondary cdk.Color)
+	UnsetCursorColors()
+	GetDeclarations() (declarations string)
+	SetDeclarations(declarations string) (err error)
+	GetProperties(state StateType) (properties []*StyleSheetProperty)
 }
 
+// the RcFlags of each color component, in the order applied
+var rcStyleComponents = []RcFlags{RC_FG, RC_BG, RC_TEXT, RC_BASE}
+
+// the StateTypes colors are set for, in the order applied
+var rcStyleStates = []StateType{StateNormal, StateActive, StatePrelight, StateSelected, StateInsensitive}
+
+// The CRcStyle structure implements the RcStyle interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with RcStyle values
 type CRcStyle struct {
+	colors       map[StateType]map[RcFlags]cdk.Color
+	cursor       [2]cdk.Color
+	cursorSet    bool
+	declarations string
+	properties   []*StyleSheetProperty
+}
+
+// Default constructor for RcStyle values
+func MakeRcStyle() *CRcStyle {
+	return NewRcStyle()
+}
+
+// Returns a new RcStyle with no modifications set.
+func NewRcStyle() (value *CRcStyle) {
+	return &CRcStyle{colors: make(map[StateType]map[RcFlags]cdk.Color)}
+}
+
+// Returns a copy of the RcStyle, changes to the copy do not affect the
+// original.
+func (r *CRcStyle) Copy() (value RcStyle) {
+	c := NewRcStyle()
+	for state, colors := range r.colors {
+		c.colors[state] = make(map[RcFlags]cdk.Color)
+		for component, color := range colors {
+			c.colors[state][component] = color
+		}
+	}
+	c.cursor, c.cursorSet = r.cursor, r.cursorSet
+	c.declarations = r.declarations
+	c.properties = append([]*StyleSheetProperty{}, r.properties...)
+	return c
+}
+
+// Returns TRUE if no modifications are set.
+func (r *CRcStyle) IsEmpty() (empty bool) {
+	return len(r.colors) == 0 && !r.cursorSet && len(r.properties) == 0
+}
+
+// Returns the color components set for the given state.
+func (r *CRcStyle) GetColorFlags(state StateType) (flags RcFlags) {
+	for component := range r.colors[state] {
+		flags |= component
+	}
+	return
+}
+
+// Returns the color of the component given, one of RC_FG, RC_BG, RC_TEXT or
+// RC_BASE, for the given state and TRUE if the color is set.
+func (r *CRcStyle) GetColor(component RcFlags, state StateType) (color cdk.Color, set bool) {
+	color, set = r.colors[state][component]
+	return
+}
+
+// Sets the color of the component given, one of RC_FG, RC_BG, RC_TEXT or
+// RC_BASE, for the given state.
+func (r *CRcStyle) SetColor(component RcFlags, state StateType, color cdk.Color) {
+	if _, ok := r.colors[state]; !ok {
+		r.colors[state] = make(map[RcFlags]cdk.Color)
+	}
+	r.colors[state][component] = color
+}
+
+// Unsets the color of the component given for the given state, undoing the
+// effect of SetColor.
+func (r *CRcStyle) UnsetColor(component RcFlags, state StateType) {
+	if colors, ok := r.colors[state]; ok {
+		delete(colors, component)
+		if len(colors) == 0 {
+			delete(r.colors, state)
+		}
+	}
+}
+
+// Returns the primary and secondary cursor colors and TRUE if they are set.
+func (r *CRcStyle) GetCursorColors() (primary, secondary cdk.Color, set bool) {
+	return r.cursor[0], r.cursor[1], r.cursorSet
+}
+
+// Sets the primary and secondary cursor colors, applied as the --cursor-color
+// and --secondary-cursor-color custom properties.
+func (r *CRcStyle) SetCursorColors(primary, secondary cdk.Color) {
+	r.cursor = [2]cdk.Color{primary, secondary}
+	r.cursorSet = true
+}
+
+// Unsets the cursor colors, undoing the effect of SetCursorColors.
+func (r *CRcStyle) UnsetCursorColors() {
+	r.cursor = [2]cdk.Color{}
+	r.cursorSet = false
+}
+
+// Returns the css declarations set with SetDeclarations.
+func (r *CRcStyle) GetDeclarations() (declarations string) {
+	return r.declarations
+}
+
+// Sets a list of css declarations such as "color: red; bold: true", applied
+// in all states before the colors set. Passing an empty string clears the
+// declarations. The declarations are kept unchanged if they fail to parse.
+func (r *CRcStyle) SetDeclarations(declarations string) (err error) {
+	var properties []*StyleSheetProperty
+	if properties, err = parseInlineStyle(declarations); err != nil {
+		return
+	}
+	r.declarations = declarations
+	r.properties = properties
+	return
+}
+
+// Returns the css properties of the modifications which apply to a Widget in
+// the given state, in the order they are to be applied.
+func (r *CRcStyle) GetProperties(state StateType) (properties []*StyleSheetProperty) {
+	properties = append(properties, r.properties...)
+	var declarations []string
+	for _, s := range rcStyleStates {
+		if s != StateNormal && state&s == 0 {
+			continue
+		}
+		for _, component := range rcStyleComponents {
+			if color, ok := r.colors[s][component]; ok {
+				declarations = append(declarations, fmt.Sprintf("%v: %v", rcStyleProperty(component), styleColorValue(color)))
+			}
+		}
+	}
+	if r.cursorSet {
+		declarations = append(declarations,
+			fmt.Sprintf("--cursor-color: %v", styleColorValue(r.cursor[0])),
+			fmt.Sprintf("--secondary-cursor-color: %v", styleColorValue(r.cursor[1])),
+		)
+	}
+	if len(declarations) > 0 {
+		// the values are all valid colors
+		colors, _ := parseInlineStyle(strings.Join(declarations, "; "))
+		properties = append(properties, colors...)
+	}
+	return
+}
+
+// returns the name of the css property a color component is applied as
+func rcStyleProperty(component RcFlags) cdk.Property {
+	switch component {
+	case RC_BG, RC_BASE:
+		return PropertyBackgroundColor
+	}
+	return PropertyColor
 }
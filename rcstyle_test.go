@@ -0,0 +1,81 @@
+package ctk
+
+import (
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRcStyle(t *testing.T) {
+	Convey("Testing RcStyles", t, func() {
+		Convey("setting and unsetting colors", func() {
+			style := NewRcStyle()
+			So(style.IsEmpty(), ShouldBeTrue)
+			style.SetColor(RC_FG, StateNormal, cdk.ColorRed)
+			style.SetColor(RC_BG, StatePrelight, cdk.ColorDefault)
+			So(style.IsEmpty(), ShouldBeFalse)
+			So(style.GetColorFlags(StateNormal), ShouldEqual, RC_FG)
+			color, set := style.GetColor(RC_FG, StateNormal)
+			So(set, ShouldBeTrue)
+			So(color, ShouldEqual, cdk.ColorRed)
+			_, set = style.GetColor(RC_BG, StateNormal)
+			So(set, ShouldBeFalse)
+			copied := style.Copy()
+			style.UnsetColor(RC_FG, StateNormal)
+			style.UnsetColor(RC_BG, StatePrelight)
+			So(style.IsEmpty(), ShouldBeTrue)
+			So(copied.GetColorFlags(StateNormal), ShouldEqual, RC_FG)
+			So(copied.GetProperties(StateNormal), ShouldHaveLength, 1)
+			So(copied.GetProperties(StatePrelight|StateActive), ShouldHaveLength, 2)
+			So(style.SetDeclarations("bold: true; color: "), ShouldNotBeNil)
+			So(style.SetDeclarations("bold: true"), ShouldBeNil)
+			So(style.GetDeclarations(), ShouldEqual, "bold: true")
+			So(style.GetProperties(StateNormal)[0].Key, ShouldEqual, "bold")
+		})
+		Convey("widget modifications", func() {
+			window := NewWindowWithTitle("modify")
+			button := NewButtonWithLabel("button")
+			window.Add(button)
+			sheet := NewStyleSheet()
+			So(sheet.ParseString("button { color: green; background-color: navy; }"), ShouldBeNil)
+			AddStyleSheet(sheet, STYLE_PROVIDER_PRIORITY_USER)
+			defer RemoveStyleSheet(sheet)
+			So(button.SetInlineStyle("color: yellow"), ShouldBeNil)
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "yellow")
+			button.ModifyFg(StateNormal, cdk.NewRGBColor(255, 0, 0))
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "#ff0000")
+			button.ModifyBg(StatePrelight, cdk.ColorDefault)
+			So(button.GetComputedStyle().Get(PropertyBackgroundColor).Value, ShouldEqual, "navy")
+			button.SetState(StatePrelight)
+			So(button.GetComputedStyle().Get(PropertyBackgroundColor).Value, ShouldEqual, "default")
+			button.UnsetState(StatePrelight)
+			button.ModifyText(StateNormal, cdk.NewRGBColor(0, 0, 255))
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "#0000ff")
+			button.ModifyCursor(cdk.NewRGBColor(0, 255, 0), cdk.ColorDefault)
+			So(button.GetComputedStyle().Get("--cursor-color").Value, ShouldEqual, "#00ff00")
+			modifier := button.GetModifierStyle()
+			So(modifier.GetColorFlags(StateNormal), ShouldEqual, RC_FG|RC_TEXT)
+			modifier.UnsetColor(RC_TEXT, StateNormal)
+			button.ModifyStyle(modifier)
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "#ff0000")
+			button.ModifyStyle(nil)
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "yellow")
+			So(button.GetModifierStyle().IsEmpty(), ShouldBeTrue)
+		})
+		Convey("glade style property", func() {
+			builder := NewBuilder()
+			top, err := builder.LoadFromString(`<interface>
+  <object class="GtkLabel" id="styled">
+    <property name="label">styled</property>
+    <property name="style">bold: true; color: red</property>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			label, ok := top.Children[0].Instance.(*CLabel)
+			So(ok, ShouldBeTrue)
+			So(label.GetModifierStyle().GetDeclarations(), ShouldEqual, "bold: true; color: red")
+			So(label.GetComputedStyle().Get(PropertyBold).Value, ShouldEqual, "true")
+		})
+	})
+}
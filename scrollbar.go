@@ -12,6 +12,35 @@ const TypeScrollbar cdk.CTypeTag = "ctk-scrollbar"
 
 func init() {
 	_ = cdk.TypesManager.AddType(TypeScrollbar, nil)
+	vertical := []struct {
+		key    cdk.Key
+		mods   cdk.ModMask
+		scroll ScrollType
+	}{
+		{cdk.KeyUp, cdk.ModNone, SCROLL_STEP_UP},
+		{cdk.KeyDown, cdk.ModNone, SCROLL_STEP_DOWN},
+		{cdk.KeyPgUp, cdk.ModNone, SCROLL_PAGE_UP},
+		{cdk.KeyPgDn, cdk.ModNone, SCROLL_PAGE_DOWN},
+	}
+	horizontal := []struct {
+		key    cdk.Key
+		mods   cdk.ModMask
+		scroll ScrollType
+	}{
+		{cdk.KeyLeft, cdk.ModNone, SCROLL_STEP_LEFT},
+		{cdk.KeyRight, cdk.ModNone, SCROLL_STEP_RIGHT},
+		{cdk.KeyLeft, cdk.ModShift, SCROLL_PAGE_LEFT},
+		{cdk.KeyRight, cdk.ModShift, SCROLL_PAGE_RIGHT},
+	}
+	scrollbar, vScrollbar, hScrollbar := BindingSetByType(TypeScrollbar), BindingSetByType(TypeVScrollbar), BindingSetByType(TypeHScrollbar)
+	for _, binding := range vertical {
+		scrollbar.AddSignal(binding.key, binding.mods, SignalMoveSlider, binding.scroll)
+		vScrollbar.AddSignal(binding.key, binding.mods, SignalMoveSlider, binding.scroll)
+	}
+	for _, binding := range horizontal {
+		scrollbar.AddSignal(binding.key, binding.mods, SignalMoveSlider, binding.scroll)
+		hScrollbar.AddSignal(binding.key, binding.mods, SignalMoveSlider, binding.scroll)
+	}
 }
 
 var (
@@ -154,6 +183,14 @@ func (s *CScrollbar) Init() (already bool) {
 	s.hasSecondaryBackwardStepper = false
 	s.hasSecondaryForwardStepper = false
 	s.SetTheme(DefaultColorScrollbarTheme)
+	s.Connect(SignalMoveSlider, fmt.Sprintf("%v.move-slider", s.ObjectName()), func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+		if len(argv) > 1 {
+			if scroll, ok := argv[1].(ScrollType); ok {
+				return s.moveSlider(scroll)
+			}
+		}
+		return cdk.EVENT_PASS
+	})
 	s.Resize()
 	return false
 }
@@ -276,6 +313,46 @@ func (s *CScrollbar) BackwardPage() cdk.EventFlag {
 	return s.Backward(page)
 }
 
+// moves the slider as given, for the "move-slider" key bindings. The scroll
+// types of the other orientation, such as SCROLL_STEP_LEFT for a vertical
+// scrollbar, are ignored
+func (s *CScrollbar) moveSlider(scroll ScrollType) cdk.EventFlag {
+	horizontal := s.orientation == cdk.ORIENTATION_HORIZONTAL
+	switch scroll {
+	case SCROLL_STEP_BACKWARD:
+		return s.BackwardStep()
+	case SCROLL_STEP_FORWARD:
+		return s.ForwardStep()
+	case SCROLL_PAGE_BACKWARD:
+		return s.BackwardPage()
+	case SCROLL_PAGE_FORWARD:
+		return s.ForwardPage()
+	case SCROLL_STEP_UP, SCROLL_STEP_LEFT:
+		if horizontal == (scroll == SCROLL_STEP_LEFT) {
+			return s.BackwardStep()
+		}
+	case SCROLL_STEP_DOWN, SCROLL_STEP_RIGHT:
+		if horizontal == (scroll == SCROLL_STEP_RIGHT) {
+			return s.ForwardStep()
+		}
+	case SCROLL_PAGE_UP, SCROLL_PAGE_LEFT:
+		if horizontal == (scroll == SCROLL_PAGE_LEFT) {
+			return s.BackwardPage()
+		}
+	case SCROLL_PAGE_DOWN, SCROLL_PAGE_RIGHT:
+		if horizontal == (scroll == SCROLL_PAGE_RIGHT) {
+			return s.ForwardPage()
+		}
+	case SCROLL_START:
+		min, _ := s.GetRange()
+		return s.Backward(s.GetValue() - min)
+	case SCROLL_END:
+		_, max := s.GetRange()
+		return s.Forward(max - s.GetValue())
+	}
+	return cdk.EVENT_PASS
+}
+
 func (s *CScrollbar) GetSizeRequest() (width, height int) {
 	size := cdk.NewRectangle(s.CWidget.GetSizeRequest())
 	switch s.orientation {
@@ -399,40 +476,7 @@ func (s *CScrollbar) ProcessEvent(evt cdk.Event) cdk.EventFlag {
 			s.CancelEvent()
 			return cdk.EVENT_STOP
 		}
-		step, page := 0, 0
-		if adjustment := s.GetAdjustment(); adjustment != nil {
-			step, page = adjustment.GetStepIncrement(), adjustment.GetPageIncrement()
-		} else {
-			s.LogError("missing adjustment")
-		}
-		switch s.orientation {
-		case cdk.ORIENTATION_HORIZONTAL:
-			switch e.Key() {
-			case cdk.KeyLeft:
-				if e.Modifiers().Has(cdk.ModShift) {
-					return s.Backward(page)
-				}
-				return s.Backward(step)
-			case cdk.KeyRight:
-				if e.Modifiers().Has(cdk.ModShift) {
-					return s.Forward(page)
-				}
-				return s.Forward(step)
-			}
-		case cdk.ORIENTATION_VERTICAL:
-			fallthrough
-		default:
-			switch e.Key() {
-			case cdk.KeyUp:
-				return s.Backward(step)
-			case cdk.KeyDown:
-				return s.Forward(step)
-			case cdk.KeyPgUp:
-				return s.Backward(page)
-			case cdk.KeyPgDn:
-				return s.Forward(page)
-			}
-		}
+		// the arrow keys move the slider with the "move-slider" key bindings
 	}
 	return cdk.EVENT_PASS
 }
@@ -621,15 +665,7 @@ func (s *CScrollbar) Draw(canvas cdk.Canvas) cdk.EventFlag {
 		sliderOrigin := slider.GetOrigin()
 		sliderOrigin.SubPoint(origin)
 		sliderSize := slider.GetAllocation()
-		canvas.Box(
-			sliderOrigin, sliderSize,
-			false, true,
-			theme.Content.Overlay,
-			theme.Content.FillRune,
-			theme.Content.Normal,
-			theme.Border.Normal,
-			theme.Border.BorderRunes,
-		)
+		s.GetStyle().PaintSlider(canvas, s.GetState(), SHADOW_OUT, cdk.Rectangle{}, s, "slider", sliderOrigin.X, sliderOrigin.Y, sliderSize.W, sliderSize.H, s.orientation)
 	}
 	// draw the stepper buttons
 	drawStepper := func(has bool, b Button, r cdk.Region, c *cdk.CCanvas) error {
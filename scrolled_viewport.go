@@ -28,6 +28,17 @@ func init() {
 		}
 		return ErrFallthrough
 	}
+	bindings := BindingSetByType(TypeScrolledViewport)
+	bindings.AddSignal(cdk.KeyUp, cdk.ModNone, SignalScrollChild, SCROLL_STEP_UP, false)
+	bindings.AddSignal(cdk.KeyDown, cdk.ModNone, SignalScrollChild, SCROLL_STEP_DOWN, false)
+	bindings.AddSignal(cdk.KeyPgUp, cdk.ModNone, SignalScrollChild, SCROLL_PAGE_UP, false)
+	bindings.AddSignal(cdk.KeyPgDn, cdk.ModNone, SignalScrollChild, SCROLL_PAGE_DOWN, false)
+	bindings.AddSignal(cdk.KeyHome, cdk.ModCtrl, SignalScrollChild, SCROLL_START, false)
+	bindings.AddSignal(cdk.KeyEnd, cdk.ModCtrl, SignalScrollChild, SCROLL_END, false)
+	bindings.AddSignal(cdk.KeyLeft, cdk.ModNone, SignalScrollChild, SCROLL_STEP_LEFT, true)
+	bindings.AddSignal(cdk.KeyRight, cdk.ModNone, SignalScrollChild, SCROLL_STEP_RIGHT, true)
+	bindings.AddSignal(cdk.KeyLeft, cdk.ModShift, SignalScrollChild, SCROLL_PAGE_LEFT, true)
+	bindings.AddSignal(cdk.KeyRight, cdk.ModShift, SignalScrollChild, SCROLL_PAGE_RIGHT, true)
 }
 
 // ScrolledViewport Hierarchy:
@@ -75,6 +86,14 @@ type ScrolledViewport interface {
 	Invalidate() cdk.EventFlag
 }
 
+// Scrollable is implemented by widgets with native scrolling capabilities,
+// such as Terminal. When added to a ScrolledViewport, a Scrollable child is
+// given the adjustments of the scrollbars and allocated the visible area,
+// instead of being scrolled according to its size request.
+type Scrollable interface {
+	SetScrollAdjustments(hAdjustment, vAdjustment *CAdjustment)
+}
+
 type CScrolledViewport struct {
 	CViewport
 
@@ -134,6 +153,7 @@ func (s *CScrolledViewport) Init() (already bool) {
 	s.svFcHandle = fmt.Sprintf("%v.focus-changed", s.ObjectName())
 	s.Connect(SignalLostFocus, s.svFcHandle, s.handleLostFocus)
 	s.Connect(SignalGainedFocus, s.svFcHandle, s.handleGainedFocus)
+	s.Connect(SignalScrollChild, fmt.Sprintf("%v.scroll-child", s.ObjectName()), s.handleScrollChild)
 	s.Invalidate()
 	return false
 }
@@ -384,6 +404,21 @@ func (s *CScrolledViewport) SetTheme(theme cdk.Theme) {
 func (s *CScrolledViewport) Add(w Widget) {
 	if len(s.children) < 3 {
 		s.CContainer.Add(w)
+		w.SetParent(s)
+		if sw, ok := w.(Scrollable); ok {
+			var h, v *CAdjustment
+			if hs := s.GetHScrollbar(); hs != nil {
+				h = hs.GetAdjustment()
+			}
+			if vs := s.GetVScrollbar(); vs != nil {
+				v = vs.GetAdjustment()
+			}
+			sw.SetScrollAdjustments(h, v)
+		} else if cw, ok := w.(Container); ok {
+			// scroll to widgets within the child receiving the focus
+			cw.SetFocusHAdjustment(s.GetHAdjustment())
+			cw.SetFocusVAdjustment(s.GetVAdjustment())
+		}
 		s.Invalidate()
 	} else {
 		s.LogError("too many children for scrolled viewport")
@@ -392,6 +427,20 @@ func (s *CScrolledViewport) Add(w Widget) {
 
 func (s *CScrolledViewport) Remove(w Widget) {
 	s.CContainer.Remove(w)
+	if sw, ok := w.(Scrollable); ok {
+		sw.SetScrollAdjustments(nil, nil)
+	} else if cw, ok := w.(Container); ok {
+		cw.SetFocusHAdjustment(nil)
+		cw.SetFocusVAdjustment(nil)
+	}
+	s.Invalidate()
+}
+
+// Sets the focused child of the scrolled viewport, see ContainerSetFocusChild.
+// The viewport is updated for the focus adjustments of the child, which
+// scroll to the widget receiving the focus.
+func (s *CScrolledViewport) SetFocusChild(child Widget) {
+	s.CViewport.SetFocusChild(child)
 	s.Invalidate()
 }
 
@@ -578,19 +627,6 @@ func (s *CScrolledViewport) ProcessEvent(evt cdk.Event) cdk.EventFlag {
 			s.GrabFocus()
 			return cdk.EVENT_STOP
 		}
-	case *cdk.EventKey:
-		if vs := s.GetVScrollbar(); vs != nil {
-			if f := vs.ProcessEvent(evt); f == cdk.EVENT_STOP {
-				s.Invalidate()
-				return cdk.EVENT_STOP
-			}
-		}
-		if hs := s.GetHScrollbar(); hs != nil {
-			if f := hs.ProcessEvent(evt); f == cdk.EVENT_STOP {
-				s.Invalidate()
-				return cdk.EVENT_STOP
-			}
-		}
 	}
 	return cdk.EVENT_PASS
 }
@@ -836,6 +872,21 @@ func (s *CScrolledViewport) makeAdjustments() (region cdk.Region, changed bool)
 }
 
 func (s *CScrolledViewport) resizeViewport() cdk.EventFlag {
+	if child, ok := s.GetChild().(Scrollable); ok {
+		// scrollable children configure the adjustments themselves
+		origin, alloc := s.GetOrigin(), s.GetAllocation()
+		if s.HorizontalShowByPolicy() {
+			alloc.H -= 1
+		}
+		if s.VerticalShowByPolicy() {
+			alloc.W -= 1
+		}
+		if w, ok := child.(Widget); ok {
+			w.SetOrigin(origin.X, origin.Y)
+			w.SetAllocation(alloc)
+			return w.Resize()
+		}
+	}
 	region, _ := s.makeAdjustments()
 	if child := s.GetChild(); child != nil {
 		child.SetOrigin(region.X, region.Y)
@@ -883,6 +934,65 @@ func (s *CScrolledViewport) resizeScrollbars() cdk.EventFlag {
 	return cdk.EVENT_STOP
 }
 
+// scrolls the horizontal or vertical scrollbar for the "scroll-child" key
+// bindings
+func (s *CScrolledViewport) handleScrollChild(data []interface{}, argv ...interface{}) cdk.EventFlag {
+	if len(argv) < 3 {
+		return cdk.EVENT_PASS
+	}
+	scroll, ok := argv[1].(ScrollType)
+	horizontal, hok := argv[2].(bool)
+	if !ok || !hok {
+		s.LogError("invalid scroll-child arguments: %v", argv[1:])
+		return cdk.EVENT_PASS
+	}
+	if direction, ok := scrollFocusDirections[scroll]; ok && s.moveFocusWithin(direction) {
+		return cdk.EVENT_STOP
+	}
+	var f cdk.EventFlag
+	if horizontal {
+		if hs := s.GetHScrollbar(); hs != nil {
+			f = hs.moveSlider(scroll)
+		}
+	} else if vs := s.GetVScrollbar(); vs != nil {
+		f = vs.moveSlider(scroll)
+	}
+	if f == cdk.EVENT_STOP {
+		s.Invalidate()
+	}
+	return f
+}
+
+// the directions in which the arrow key scroll steps move the focus between
+// the widgets within the viewport
+var scrollFocusDirections = map[ScrollType]DirectionType{
+	SCROLL_STEP_UP:    DIR_UP,
+	SCROLL_STEP_DOWN:  DIR_DOWN,
+	SCROLL_STEP_LEFT:  DIR_LEFT,
+	SCROLL_STEP_RIGHT: DIR_RIGHT,
+}
+
+// moves the focus from a widget within the viewport to the nearest one in the
+// given direction, which is scrolled into view. Returns FALSE if the focus is
+// not within the viewport or there is no widget in that direction
+func (s *CScrolledViewport) moveFocusWithin(direction DirectionType) bool {
+	window := s.GetWindow()
+	child, ok := s.GetChild().(Container)
+	if window == nil || !ok {
+		return false
+	}
+	focused, ok := window.GetFocus().(Widget)
+	if !ok || !focused.IsAncestor(s) {
+		return false
+	}
+	fc, _ := child.GetFocusChain()
+	if next := focusInDirection(focused, fc, direction, false); next != nil {
+		next.GrabFocus()
+		return true
+	}
+	return false
+}
+
 func (s *CScrolledViewport) handleLostFocus([]interface{}, ...interface{}) cdk.EventFlag {
 	s.Invalidate()
 	return cdk.EVENT_PASS
@@ -937,4 +1047,7 @@ const SignalMoveFocusOut cdk.Signal = "move-focus-out"
 // scrolls is pressed. The horizontal or vertical adjustment is updated which
 // triggers a signal that the scrolled windows child may listen to and scroll
 // itself.
+// Listener function arguments:
+// 	scroll ScrollType	a ScrollType describing how much to scroll
+// 	horizontal bool	whether the keybinding scrolls the child horizontally or not
 const SignalScrollChild cdk.Signal = "scroll-child"
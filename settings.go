@@ -0,0 +1,186 @@
+package ctk
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for Settings objects
+const TypeSettings cdk.CTypeTag = "ctk-settings"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeSettings, func() interface{} { return MakeSettings() })
+}
+
+// Settings Hierarchy:
+//	Object
+//	  +- Settings
+//
+// Settings provide a mechanism to share global settings between applications.
+// There is one Settings object shared by all Windows, see GetDefaultSettings.
+// Setting the ctk-theme-name property switches the current StyleTheme, see
+// SwitchTheme.
+type Settings interface {
+	Object
+
+	Init() (already bool)
+	GetThemeName() (name string)
+	SetThemeName(name string) (err error)
+	GetKeyThemeName() (name string)
+	SetKeyThemeName(name string) (err error)
+	GetKeynavWrapAround() (wrap bool)
+	SetKeynavWrapAround(wrap bool)
+}
+
+// The CSettings structure implements the Settings interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Settings objects
+type CSettings struct {
+	CObject
+
+	themeErr error
+}
+
+var (
+	defaultSettings     *CSettings
+	defaultSettingsLock = &sync.Mutex{}
+)
+
+// Default constructor for Settings objects
+func MakeSettings() *CSettings {
+	return NewSettings()
+}
+
+// Returns a newly created Settings object. Applications should use the shared
+// instance returned by GetDefaultSettings.
+func NewSettings() (value *CSettings) {
+	s := new(CSettings)
+	s.Init()
+	return s
+}
+
+// Returns the Settings object shared by all Windows.
+func GetDefaultSettings() (settings Settings) {
+	defaultSettingsLock.Lock()
+	defer defaultSettingsLock.Unlock()
+	if defaultSettings == nil {
+		defaultSettings = NewSettings()
+	}
+	return defaultSettings
+}
+
+// Settings object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the Settings instance
+func (s *CSettings) Init() (already bool) {
+	if s.InitTypeItem(TypeSettings, s) {
+		return true
+	}
+	s.CObject.Init()
+	_ = s.InstallProperty(PropertyCtkThemeName, cdk.StringProperty, true, "")
+	_ = s.InstallProperty(PropertyCtkKeyThemeName, cdk.StringProperty, true, "")
+	_ = s.InstallProperty(PropertyCtkKeynavWrapAround, cdk.BoolProperty, true, true)
+	s.Connect(cdk.SignalSetProperty, fmt.Sprintf("%v.set-theme-name", s.ObjectName()), func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+		if len(argv) == 3 {
+			if key, ok := argv[1].(cdk.Property); ok && key == PropertyCtkThemeName {
+				if name, ok := argv[2].(string); !ok {
+					s.LogError("property %v value is not string: %T", key, argv[2])
+					return cdk.EVENT_STOP
+				} else if name != "" {
+					if s.themeErr = SwitchTheme(name); s.themeErr != nil {
+						s.LogErr(s.themeErr)
+						return cdk.EVENT_STOP
+					}
+				}
+			}
+		}
+		// allow property to be set
+		return cdk.EVENT_PASS
+	})
+	return false
+}
+
+// Returns the name of the theme set with SetThemeName, or the name of the
+// current StyleTheme if no theme was set.
+func (s *CSettings) GetThemeName() (name string) {
+	var err error
+	if name, err = s.GetStringProperty(PropertyCtkThemeName); err != nil {
+		s.LogErr(err)
+	}
+	if name == "" {
+		if theme := GetCurrentStyleTheme(); theme != nil {
+			name = theme.Name
+		}
+	}
+	return
+}
+
+// Switches to the theme with the given name, see SwitchTheme. The theme name is
+// kept only if the theme was switched successfully.
+func (s *CSettings) SetThemeName(name string) (err error) {
+	s.themeErr = nil
+	if err = s.SetStringProperty(PropertyCtkThemeName, name); err != nil {
+		return
+	}
+	return s.themeErr
+}
+
+// Returns the name of the key theme in use, or an empty string if only the
+// bindings of the Widget types apply.
+func (s *CSettings) GetKeyThemeName() (name string) {
+	var err error
+	if name, err = s.GetStringProperty(PropertyCtkKeyThemeName); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// Switches to the key theme with the given name, the BindingSet of the theme
+// applying to all Widgets ahead of the bindings of the Widget types. The
+// "emacs" and "vi" key themes are provided, any registered BindingSet may be
+// used. An empty name switches back to the bindings of the Widget types.
+func (s *CSettings) SetKeyThemeName(name string) (err error) {
+	if name != "" && FindBindingSet(name) == nil {
+		return fmt.Errorf("key theme not found: %v", name)
+	}
+	return s.SetStringProperty(PropertyCtkKeyThemeName, name)
+}
+
+// Returns TRUE if keyboard navigation with the arrow keys wraps around to the
+// far side of the Window when there is no widget in the direction moved.
+func (s *CSettings) GetKeynavWrapAround() (wrap bool) {
+	var err error
+	if wrap, err = s.GetBoolProperty(PropertyCtkKeynavWrapAround); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// Sets whether keyboard navigation with the arrow keys wraps around, see
+// GetKeynavWrapAround. When not wrapping around, failed keyboard navigation
+// rings the error bell instead.
+func (s *CSettings) SetKeynavWrapAround(wrap bool) {
+	if err := s.SetBoolProperty(PropertyCtkKeynavWrapAround, wrap); err != nil {
+		s.LogErr(err)
+	}
+}
+
+// The name of the StyleTheme in use, see SwitchTheme.
+// Flags: Read / Write
+// Default value: NULL
+const PropertyCtkThemeName cdk.Property = "ctk-theme-name"
+
+// The name of the key theme in use, see SetKeyThemeName.
+// Flags: Read / Write
+// Default value: NULL
+const PropertyCtkKeyThemeName cdk.Property = "ctk-key-theme-name"
+
+// Whether keyboard navigation with the arrow keys wraps around, see
+// SetKeynavWrapAround.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyCtkKeynavWrapAround cdk.Property = "ctk-keynav-wrap-around"
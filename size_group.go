@@ -0,0 +1,256 @@
+package ctk
+
+import (
+	"fmt"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for SizeGroup objects
+const TypeSizeGroup cdk.CTypeTag = "ctk-size-group"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeSizeGroup, func() interface{} { return MakeSizeGroup() })
+}
+
+// SizeGroup Hierarchy:
+//	Object
+//	  +- SizeGroup
+//
+// SizeGroup provides a mechanism for grouping a number of widgets together
+// so they all request the same amount of space. This is typically useful
+// when you want a column of widgets to have the same size, but you can't use
+// a Table widget. In detail, the size requested for each widget in a
+// SizeGroup is the maximum of the sizes that would have been requested for
+// each widget in the size group if they were not in the size group. The mode
+// of the size group (see SetMode) determines whether this applies to the
+// horizontal size, the vertical size, or both sizes. Note that size groups
+// only affect the amount of space requested, not the size that the widgets
+// finally receive. If you want the widgets in a SizeGroup to actually be the
+// same size, you need to pack them in such a way that they get the size they
+// request and not more.
+type SizeGroup interface {
+	Object
+
+	Init() (already bool)
+	SetMode(mode SizeGroupMode)
+	GetMode() (value SizeGroupMode)
+	SetIgnoreHidden(ignoreHidden bool)
+	GetIgnoreHidden() (value bool)
+	AddWidget(widget Widget)
+	RemoveWidget(widget Widget)
+	GetWidgets() (value []Widget)
+	GetSizeRequest() (width, height int)
+}
+
+// sizeGroupMember is implemented by CWidget and is used by SizeGroup to keep
+// track of the groups a widget belongs to
+type sizeGroupMember interface {
+	addSizeGroup(group SizeGroup)
+	removeSizeGroup(group SizeGroup)
+}
+
+// The CSizeGroup structure implements the SizeGroup interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with SizeGroup objects
+type CSizeGroup struct {
+	CObject
+
+	widgets    []Widget
+	requesting bool
+	dHandle    string
+}
+
+// Default constructor for SizeGroup objects
+func MakeSizeGroup() *CSizeGroup {
+	return NewSizeGroup(SIZE_GROUP_HORIZONTAL)
+}
+
+// Create a new SizeGroup.
+// Parameters:
+// 	mode	the mode for the new size group.
+// Returns:
+// 	a newly created SizeGroup
+func NewSizeGroup(mode SizeGroupMode) *CSizeGroup {
+	s := new(CSizeGroup)
+	s.Init()
+	s.SetMode(mode)
+	return s
+}
+
+// SizeGroup object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the SizeGroup instance
+func (s *CSizeGroup) Init() (already bool) {
+	if s.InitTypeItem(TypeSizeGroup, s) {
+		return true
+	}
+	s.CObject.Init()
+	s.widgets = make([]Widget, 0)
+	s.requesting = false
+	s.dHandle = fmt.Sprintf("%v.size-group-destroy", s.ObjectName())
+	_ = s.InstallBuildableProperty(PropertySizeGroupMode, cdk.StructProperty, true, SIZE_GROUP_HORIZONTAL)
+	_ = s.InstallBuildableProperty(PropertyIgnoreHidden, cdk.BoolProperty, true, false)
+	return false
+}
+
+// Sets the SizeGroupMode of the size group. The mode of the size group
+// determines whether the widgets in the size group should all have the same
+// horizontal requisition (SIZE_GROUP_HORIZONTAL) all have the same vertical
+// requisition (SIZE_GROUP_VERTICAL), or should all have the same
+// requisition in both directions (SIZE_GROUP_BOTH).
+// Parameters:
+// 	mode	the mode to set for the size group.
+func (s *CSizeGroup) SetMode(mode SizeGroupMode) {
+	if err := s.SetStructProperty(PropertySizeGroupMode, mode); err != nil {
+		s.LogErr(err)
+	}
+}
+
+// Gets the current mode of the size group. See SetMode.
+// Returns:
+// 	the current mode of the size group.
+func (s *CSizeGroup) GetMode() (value SizeGroupMode) {
+	var ok bool
+	if v, err := s.GetStructProperty(PropertySizeGroupMode); err != nil {
+		s.LogErr(err)
+	} else if value, ok = v.(SizeGroupMode); !ok {
+		s.LogError("value stored in %v property is not of SizeGroupMode type: %v (%T)", PropertySizeGroupMode, v, v)
+	}
+	return
+}
+
+// Sets whether unmapped widgets should be ignored when calculating the size.
+// Parameters:
+// 	ignoreHidden	whether unmapped widgets should be ignored
+// when calculating the size
+func (s *CSizeGroup) SetIgnoreHidden(ignoreHidden bool) {
+	if err := s.SetBoolProperty(PropertyIgnoreHidden, ignoreHidden); err != nil {
+		s.LogErr(err)
+	}
+}
+
+// Returns if invisible widgets are ignored when calculating the size.
+// Returns:
+// 	TRUE if invisible widgets are ignored.
+func (s *CSizeGroup) GetIgnoreHidden() (value bool) {
+	var err error
+	if value, err = s.GetBoolProperty(PropertyIgnoreHidden); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// Adds a widget to a SizeGroup. In the future, the requisition of the
+// widget will be determined as the maximum of its requisition and the
+// requisition of the other widgets in the size group. Whether this applies
+// horizontally, vertically, or in both directions depends on the mode of the
+// size group. See SetMode. When the widget is destroyed, it will be removed
+// from the size group.
+// Parameters:
+// 	widget	the Widget to add
+func (s *CSizeGroup) AddWidget(widget Widget) {
+	for _, w := range s.widgets {
+		if w.ObjectID() == widget.ObjectID() {
+			return
+		}
+	}
+	if f := s.Emit(SignalSizeGroupAdd, s, widget); f == cdk.EVENT_PASS {
+		s.widgets = append(s.widgets, widget)
+		if m, ok := widget.(sizeGroupMember); ok {
+			m.addSizeGroup(s)
+		}
+		widget.Connect(SignalDestroyEvent, s.dHandle, s.handleWidgetDestroy)
+	}
+}
+
+// Removes a widget from a SizeGroup.
+// Parameters:
+// 	widget	the Widget to remove
+func (s *CSizeGroup) RemoveWidget(widget Widget) {
+	for idx, w := range s.widgets {
+		if w.ObjectID() == widget.ObjectID() {
+			if f := s.Emit(SignalSizeGroupRemove, s, widget); f == cdk.EVENT_PASS {
+				s.widgets = append(s.widgets[:idx], s.widgets[idx+1:]...)
+				if m, ok := widget.(sizeGroupMember); ok {
+					m.removeSizeGroup(s)
+				}
+				_ = widget.Disconnect(SignalDestroyEvent, s.dHandle)
+			}
+			return
+		}
+	}
+}
+
+func (s *CSizeGroup) handleWidgetDestroy(_ []interface{}, argv ...interface{}) cdk.EventFlag {
+	if len(argv) > 0 {
+		if widget, ok := argv[0].(Widget); ok {
+			s.RemoveWidget(widget)
+		}
+	}
+	return cdk.EVENT_PASS
+}
+
+// Returns the list of widgets associated with size_group .
+// Returns:
+// 	a list of widgets.
+func (s *CSizeGroup) GetWidgets() (value []Widget) {
+	value = make([]Widget, len(s.widgets))
+	copy(value, s.widgets)
+	return
+}
+
+// Returns the maximum size requested by the widgets within the group, along
+// the directions specified by the group mode. Directions not managed by the
+// group and groups with no applicable members return -1. While the group is
+// computing the maximum, the members report their natural size requests.
+func (s *CSizeGroup) GetSizeRequest() (width, height int) {
+	width, height = -1, -1
+	if s.requesting {
+		return
+	}
+	mode := s.GetMode()
+	if mode == SIZE_GROUP_NONE {
+		return
+	}
+	s.requesting = true
+	defer func() { s.requesting = false }()
+	ignoreHidden := s.GetIgnoreHidden()
+	for _, w := range s.widgets {
+		if ignoreHidden && !w.IsVisible() {
+			continue
+		}
+		ww, wh := w.GetSizeRequest()
+		if (mode == SIZE_GROUP_HORIZONTAL || mode == SIZE_GROUP_BOTH) && ww > width {
+			width = ww
+		}
+		if (mode == SIZE_GROUP_VERTICAL || mode == SIZE_GROUP_BOTH) && wh > height {
+			height = wh
+		}
+	}
+	return
+}
+
+// If TRUE, unmapped widgets are ignored when determining the size of the
+// group.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyIgnoreHidden cdk.Property = "ignore-hidden"
+
+// The directions in which the size group affects the requested sizes of its
+// component widgets.
+// Flags: Read / Write
+// Default value: GTK_SIZE_GROUP_HORIZONTAL
+const PropertySizeGroupMode cdk.Property = "mode"
+
+// Emitted when a widget is added to the size group.
+// Listener function arguments:
+// 	widget Widget	the widget being added
+const SignalSizeGroupAdd cdk.Signal = "size-group-add"
+
+// Emitted when a widget is removed from the size group.
+// Listener function arguments:
+// 	widget Widget	the widget being removed
+const SignalSizeGroupRemove cdk.Signal = "size-group-remove"
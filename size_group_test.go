@@ -0,0 +1,76 @@
+package ctk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSizeGroup(t *testing.T) {
+	Convey("Testing SizeGroups", t, func() {
+		Convey("basics", func() {
+			sg := NewSizeGroup(SIZE_GROUP_HORIZONTAL)
+			So(sg, ShouldNotBeNil)
+			So(sg.GetMode(), ShouldEqual, SIZE_GROUP_HORIZONTAL)
+			So(sg.GetIgnoreHidden(), ShouldBeFalse)
+			w, h := sg.GetSizeRequest()
+			So(w, ShouldEqual, -1)
+			So(h, ShouldEqual, -1)
+		})
+		Convey("members report the group maximum", func() {
+			short, long := NewLabel("a"), NewLabel("abcdef")
+			short.Show()
+			long.Show()
+			sg := NewSizeGroup(SIZE_GROUP_HORIZONTAL)
+			sg.AddWidget(short)
+			sg.AddWidget(long)
+			sg.AddWidget(long)
+			So(sg.GetWidgets(), ShouldHaveLength, 2)
+			w, _ := short.GetSizeRequest()
+			So(w, ShouldEqual, 6)
+			w, _ = long.GetSizeRequest()
+			So(w, ShouldEqual, 6)
+			long.Hide()
+			sg.SetIgnoreHidden(true)
+			w, _ = short.GetSizeRequest()
+			So(w, ShouldEqual, 1)
+			sg.SetMode(SIZE_GROUP_NONE)
+			sg.SetIgnoreHidden(false)
+			w, _ = short.GetSizeRequest()
+			So(w, ShouldEqual, 1)
+			sg.SetMode(SIZE_GROUP_BOTH)
+			sg.RemoveWidget(long)
+			So(sg.GetWidgets(), ShouldHaveLength, 1)
+			w, _ = short.GetSizeRequest()
+			So(w, ShouldEqual, 1)
+		})
+		Convey("insets are not added to the group maximum", func() {
+			short, long := NewLabel("a"), NewLabel("abcdef")
+			So(short.SetInlineStyle("padding: 0 2"), ShouldBeNil)
+			So(long.SetInlineStyle("padding: 1 1; border-style: single; border-width: 1"), ShouldBeNil)
+			w, h := long.GetSizeRequest()
+			So(w, ShouldEqual, 10)
+			So(h, ShouldEqual, 5)
+			sg := NewSizeGroup(SIZE_GROUP_BOTH)
+			sg.AddWidget(short)
+			sg.AddWidget(long)
+			gw, gh := sg.GetSizeRequest()
+			So(gw, ShouldEqual, 10)
+			So(gh, ShouldEqual, 5)
+			for _, label := range []*CLabel{short, long} {
+				w, h = label.GetSizeRequest()
+				So(w, ShouldEqual, gw)
+				So(h, ShouldEqual, gh)
+			}
+		})
+		Convey("destroyed members are removed", func() {
+			sg := NewSizeGroup(SIZE_GROUP_BOTH)
+			label := NewLabel("a")
+			sg.AddWidget(label)
+			So(sg.GetWidgets(), ShouldHaveLength, 1)
+			label.Destroy()
+			So(sg.GetWidgets(), ShouldHaveLength, 0)
+			So(label.sizeGroups, ShouldHaveLength, 0)
+		})
+	})
+}
@@ -1,6 +1,8 @@
 package ctk
 
 import (
+	"sync"
+
 	"github.com/kckrinke/go-cdk"
 )
 
@@ -21,33 +23,32 @@ type Style interface {
 	Copy() (value Style)
 	Attach(window Window) (value Style)
 	Detach()
-	ApplyDefaultBackground(window Window, setBg bool, stateType StateType, area cdk.Rectangle, x int, y int, width int, height int)
-	LookupColor(colorName string, color cdk.Color) (value bool)
-	Get(widgetType cdk.CTypeTag, firstPropertyName string, argv ...interface{})
-	PaintArrow(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, arrowType ArrowType, fill bool, x int, y int, width int, height int)
-	PaintBox(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
-	PaintBoxGap(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType, gapX int, gapWidth int)
-	PaintCheck(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
-	PaintDiamond(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
-	PaintExtension(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType)
-	PaintFlatBox(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
-	PaintFocus(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
-	PaintHandle(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cdk.Orientation)
-	PaintHLine(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x1 int, x2 int, y int)
-	PaintOption(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
-	PaintPolygon(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, points cdk.Point2I, nPoints int, fill bool)
-	PaintShadow(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
-	PaintShadowGap(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType, gapX int, gapWidth int)
-	PaintSlider(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cdk.Orientation)
-	PaintSpinner(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, step int, x int, y int, width int, height int)
-	PaintTab(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
-	PaintVLine(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, y1 int, y2 int, x int)
-	PaintExpander(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x int, y int, expanderStyle ExpanderStyle)
+	GetEngine() (engine StyleEngine)
+	SetEngine(engine StyleEngine)
+	ApplyDefaultBackground(canvas cdk.Canvas, setBg bool, stateType StateType, area cdk.Rectangle, x int, y int, width int, height int)
+	LookupColor(colorName string) (color cdk.Color, found bool)
+	GetStyleProperty(widgetType cdk.CTypeTag, propertyName string) (value interface{})
+	PaintArrow(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, arrowType ArrowType, fill bool, x int, y int, width int, height int)
+	PaintBox(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
+	PaintBoxGap(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType, gapX int, gapWidth int)
+	PaintCheck(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
+	PaintDiamond(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
+	PaintExtension(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType)
+	PaintFlatBox(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
+	PaintFocus(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
+	PaintHandle(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cdk.Orientation)
+	PaintHLine(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x1 int, x2 int, y int)
+	PaintOption(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
+	PaintPolygon(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, points []cdk.Point2I, nPoints int, fill bool)
+	PaintShadow(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
+	PaintShadowGap(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType, gapX int, gapWidth int)
+	PaintSlider(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cdk.Orientation)
+	PaintSpinner(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, step int, x int, y int, width int, height int)
+	PaintTab(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int)
+	PaintVLine(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, y1 int, y2 int, x int)
+	PaintExpander(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x int, y int, expanderStyle ExpanderStyle)
 	// PaintLayout(window Window, stateType StateType, useText bool, area cdk.Rectangle, widget Widget, detail string, x int, y int, layout PangoLayout)
-	PaintResizeGrip(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, edge WindowEdge, x int, y int, width int, height int)
-	BorderNew() (value cdk.Border)
-	BorderCopy(border cdk.Border) (value cdk.Border)
-	BorderFree(border cdk.Border)
+	PaintResizeGrip(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, edge WindowEdge, x int, y int, width int, height int)
 }
 
 // The CStyle structure implements the Style interface and is
@@ -56,8 +57,15 @@ type Style interface {
 // of interacting with Style objects
 type CStyle struct {
 	CObject
+
+	engine StyleEngine
 }
 
+var (
+	defaultStyle     *CStyle
+	defaultStyleLock = &sync.Mutex{}
+)
+
 // Default constructor for Style objects
 func MakeStyle() *CStyle {
 	return NewStyle()
@@ -87,7 +95,9 @@ func (s *CStyle) Init() (already bool) {
 // 	a copy of style .
 // 	[transfer full]
 func (s *CStyle) Copy() (value Style) {
-	return nil
+	c := NewStyle()
+	c.engine = s.engine
+	return c
 }
 
 // Attaches a style to a window; this process allocates the colors and
@@ -103,30 +113,87 @@ func (s *CStyle) Copy() (value Style) {
 // 	newly created, the style parameter will be unref'ed, and the
 // 	new style will have a reference count belonging to the caller.
 func (s *CStyle) Attach(window Window) (value Style) {
-	return nil
+	return s
 }
 
 // Detaches a style from a window. If the style is not attached to any
 // windows anymore, it is unrealized. See Attach.
 func (s *CStyle) Detach() {}
 
+// Returns the StyleEngine the style paints with, which is the engine given to
+// SetEngine or, if none was given, the engine of the current StyleTheme.
+func (s *CStyle) GetEngine() (engine StyleEngine) {
+	if s.engine != nil {
+		return s.engine
+	}
+	return GetCurrentStyleEngine()
+}
+
+// Sets the StyleEngine the style paints with, regardless of the current
+// StyleTheme. Passing nil restores the engine of the current StyleTheme.
+// Parameters:
+// 	engine	the StyleEngine to use, or nil
+func (s *CStyle) SetEngine(engine StyleEngine) {
+	s.engine = engine
+}
+
+// returns the theme of the given widget, or the default theme if widget is
+// nil, with the Normal aspects set for the given state
+func (s *CStyle) paintTheme(widget Widget, stateType StateType) (theme cdk.Theme) {
+	if widget != nil {
+		theme = widget.GetThemeRequest()
+	} else {
+		theme = cdk.DefaultColorTheme
+	}
+	switch stateType {
+	case StateActive:
+		theme.Content.Normal = theme.Content.Active
+		theme.Border.Normal = theme.Border.Active
+	case StatePrelight, StateSelected:
+		theme.Content.Normal = theme.Content.Focused
+		theme.Border.Normal = theme.Border.Focused
+	case StateInsensitive:
+		theme.Content.Normal = theme.Content.Normal.Dim(true)
+		theme.Border.Normal = theme.Border.Normal.Dim(true)
+	}
+	return
+}
+
+// the origin and size of the rectangle given, with the size limited to that of
+// the area unless the area is empty. A cdk.Rectangle has no position, so the
+// area is taken to start at the origin of the rectangle and only its size
+// limits the painting.
+func paintStyleArea(area cdk.Rectangle, x, y, width, height int) (origin cdk.Point2I, size cdk.Rectangle) {
+	if area.W > 0 && width > area.W {
+		width = area.W
+	}
+	if area.H > 0 && height > area.H {
+		height = area.H
+	}
+	return cdk.MakePoint2I(x, y), cdk.MakeRectangle(width, height)
+}
+
 //
 // Parameters:
 // 	area	.
-func (s *CStyle) ApplyDefaultBackground(window Window, setBg bool, stateType StateType, area cdk.Rectangle, x int, y int, width int, height int) {
+func (s *CStyle) ApplyDefaultBackground(canvas cdk.Canvas, setBg bool, stateType StateType, area cdk.Rectangle, x int, y int, width int, height int) {
+	if setBg {
+		origin, size := paintStyleArea(area, x, y, width, height)
+		s.GetEngine().PaintFlatBox(canvas, s.paintTheme(nil, stateType), origin, size)
+	}
 }
 
-// Looks up color_name in the style's logical color mappings, filling in
-// color and returning TRUE if found, otherwise returning FALSE. Do not cache
-// the found mapping, because it depends on the Style and might change
-// when a theme switch occurs.
+// Looks up color_name in the style's logical color mappings, returning the
+// color and TRUE if found, otherwise returning FALSE. The logical colors are
+// those defined with @define-color by the StyleSheets registered for all
+// Windows. Do not cache the found mapping, because it depends on the Style and
+// might change when a theme switch occurs.
 // Parameters:
 // 	colorName	the name of the logical color to look up
-// 	color	the Color to fill in.
 // Returns:
-// 	TRUE if the mapping was found.
-func (s *CStyle) LookupColor(colorName string, color cdk.Color) (value bool) {
-	return false
+// 	the color found and TRUE if the mapping was found.
+func (s *CStyle) LookupColor(colorName string) (color cdk.Color, found bool) {
+	return LookupStyleColor(colorName)
 }
 
 // Looks up stock_id in the icon factories associated with style and the
@@ -191,10 +258,10 @@ func (s *CStyle) GetStyleProperty(widgetType cdk.CTypeTag, propertyName string)
 // , terminated by NULL.
 // func (s *CStyle) Get(widgetType GType, firstPropertyName string, argv ...interface{}) {}
 
-// Draws an arrow in the given rectangle on window using the given
+// Draws an arrow in the given rectangle on canvas using the given
 // parameters. arrow_type determines the direction of the arrow.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	the type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -207,12 +274,14 @@ func (s *CStyle) GetStyleProperty(widgetType cdk.CTypeTag, propertyName string)
 // 	y	y origin of the rectangle to draw the arrow in
 // 	width	width of the rectangle to draw the arrow in
 // 	height	height of the rectangle to draw the arrow in
-func (s *CStyle) PaintArrow(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, arrowType ArrowType, fill bool, x int, y int, width int, height int) {
+func (s *CStyle) PaintArrow(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, arrowType ArrowType, fill bool, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintArrow(canvas, s.paintTheme(widget, stateType), arrowType, fill, origin, size)
 }
 
-// Draws a box on window with the given parameters.
+// Draws a box on canvas with the given parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	the type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -223,13 +292,15 @@ func (s *CStyle) PaintArrow(window Window, stateType StateType, shadowType Shado
 // 	y	y origin of the box
 // 	width	the width of the box
 // 	height	the height of the box
-func (s *CStyle) PaintBox(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+func (s *CStyle) PaintBox(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintBox(canvas, s.paintTheme(widget, stateType), shadowType, origin, size)
 }
 
-// Draws a box in window using the given style and state and shadow type,
+// Draws a box in canvas using the given style and state and shadow type,
 // leaving a gap in one side.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -243,13 +314,15 @@ func (s *CStyle) PaintBox(window Window, stateType StateType, shadowType ShadowT
 // 	gapSide	side in which to leave the gap
 // 	gapX	starting position of the gap
 // 	gapWidth	width of the gap
-func (s *CStyle) PaintBoxGap(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType, gapX int, gapWidth int) {
+func (s *CStyle) PaintBoxGap(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType, gapX int, gapWidth int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintBoxGap(canvas, s.paintTheme(widget, stateType), shadowType, origin, size, gapSide, gapX, gapWidth)
 }
 
-// Draws a check button indicator in the given rectangle on window with the
+// Draws a check button indicator in the given rectangle on canvas with the
 // given parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	the type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -260,13 +333,15 @@ func (s *CStyle) PaintBoxGap(window Window, stateType StateType, shadowType Shad
 // 	y	y origin of the rectangle to draw the check in
 // 	width	the width of the rectangle to draw the check in
 // 	height	the height of the rectangle to draw the check in
-func (s *CStyle) PaintCheck(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+func (s *CStyle) PaintCheck(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintCheck(canvas, s.paintTheme(widget, stateType), shadowType, origin, size)
 }
 
-// Draws a diamond in the given rectangle on window using the given
+// Draws a diamond in the given rectangle on canvas using the given
 // parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	the type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -277,12 +352,14 @@ func (s *CStyle) PaintCheck(window Window, stateType StateType, shadowType Shado
 // 	y	y origin of the rectangle to draw the diamond in
 // 	width	width of the rectangle to draw the diamond in
 // 	height	height of the rectangle to draw the diamond in
-func (s *CStyle) PaintDiamond(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+func (s *CStyle) PaintDiamond(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintDiamond(canvas, s.paintTheme(widget, stateType), shadowType, origin, size)
 }
 
 // Draws an extension, i.e. a notebook tab.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -294,12 +371,14 @@ func (s *CStyle) PaintDiamond(window Window, stateType StateType, shadowType Sha
 // 	width	width of the extension
 // 	height	width of the extension
 // 	gapSide	the side on to which the extension is attached
-func (s *CStyle) PaintExtension(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType) {
+func (s *CStyle) PaintExtension(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintExtension(canvas, s.paintTheme(widget, stateType), shadowType, origin, size, gapSide)
 }
 
-// Draws a flat box on window with the given parameters.
+// Draws a flat box on canvas with the given parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	the type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -310,13 +389,15 @@ func (s *CStyle) PaintExtension(window Window, stateType StateType, shadowType S
 // 	y	y origin of the box
 // 	width	the width of the box
 // 	height	the height of the box
-func (s *CStyle) PaintFlatBox(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+func (s *CStyle) PaintFlatBox(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintFlatBox(canvas, s.paintTheme(widget, stateType), origin, size)
 }
 
-// Draws a focus indicator around the given rectangle on window using the
+// Draws a focus indicator around the given rectangle on canvas using the
 // given style.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	area	clip rectangle, or NULL if the
 // output should not be clipped.
@@ -326,12 +407,14 @@ func (s *CStyle) PaintFlatBox(window Window, stateType StateType, shadowType Sha
 // 	y	the y origin of the rectangle around which to draw a focus indicator
 // 	width	the width of the rectangle around which to draw a focus indicator
 // 	height	the height of the rectangle around which to draw a focus indicator
-func (s *CStyle) PaintFocus(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+func (s *CStyle) PaintFocus(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintFocus(canvas, s.paintTheme(widget, stateType), origin, size)
 }
 
 // Draws a handle as used in HandleBox and Paned.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -343,13 +426,34 @@ func (s *CStyle) PaintFocus(window Window, stateType StateType, area cdk.Rectang
 // 	width	with of the handle
 // 	height	height of the handle
 // 	orientation	the orientation of the handle
-func (s *CStyle) PaintHandle(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cdk.Orientation) {
+func (s *CStyle) PaintHandle(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cdk.Orientation) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintHandle(canvas, s.paintTheme(widget, stateType), origin, size, orientation)
 }
 
-// Draws a radio button indicator in the given rectangle on window with the
+// Draws a horizontal line from (x1 , y ) to (x2 , y ) on canvas using the
+// given style and state.
+// Parameters:
+// 	canvas	the cdk.Canvas to draw on
+// 	stateType	a state
+// 	area	rectangle to which the output is clipped, or NULL if the
+// output should not be clipped.
+// 	widget	the widget.
+// 	detail	a style detail.
+// 	x1	the starting x coordinate
+// 	x2	the ending x coordinate
+// 	y	the y coordinate
+func (s *CStyle) PaintHLine(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x1 int, x2 int, y int) {
+	if area.W > 0 && x2-x1 >= area.W {
+		x2 = x1 + area.W - 1
+	}
+	s.GetEngine().PaintHLine(canvas, s.paintTheme(widget, stateType), x1, x2, y)
+}
+
+// Draws a radio button indicator in the given rectangle on canvas with the
 // given parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	the type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -360,12 +464,14 @@ func (s *CStyle) PaintHandle(window Window, stateType StateType, shadowType Shad
 // 	y	y origin of the rectangle to draw the option in
 // 	width	the width of the rectangle to draw the option in
 // 	height	the height of the rectangle to draw the option in
-func (s *CStyle) PaintOption(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+func (s *CStyle) PaintOption(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintOption(canvas, s.paintTheme(widget, stateType), shadowType, origin, size)
 }
 
-// Draws a polygon on window with the given parameters.
+// Draws a polygon on canvas with the given parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -376,13 +482,17 @@ func (s *CStyle) PaintOption(window Window, stateType StateType, shadowType Shad
 // 	nPoints	length of points
 //
 // 	fill	TRUE if the polygon should be filled
-func (s *CStyle) PaintPolygon(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, points []cdk.Point2I, nPoints int, fill bool) {
+func (s *CStyle) PaintPolygon(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, points []cdk.Point2I, nPoints int, fill bool) {
+	if nPoints >= 0 && nPoints < len(points) {
+		points = points[:nPoints]
+	}
+	s.GetEngine().PaintPolygon(canvas, s.paintTheme(widget, stateType), points, fill)
 }
 
-// Draws a shadow around the given rectangle in window using the given style
+// Draws a shadow around the given rectangle in canvas using the given style
 // and state and shadow type.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	type of shadow to draw
 // 	area	clip rectangle or NULL if the
@@ -393,13 +503,15 @@ func (s *CStyle) PaintPolygon(window Window, stateType StateType, shadowType Sha
 // 	y	y origin of the rectangle
 // 	width	width of the rectangle
 // 	height	width of the rectangle
-func (s *CStyle) PaintShadow(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+func (s *CStyle) PaintShadow(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintShadow(canvas, s.paintTheme(widget, stateType), shadowType, origin, size)
 }
 
-// Draws a shadow around the given rectangle in window using the given style
+// Draws a shadow around the given rectangle in canvas using the given style
 // and state and shadow type, leaving a gap in one side.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -413,13 +525,15 @@ func (s *CStyle) PaintShadow(window Window, stateType StateType, shadowType Shad
 // 	gapSide	side in which to leave the gap
 // 	gapX	starting position of the gap
 // 	gapWidth	width of the gap
-func (s *CStyle) PaintShadowGap(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType, gapX int, gapWidth int) {
+func (s *CStyle) PaintShadowGap(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide PositionType, gapX int, gapWidth int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintShadowGap(canvas, s.paintTheme(widget, stateType), shadowType, origin, size, gapSide, gapX, gapWidth)
 }
 
-// Draws a slider in the given rectangle on window using the given style and
+// Draws a slider in the given rectangle on canvas using the given style and
 // orientation.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	a shadow
 // 	area	clip rectangle, or NULL if the
@@ -431,12 +545,14 @@ func (s *CStyle) PaintShadowGap(window Window, stateType StateType, shadowType S
 // 	width	the width of the rectangle in which to draw a slider
 // 	height	the height of the rectangle in which to draw a slider
 // 	orientation	the orientation to be used
-func (s *CStyle) PaintSlider(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cdk.Orientation) {
+func (s *CStyle) PaintSlider(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cdk.Orientation) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintSlider(canvas, s.paintTheme(widget, stateType), origin, size, orientation)
 }
 
-// Draws a spinner on window using the given parameters.
+// Draws a spinner on canvas using the given parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	area	clip rectangle, or NULL if the
 // output should not be clipped.
@@ -447,13 +563,15 @@ func (s *CStyle) PaintSlider(window Window, stateType StateType, shadowType Shad
 // 	y	the y origin of the rectangle in which to draw the spinner
 // 	width	the width of the rectangle in which to draw the spinner
 // 	height	the height of the rectangle in which to draw the spinner
-func (s *CStyle) PaintSpinner(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, step int, x int, y int, width int, height int) {
+func (s *CStyle) PaintSpinner(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, step int, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintSpinner(canvas, s.paintTheme(widget, stateType), step, origin, size)
 }
 
 // Draws an option menu tab (i.e. the up and down pointing arrows) in the
-// given rectangle on window using the given parameters.
+// given rectangle on canvas using the given parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	shadowType	the type of shadow to draw
 // 	area	clip rectangle, or NULL if the
@@ -464,13 +582,15 @@ func (s *CStyle) PaintSpinner(window Window, stateType StateType, area cdk.Recta
 // 	y	y origin of the rectangle to draw the tab in
 // 	width	the width of the rectangle to draw the tab in
 // 	height	the height of the rectangle to draw the tab in
-func (s *CStyle) PaintTab(window Window, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+func (s *CStyle) PaintTab(canvas cdk.Canvas, stateType StateType, shadowType ShadowType, area cdk.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintTab(canvas, s.paintTheme(widget, stateType), origin, size)
 }
 
-// Draws a vertical line from (x , y1_ ) to (x , y2_ ) in window using the
+// Draws a vertical line from (x , y1_ ) to (x , y2_ ) in canvas using the
 // given style and state.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	area	rectangle to which the output is clipped, or NULL if the
 // output should not be clipped.
@@ -479,7 +599,11 @@ func (s *CStyle) PaintTab(window Window, stateType StateType, shadowType ShadowT
 // 	y1	the starting y coordinate
 // 	y2	the ending y coordinate
 // 	x	the x coordinate
-func (s *CStyle) PaintVLine(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, y1 int, y2 int, x int) {
+func (s *CStyle) PaintVLine(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, y1 int, y2 int, x int) {
+	if area.H > 0 && y2-y1 >= area.H {
+		y2 = y1 + area.H - 1
+	}
+	s.GetEngine().PaintVLine(canvas, s.paintTheme(widget, stateType), y1, y2, x)
 }
 
 // Draws an expander as used in TreeView. x and y specify the center the
@@ -491,7 +615,7 @@ func (s *CStyle) PaintVLine(window Window, stateType StateType, area cdk.Rectang
 // the collapsed position and expander_size pixels wide in the expanded
 // position.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	area	clip rectangle, or NULL if the
 // output should not be clipped.
@@ -502,12 +626,13 @@ func (s *CStyle) PaintVLine(window Window, stateType StateType, area cdk.Rectang
 // 	expanderStyle	the style to draw the expander in; determines
 // whether the expander is collapsed, expanded, or in an
 // intermediate state.
-func (s *CStyle) PaintExpander(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x int, y int, expanderStyle ExpanderStyle) {
+func (s *CStyle) PaintExpander(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, x int, y int, expanderStyle ExpanderStyle) {
+	s.GetEngine().PaintExpander(canvas, s.paintTheme(widget, stateType), cdk.MakePoint2I(x, y), expanderStyle)
 }
 
-// Draws a layout on window using the given parameters.
+// Draws a layout on canvas using the given parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	useText	whether to use the text or foreground
 // graphics context of style
@@ -519,13 +644,13 @@ func (s *CStyle) PaintExpander(window Window, stateType StateType, area cdk.Rect
 // 	x	x origin
 // 	y	y origin
 // 	layout	the layout to draw
-// func (s *CStyle) PaintLayout(window Window, stateType StateType, useText bool, area cdk.Rectangle, widget Widget, detail string, x int, y int, layout PangoLayout) {
+// func (s *CStyle) PaintLayout(canvas cdk.Canvas, stateType StateType, useText bool, area cdk.Rectangle, widget Widget, detail string, x int, y int, layout PangoLayout) {
 // }
 
-// Draws a resize grip in the given rectangle on window using the given
+// Draws a resize grip in the given rectangle on canvas using the given
 // parameters.
 // Parameters:
-// 	window	a Window
+// 	canvas	the cdk.Canvas to draw on
 // 	stateType	a state
 // 	area	clip rectangle, or NULL if the
 // output should not be clipped.
@@ -536,7 +661,9 @@ func (s *CStyle) PaintExpander(window Window, stateType StateType, area cdk.Rect
 // 	y	the y origin of the rectangle in which to draw the resize grip
 // 	width	the width of the rectangle in which to draw the resize grip
 // 	height	the height of the rectangle in which to draw the resize grip
-func (s *CStyle) PaintResizeGrip(window Window, stateType StateType, area cdk.Rectangle, widget Widget, detail string, edge WindowEdge, x int, y int, width int, height int) {
+func (s *CStyle) PaintResizeGrip(canvas cdk.Canvas, stateType StateType, area cdk.Rectangle, widget Widget, detail string, edge WindowEdge, x int, y int, width int, height int) {
+	origin, size := paintStyleArea(area, x, y, width, height)
+	s.GetEngine().PaintResizeGrip(canvas, s.paintTheme(widget, stateType), edge, origin, size)
 }
 
 // // Allocates a new Border structure and initializes its elements to zero.
@@ -0,0 +1,133 @@
+package ctk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// StyleSides holds a number of cells for each side of a Widget, as used by the
+// margin, border-width and padding CSS properties
+type StyleSides struct {
+	Top    int
+	Right  int
+	Bottom int
+	Left   int
+}
+
+// MakeStyleSides returns StyleSides with the same number of cells on each side
+func MakeStyleSides(cells int) StyleSides {
+	return StyleSides{Top: cells, Right: cells, Bottom: cells, Left: cells}
+}
+
+// Add returns the sum of both StyleSides, side by side
+func (s StyleSides) Add(other StyleSides) StyleSides {
+	return StyleSides{
+		Top:    s.Top + other.Top,
+		Right:  s.Right + other.Right,
+		Bottom: s.Bottom + other.Bottom,
+		Left:   s.Left + other.Left,
+	}
+}
+
+// Width returns the number of cells on the left and right sides combined
+func (s StyleSides) Width() int {
+	return s.Left + s.Right
+}
+
+// Height returns the number of cells on the top and bottom sides combined
+func (s StyleSides) Height() int {
+	return s.Top + s.Bottom
+}
+
+func (s StyleSides) String() string {
+	return fmt.Sprintf("%d %d %d %d", s.Top, s.Right, s.Bottom, s.Left)
+}
+
+// parse one to four cell counts, following the CSS shorthand convention of
+// top, right, bottom and left sides
+func parseStyleSides(value string) (sides StyleSides, err error) {
+	fields := strings.Fields(value)
+	if len(fields) < 1 || len(fields) > 4 {
+		return sides, fmt.Errorf("expected one to four sizes: %q", value)
+	}
+	cells := make([]int, len(fields))
+	for idx, field := range fields {
+		field = strings.TrimSuffix(strings.TrimSuffix(field, "px"), "ch")
+		if cells[idx], err = strconv.Atoi(field); err != nil || cells[idx] < 0 {
+			return sides, fmt.Errorf("invalid size: %q", fields[idx])
+		}
+	}
+	switch len(cells) {
+	case 1:
+		return MakeStyleSides(cells[0]), nil
+	case 2:
+		return StyleSides{Top: cells[0], Right: cells[1], Bottom: cells[0], Left: cells[1]}, nil
+	case 3:
+		return StyleSides{Top: cells[0], Right: cells[1], Bottom: cells[2], Left: cells[1]}, nil
+	}
+	return StyleSides{Top: cells[0], Right: cells[1], Bottom: cells[2], Left: cells[3]}, nil
+}
+
+// the runes used to draw each BorderStyle: top-left, top, top-right, left,
+// right, bottom-left, bottom and bottom-right
+var styleBorderRunes = map[BorderStyle][8]rune{
+	BorderStyleSingle:  {'┌', '─', '┐', '│', '│', '└', '─', '┘'},
+	BorderStyleDouble:  {'╔', '═', '╗', '║', '║', '╚', '═', '╝'},
+	BorderStyleRounded: {'╭', '─', '╮', '│', '│', '╰', '─', '╯'},
+	BorderStyleHeavy:   {'┏', '━', '┓', '┃', '┃', '┗', '━', '┛'},
+	BorderStyleAscii:   {'+', '-', '+', '|', '|', '+', '-', '+'},
+}
+
+// styleBox is the CSS box model of a Widget, the margin surrounds the border
+// which surrounds the padding which surrounds the content
+type styleBox struct {
+	margin  StyleSides
+	border  StyleSides
+	padding StyleSides
+	style   BorderStyle
+}
+
+// the space between the edge of the allocation and the content area
+func (b styleBox) insets() StyleSides {
+	return b.margin.Add(b.border).Add(b.padding)
+}
+
+// returns the box with the padding, then the border and then the margin
+// dropped until the content area given has room for at least one cell
+func (b styleBox) fit(alloc cdk.Rectangle) styleBox {
+	fits := func() bool {
+		insets := b.insets()
+		return alloc.W-insets.Width() > 0 && alloc.H-insets.Height() > 0
+	}
+	if !fits() {
+		b.padding = StyleSides{}
+	}
+	if !fits() {
+		b.border = StyleSides{}
+	}
+	if !fits() {
+		b.margin = StyleSides{}
+	}
+	return b
+}
+
+// returns the position and size of the content area, relative to the origin
+// of the allocation given
+func (b styleBox) content(alloc cdk.Rectangle) (origin cdk.Point2I, size cdk.Rectangle) {
+	insets := b.insets()
+	origin = cdk.MakePoint2I(insets.Left, insets.Top)
+	size = cdk.MakeRectangle(alloc.W-insets.Width(), alloc.H-insets.Height())
+	size.Floor(0, 0)
+	return
+}
+
+// draw the box within the area given with the StyleEngine given, the margin is
+// left untouched and the area within the border is filled when requested
+func (b styleBox) draw(canvas cdk.Canvas, engine StyleEngine, origin cdk.Point2I, size cdk.Rectangle, fill bool, theme cdk.Theme) {
+	origin = cdk.MakePoint2I(origin.X+b.margin.Left, origin.Y+b.margin.Top)
+	size = cdk.MakeRectangle(size.W-b.margin.Width(), size.H-b.margin.Height())
+	engine.PaintBorder(canvas, theme, b.style, b.border, fill, origin, size)
+}
@@ -0,0 +1,85 @@
+package ctk
+
+import (
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStyleBox(t *testing.T) {
+	Convey("Testing Style Boxes", t, func() {
+		Convey("parsing sides", func() {
+			sides, err := parseStyleSides("1")
+			So(err, ShouldBeNil)
+			So(sides, ShouldResemble, MakeStyleSides(1))
+			sides, err = parseStyleSides("1 2")
+			So(err, ShouldBeNil)
+			So(sides, ShouldResemble, StyleSides{Top: 1, Right: 2, Bottom: 1, Left: 2})
+			sides, err = parseStyleSides("1px 2ch 3")
+			So(err, ShouldBeNil)
+			So(sides, ShouldResemble, StyleSides{Top: 1, Right: 2, Bottom: 3, Left: 2})
+			sides, err = parseStyleSides("1 2 3 4")
+			So(err, ShouldBeNil)
+			So(sides, ShouldResemble, StyleSides{Top: 1, Right: 2, Bottom: 3, Left: 4})
+			So(sides.Width(), ShouldEqual, 6)
+			So(sides.Height(), ShouldEqual, 4)
+			_, err = parseStyleSides("")
+			So(err, ShouldNotBeNil)
+			_, err = parseStyleSides("1 2 3 4 5")
+			So(err, ShouldNotBeNil)
+			_, err = parseStyleSides("-1")
+			So(err, ShouldNotBeNil)
+		})
+		Convey("fitting and content", func() {
+			box := styleBox{
+				margin:  MakeStyleSides(1),
+				border:  MakeStyleSides(1),
+				padding: MakeStyleSides(1),
+				style:   BorderStyleSingle,
+			}
+			origin, size := box.content(cdk.MakeRectangle(10, 10))
+			So(origin, ShouldResemble, cdk.MakePoint2I(3, 3))
+			So(size, ShouldResemble, cdk.MakeRectangle(4, 4))
+			fitted := box.fit(cdk.MakeRectangle(10, 5))
+			So(fitted.padding, ShouldResemble, StyleSides{})
+			So(fitted.border, ShouldResemble, MakeStyleSides(1))
+			fitted = box.fit(cdk.MakeRectangle(10, 1))
+			So(fitted.insets(), ShouldResemble, StyleSides{})
+		})
+		Convey("widget defaults and overrides", func() {
+			button := NewButtonWithLabel("button")
+			So(button.GetCssInsets(), ShouldResemble, StyleSides{Top: 1, Right: 2, Bottom: 1, Left: 2})
+			w, h := button.GetSizeRequest()
+			So(w, ShouldEqual, 10)
+			So(h, ShouldEqual, 3)
+			So(button.SetInlineStyle("border-style: none; padding: 0"), ShouldBeNil)
+			So(button.GetCssInsets(), ShouldResemble, StyleSides{})
+			w, h = button.GetSizeRequest()
+			So(w, ShouldEqual, 6)
+			So(h, ShouldEqual, 1)
+			So(button.SetInlineStyle("border-style: double; border-width: 2 1; margin: 1"), ShouldBeNil)
+			border, style := button.GetCssBorder()
+			So(style, ShouldEqual, BorderStyleDouble)
+			So(border, ShouldResemble, StyleSides{Top: 2, Right: 1, Bottom: 2, Left: 1})
+			So(button.GetCssInsets(), ShouldResemble, StyleSides{Top: 3, Right: 3, Bottom: 3, Left: 3})
+			frame := NewFrame("frame")
+			border, style = frame.GetCssBorder()
+			So(style, ShouldEqual, BorderStyleSingle)
+			So(border, ShouldResemble, MakeStyleSides(1))
+			label := NewLabel("label")
+			So(label.GetCssInsets(), ShouldResemble, StyleSides{})
+			label.SetPadding(1, 0)
+			So(label.GetCssPadding(), ShouldResemble, StyleSides{Right: 1, Left: 1})
+			w, _ = label.GetSizeRequest()
+			So(w, ShouldEqual, 7)
+			So(label.SetInlineStyle("padding: 0 1"), ShouldBeNil)
+			So(label.GetCssInsets(), ShouldResemble, StyleSides{Right: 2, Left: 2})
+			w, _ = label.GetSizeRequest()
+			So(w, ShouldEqual, 9)
+			alignment := NewAlignment(0.5, 0.5, 1, 1)
+			alignment.SetPadding(1, 2, 3, 4)
+			So(alignment.GetCssPadding(), ShouldResemble, StyleSides{Top: 1, Right: 4, Bottom: 2, Left: 3})
+		})
+	})
+}
@@ -0,0 +1,381 @@
+package ctk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// StyleProviderPriority orders the StyleSheets registered for display or
+// window wide use. When cascading, the properties of a StyleSheet with a higher
+// priority take precedence over those of lower priority regardless of selector
+// specificity. Inline styles set on a Widget take precedence over all
+// registered StyleSheets.
+type StyleProviderPriority uint
+
+const (
+	// the priority used for default style information that is used in the
+	// absence of more specific style information
+	STYLE_PROVIDER_PRIORITY_FALLBACK StyleProviderPriority = 1
+	// the priority used for style information provided by themes
+	STYLE_PROVIDER_PRIORITY_THEME StyleProviderPriority = 200
+	// the priority used for style information provided by settings
+	STYLE_PROVIDER_PRIORITY_SETTINGS StyleProviderPriority = 400
+	// a good default priority for style information provided by applications
+	STYLE_PROVIDER_PRIORITY_APPLICATION StyleProviderPriority = 600
+	// the priority used for the style information from the user's own
+	// configuration, which overrides the application
+	STYLE_PROVIDER_PRIORITY_USER StyleProviderPriority = 800
+)
+
+// ComputedStyle holds the cascaded style properties of a Widget, including
+// any properties inherited from the Widget's parent. A new ComputedStyle is
+// made each time the style is recomputed, so children can tell when their
+// parent's style has changed
+type ComputedStyle struct {
+	Properties map[string]*StyleSheetProperty
+}
+
+// Get returns the cascaded property with the given name, or nil if no style
+// sheet declared a value for the property
+func (c *ComputedStyle) Get(name cdk.Property) *StyleSheetProperty {
+	if c == nil {
+		return nil
+	}
+	if property, ok := c.Properties[string(name)]; ok {
+		return property
+	}
+	return nil
+}
+
+// properties inherited from the parent Widget when not declared
+var inheritedStyleProperties = []cdk.Property{
+	PropertyColor,
+	PropertyBackgroundColor,
+	PropertyBold,
+	PropertyBlink,
+	PropertyReverse,
+	PropertyUnderline,
+	PropertyDim,
+	PropertyItalic,
+	PropertyStrike,
+}
+
+type styleRegistration struct {
+	sheet    *StyleSheet
+	priority StyleProviderPriority
+	window   int
+	sequence uint64
+}
+
+var (
+	styleRegistry   []*styleRegistration
+	styleSequence   uint64
+	styleGeneration uint64
+	styleLock       = &sync.RWMutex{}
+	// until the display reports otherwise, presume a classic terminal
+	styleMedia = StyleMedia{
+		Width:       80,
+		Height:      24,
+		Colors:      256,
+		ColorScheme: detectColorScheme(),
+	}
+)
+
+// AddStyleSheet registers the given StyleSheet with all Widgets of all Windows
+// at the given priority. Widgets restyle the next time they are drawn.
+func AddStyleSheet(sheet *StyleSheet, priority StyleProviderPriority) {
+	addStyleSheet(sheet, priority, -1)
+}
+
+// RemoveStyleSheet unregisters the given StyleSheet from all Widgets
+func RemoveStyleSheet(sheet *StyleSheet) {
+	removeStyleSheet(sheet, -1)
+}
+
+// register a StyleSheet for the Window with the given ObjectID, or for all
+// Windows if the id given is -1. Registering a StyleSheet already registered
+// updates the priority
+func addStyleSheet(sheet *StyleSheet, priority StyleProviderPriority, window int) {
+	styleLock.Lock()
+	found := false
+	for _, registration := range styleRegistry {
+		if registration.sheet == sheet && registration.window == window {
+			registration.priority = priority
+			found = true
+			break
+		}
+	}
+	if !found {
+		styleSequence++
+		styleRegistry = append(styleRegistry, &styleRegistration{
+			sheet:    sheet,
+			priority: priority,
+			window:   window,
+			sequence: styleSequence,
+		})
+	}
+	sort.SliceStable(styleRegistry, func(i, j int) bool {
+		if styleRegistry[i].priority == styleRegistry[j].priority {
+			return styleRegistry[i].sequence < styleRegistry[j].sequence
+		}
+		return styleRegistry[i].priority < styleRegistry[j].priority
+	})
+	styleGeneration++
+	styleLock.Unlock()
+	requestStyleDraw()
+}
+
+func removeStyleSheet(sheet *StyleSheet, window int) {
+	styleLock.Lock()
+	for idx, registration := range styleRegistry {
+		if registration.sheet == sheet && registration.window == window {
+			styleRegistry = append(styleRegistry[:idx], styleRegistry[idx+1:]...)
+			styleGeneration++
+			break
+		}
+	}
+	styleLock.Unlock()
+	requestStyleDraw()
+}
+
+// replace the given StyleSheet with another in all of its registrations, for
+// style information to change without modifying a StyleSheet which may be in
+// use by a cascade
+func replaceStyleSheet(sheet, replacement *StyleSheet) {
+	styleLock.Lock()
+	for _, registration := range styleRegistry {
+		if registration.sheet == sheet {
+			registration.sheet = replacement
+		}
+	}
+	styleGeneration++
+	styleLock.Unlock()
+	requestStyleDraw()
+}
+
+// returns the registrations applicable to the Window with the given ObjectID,
+// in order of increasing priority
+func getStyleRegistrations(window int) (registrations []styleRegistration) {
+	styleLock.RLock()
+	defer styleLock.RUnlock()
+	for _, registration := range styleRegistry {
+		if registration.window == -1 || registration.window == window {
+			registrations = append(registrations, *registration)
+		}
+	}
+	return
+}
+
+// flag all computed styles as out of date, for example when the contents of
+// a registered StyleSheet change
+func invalidateStyles() {
+	styleLock.Lock()
+	styleGeneration++
+	styleLock.Unlock()
+	requestStyleDraw()
+}
+
+// GetStyleMedia returns the description of the display that @media conditions
+// are currently evaluated against
+func GetStyleMedia() (media StyleMedia) {
+	styleLock.RLock()
+	defer styleLock.RUnlock()
+	return styleMedia
+}
+
+// SetStyleMedia changes the description of the display that @media conditions
+// are evaluated against, restyling all Widgets if anything changed. Windows
+// update the size and colors of the media each time the display is resized.
+func SetStyleMedia(media StyleMedia) {
+	styleLock.Lock()
+	if media == styleMedia {
+		styleLock.Unlock()
+		return
+	}
+	styleMedia = media
+	styleGeneration++
+	styleLock.Unlock()
+	requestStyleDraw()
+}
+
+// returns the current style generation, incremented each time the set of
+// registered StyleSheets changes
+func getStyleGeneration() uint64 {
+	styleLock.RLock()
+	defer styleLock.RUnlock()
+	return styleGeneration
+}
+
+func requestStyleDraw() {
+	if dm := cdk.GetDisplayManager(); dm != nil {
+		dm.RequestDraw()
+		dm.RequestShow()
+	}
+}
+
+// parse a list of declarations, as found within the braces of a rule
+func parseInlineStyle(declarations string) (properties []*StyleSheetProperty, err error) {
+	if strings.TrimSpace(declarations) == "" {
+		return nil, nil
+	}
+	if !strings.HasSuffix(strings.TrimSpace(declarations), ";") {
+		declarations += ";"
+	}
+	sheet := NewStyleSheet()
+	if err = sheet.ParseString("* {" + declarations + "}"); err != nil {
+		return nil, err
+	}
+	if len(sheet.Rules) != 1 {
+		return nil, fmt.Errorf("invalid inline style: %q", declarations)
+	}
+	return sheet.Rules[0].Properties, nil
+}
+
+// cascade all StyleSheets registered for the given Widget's Window, in order
+// of priority, followed by the inline properties given and then resolve
+// inheritance from the parent style given
+func computeStyle(widget Widget, parent *ComputedStyle, inline []*StyleSheetProperty) (computed *ComputedStyle) {
+	computed = &ComputedStyle{Properties: make(map[string]*StyleSheetProperty)}
+	window := -1
+	if w := widget.GetWindow(); w != nil {
+		window = w.ObjectID()
+	}
+	// StyleSheets of the same priority are cascaded together, by specificity
+	// and then by the order registered
+	var matches []styleSheetMatch
+	colors := make(map[string]string)
+	registrations := getStyleRegistrations(window)
+	for idx, registration := range registrations {
+		if idx > 0 && registration.priority != registrations[idx-1].priority {
+			applyStyleSheetMatches(matches, computed.Properties)
+			matches = nil
+		}
+		matches = append(matches, registration.sheet.matchRules(widget)...)
+		for name, value := range registration.sheet.Colors {
+			colors[name] = value
+		}
+	}
+	applyStyleSheetMatches(matches, computed.Properties)
+	for _, property := range inline {
+		computed.Properties[property.Key] = property
+	}
+	initial := make(map[string]bool)
+	for key, property := range computed.Properties {
+		switch strings.ToLower(property.Value) {
+		case "inherit":
+			if inherited := parent.Get(cdk.Property(key)); inherited != nil {
+				computed.Properties[key] = inherited
+			} else {
+				delete(computed.Properties, key)
+			}
+		case "initial":
+			initial[key] = true
+			delete(computed.Properties, key)
+		}
+	}
+	// custom properties are always inherited
+	if parent != nil {
+		for key, property := range parent.Properties {
+			if _, ok := computed.Properties[key]; !ok && !initial[key] && strings.HasPrefix(key, "--") {
+				computed.Properties[key] = property
+			}
+		}
+	}
+	resolveComputedStyle(computed, colors)
+	for _, name := range inheritedStyleProperties {
+		if _, ok := computed.Properties[string(name)]; !ok && !initial[string(name)] {
+			if inherited := parent.Get(name); inherited != nil {
+				computed.Properties[string(name)] = inherited
+			}
+		}
+	}
+	return
+}
+
+// substitute the var() and @name references of all computed properties,
+// properties referring to undefined names are dropped as if never declared
+func resolveComputedStyle(computed *ComputedStyle, colors map[string]string) {
+	lookup := func(name string) (value string, ok bool) {
+		if property, found := computed.Properties[name]; found {
+			return property.Value, true
+		}
+		return "", false
+	}
+	resolved := make(map[string]*StyleSheetProperty)
+	for key, property := range computed.Properties {
+		if !strings.Contains(property.Value, "var(") && indexStyleColorReference(property.Value, 0) < 0 {
+			continue
+		}
+		if value, err := resolveStyleReferences(property.Value, lookup, colors, 0); err != nil {
+			resolved[key] = nil
+		} else {
+			resolved[key] = &StyleSheetProperty{Key: key, Value: value, Type: property.Type}
+		}
+	}
+	for key, property := range resolved {
+		if property == nil {
+			delete(computed.Properties, key)
+		} else {
+			computed.Properties[key] = property
+		}
+	}
+}
+
+// LookupStyleColor returns the color defined with @define-color by the
+// StyleSheets registered for all Windows, resolved to a cdk.Color.
+func LookupStyleColor(name string) (color cdk.Color, found bool) {
+	colors := make(map[string]string)
+	for _, registration := range getStyleRegistrations(-1) {
+		for defined, value := range registration.sheet.Colors {
+			colors[defined] = value
+		}
+	}
+	value, ok := colors[strings.TrimPrefix(name, "@")]
+	if !ok {
+		return cdk.ColorDefault, false
+	}
+	noVariables := func(string) (string, bool) { return "", false }
+	var err error
+	if value, err = resolveStyleReferences(value, noVariables, colors, 0); err != nil {
+		return cdk.ColorDefault, false
+	}
+	if color, err = parseStyleColor(value); err != nil {
+		return cdk.ColorDefault, false
+	}
+	return color, true
+}
+
+// convert a style sheet value to the type of the given css property
+func parseCssPropertyValue(kind cdk.PropertyType, value string) (parsed interface{}, err error) {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case cdk.BoolProperty:
+		switch strings.ToLower(value) {
+		case "true", "yes", "on", "1":
+			return true, nil
+		case "false", "no", "off", "none", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("invalid boolean value: %q", value)
+	case cdk.IntProperty:
+		return strconv.Atoi(strings.TrimSuffix(value, "px"))
+	case cdk.FloatProperty:
+		return strconv.ParseFloat(value, 64)
+	case cdk.StringProperty:
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted, nil
+		}
+		if len(value) > 1 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			return value[1 : len(value)-1], nil
+		}
+		return value, nil
+	case cdk.ColorProperty:
+		return parseStyleColor(value)
+	}
+	return nil, fmt.Errorf("unsupported css property type: %v", kind)
+}
@@ -0,0 +1,184 @@
+package ctk
+
+import (
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStyleCascade(t *testing.T) {
+	Convey("Testing Style Cascading", t, func() {
+		window := NewWindowWithTitle("test")
+		window.SetName("main")
+		vbox := NewVBox(false, 0)
+		window.Add(vbox)
+		button := NewButtonWithLabel("button")
+		vbox.PackStart(button, false, false, 0)
+		label := NewLabel("label")
+		vbox.PackStart(label, false, false, 0)
+		defaults, application := NewStyleSheet(), NewStyleSheet()
+		So(defaults.ParseString(`
+window#main button { color: blue; bold: true; }
+vbox { color: yellow; dim: true; }
+`), ShouldBeNil)
+		So(application.ParseString(`
+button { color: red; }
+label { dim: initial; }
+`), ShouldBeNil)
+		AddStyleSheet(defaults, STYLE_PROVIDER_PRIORITY_FALLBACK)
+		AddStyleSheet(application, STYLE_PROVIDER_PRIORITY_APPLICATION)
+		Reset(func() {
+			RemoveStyleSheet(defaults)
+			RemoveStyleSheet(application)
+		})
+		Convey("priorities take precedence over specificity", func() {
+			style := button.GetComputedStyle()
+			So(style.Get(PropertyColor).Value, ShouldEqual, "red")
+			So(style.Get(PropertyBold).Value, ShouldEqual, "true")
+			color, err := button.GetCssColor(PropertyColor)
+			So(err, ShouldBeNil)
+			So(color, ShouldEqual, cdk.ColorRed)
+			So(button.SetInlineStyle("color: green"), ShouldBeNil)
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "green")
+			So(button.GetInlineStyle(), ShouldEqual, "color: green")
+		})
+		Convey("the class property is not cascaded", func() {
+			So(label.GetCssProperty(PropertyClass).Set("error"), ShouldBeNil)
+			label.InvalidateStyle()
+			So(label.GetComputedStyle(), ShouldNotBeNil)
+			classes, err := label.GetCssString(PropertyClass)
+			So(err, ShouldBeNil)
+			So(classes, ShouldEqual, "error")
+		})
+		Convey("inheritance", func() {
+			So(button.GetComputedStyle().Get(PropertyDim).Value, ShouldEqual, "true")
+			So(button.GetChild().GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "red")
+			So(label.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "yellow")
+			So(label.GetComputedStyle().Get(PropertyDim), ShouldBeNil)
+			dim, err := label.GetCssBool(PropertyDim)
+			So(err, ShouldBeNil)
+			So(dim, ShouldBeFalse)
+		})
+		Convey("affected subtrees are recomputed", func() {
+			before := label.GetComputedStyle()
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "red")
+			So(label.GetComputedStyle(), ShouldEqual, before)
+			window.SetName("other")
+			So(button.GetComputedStyle().Get(PropertyBold), ShouldBeNil)
+			So(label.GetComputedStyle(), ShouldNotEqual, before)
+			RemoveStyleSheet(application)
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "yellow")
+		})
+		Convey("state pseudo-classes", func() {
+			states := NewStyleSheet()
+			So(states.ParseString(`
+button:hover { color: blue; }
+button:active { bold: false; }
+vbox:hover { underline: true; }
+label:insensitive { dim: true; }
+`), ShouldBeNil)
+			AddStyleSheet(states, STYLE_PROVIDER_PRIORITY_APPLICATION)
+			defer RemoveStyleSheet(states)
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "red")
+			window.updateHoverState(nil, button.GetChild())
+			So(button.HasState(StatePrelight), ShouldBeTrue)
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "blue")
+			So(label.GetComputedStyle().Get(PropertyUnderline).Value, ShouldEqual, "true")
+			button.SetPressed(true)
+			So(button.HasState(StateActive), ShouldBeTrue)
+			So(button.HasState(StatePrelight), ShouldBeTrue)
+			So(button.GetComputedStyle().Get(PropertyBold).Value, ShouldEqual, "false")
+			button.SetPressed(false)
+			So(button.GetComputedStyle().Get(PropertyBold).Value, ShouldEqual, "true")
+			window.updateHoverState(button.GetChild(), label)
+			So(button.HasState(StatePrelight), ShouldBeFalse)
+			So(vbox.HasState(StatePrelight), ShouldBeTrue)
+			So(button.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "red")
+			So(label.GetComputedStyle().Get(PropertyDim), ShouldBeNil)
+			label.SetSensitive(false)
+			So(label.GetComputedStyle().Get(PropertyDim).Value, ShouldEqual, "true")
+		})
+		Convey("style classes", func() {
+			classes := NewStyleSheet()
+			So(classes.ParseString(`
+.destructive-action { color: red; }
+label.error { bold: true; }
+`), ShouldBeNil)
+			AddStyleSheet(classes, STYLE_PROVIDER_PRIORITY_USER)
+			defer RemoveStyleSheet(classes)
+			So(label.ListClasses(), ShouldBeEmpty)
+			label.AddClass("error")
+			label.AddClass("destructive-action")
+			label.AddClass("error")
+			So(label.ListClasses(), ShouldResemble, []string{"error", "destructive-action"})
+			So(label.HasClass("error"), ShouldBeTrue)
+			So(label.CssSelector(), ShouldEndWith, ".error.destructive-action")
+			So(label.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "red")
+			So(label.GetComputedStyle().Get(PropertyBold).Value, ShouldEqual, "true")
+			label.RemoveClass("destructive-action")
+			So(label.HasClass("destructive-action"), ShouldBeFalse)
+			So(label.GetComputedStyle().Get(PropertyColor).Value, ShouldEqual, "yellow")
+			// the class property survives restyling
+			So(label.ListClasses(), ShouldResemble, []string{"error"})
+			builder := NewBuilder()
+			top, err := builder.LoadFromString(`<interface>
+  <object class="GtkLabel" id="classy">
+    <style>
+      <class name="error"/>
+      <class name="big"/>
+    </style>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			So(top.Children, ShouldHaveLength, 1)
+			So(top.Children[0].Classes, ShouldResemble, []string{"error", "big"})
+		})
+		Convey("custom properties and defined colors", func() {
+			previous := GetStyleMedia()
+			defer SetStyleMedia(previous)
+			media := previous
+			media.Colors = StyleMediaTrueColor
+			SetStyleMedia(media)
+			variables := NewStyleSheet()
+			So(variables.ParseString(`
+@define-color base #204060;
+@define-color accent shade(@base, 1.5);
+vbox { --accent: @accent; --border: var(--accent); }
+label { color: var(--accent); background-color: mix(@base, white, 0.25); }
+button { color: var(--missing, darker(@base)); bold: var(--missing); }
+`), ShouldBeNil)
+			So(variables.Colors, ShouldHaveLength, 2)
+			AddStyleSheet(variables, STYLE_PROVIDER_PRIORITY_USER)
+			defer RemoveStyleSheet(variables)
+			style := label.GetComputedStyle()
+			So(style.Get("--accent").Value, ShouldEqual, "shade(#204060, 1.5)")
+			So(style.Get("--border").Value, ShouldEqual, "shade(#204060, 1.5)")
+			color, err := label.GetCssColor(PropertyColor)
+			So(err, ShouldBeNil)
+			So(color, ShouldEqual, shadeStyleColor(cdk.NewRGBColor(0x20, 0x40, 0x60), 1.5))
+			background, err := label.GetCssColor(PropertyBackgroundColor)
+			So(err, ShouldBeNil)
+			So(background, ShouldEqual, cdk.NewRGBColor(0x58, 0x70, 0x88))
+			style = button.GetComputedStyle()
+			So(style.Get(PropertyColor).Value, ShouldEqual, "darker(#204060)")
+			// bold refers to an undefined property and is unset
+			So(style.Get(PropertyBold), ShouldBeNil)
+			found, ok := NewStyle().LookupColor("base")
+			So(ok, ShouldBeTrue)
+			So(found, ShouldEqual, cdk.NewRGBColor(0x20, 0x40, 0x60))
+			_, ok = NewStyle().LookupColor("missing")
+			So(ok, ShouldBeFalse)
+			// only @name references outside of words and strings are colors
+			noVariables := func(string) (string, bool) { return "", false }
+			resolved, err := resolveStyleReferences(`"@base" user@base.org @base`, noVariables, variables.Colors, 0)
+			So(err, ShouldBeNil)
+			So(resolved, ShouldEqual, `"@base" user@base.org #204060`)
+			media.Colors = 16
+			SetStyleMedia(media)
+			background, err = label.GetCssColor(PropertyBackgroundColor)
+			So(err, ShouldBeNil)
+			So(background, ShouldEqual, cdk.ColorGray)
+		})
+	})
+}
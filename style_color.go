@@ -0,0 +1,388 @@
+package ctk
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// the deepest nesting of var() and @name references resolved before a value is
+// considered to be self-referencing
+const styleReferenceDepth = 16
+
+// substitute all var(--name, fallback) and @name references within the given
+// value. Custom properties are looked up with the given function and @name
+// references within the colors defined with @define-color
+func resolveStyleReferences(value string, lookup func(name string) (value string, ok bool), colors map[string]string, depth int) (resolved string, err error) {
+	if depth > styleReferenceDepth {
+		return "", fmt.Errorf("recursive style reference: %q", value)
+	}
+	for {
+		start := strings.Index(value, "var(")
+		if start < 0 {
+			break
+		}
+		end := matchingParenthesis(value, start+3)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated var(): %q", value)
+		}
+		args := splitSelectorList(value[start+4 : end])
+		name := strings.TrimSpace(args[0])
+		if !strings.HasPrefix(name, "--") {
+			return "", fmt.Errorf("invalid custom property name: %q", name)
+		}
+		var substitute string
+		if found, ok := lookup(name); ok {
+			substitute = found
+		} else if len(args) > 1 {
+			substitute = strings.TrimSpace(strings.Join(args[1:], ","))
+		} else {
+			return "", fmt.Errorf("undefined custom property: %v", name)
+		}
+		if substitute, err = resolveStyleReferences(substitute, lookup, colors, depth+1); err != nil {
+			return "", err
+		}
+		value = value[:start] + substitute + value[end+1:]
+	}
+	for from := 0; ; {
+		start := indexStyleColorReference(value, from)
+		if start < 0 {
+			break
+		}
+		end := start + 1
+		for end < len(value) && isStyleNameByte(value[end]) {
+			end++
+		}
+		name := value[start+1 : end]
+		defined, ok := colors[name]
+		if !ok {
+			return "", fmt.Errorf("undefined color: @%v", name)
+		}
+		if defined, err = resolveStyleReferences(defined, lookup, colors, depth+1); err != nil {
+			return "", err
+		}
+		value = value[:start] + defined + value[end:]
+		from = start + len(defined)
+	}
+	return value, nil
+}
+
+// returns the index of the first @name color reference within the value, at
+// or after the given index, or -1. An @ within a quoted string or within a
+// word (as in an email address) does not start a reference.
+func indexStyleColorReference(value string, from int) int {
+	var quote byte
+	for idx := 0; idx < len(value); idx++ {
+		switch c := value[idx]; {
+		case quote != 0:
+			if c == '\\' {
+				idx++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '@' && idx >= from:
+			if (idx == 0 || !isStyleNameByte(value[idx-1])) && idx+1 < len(value) && isStyleNameByte(value[idx+1]) {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+func isStyleNameByte(c byte) bool {
+	return c == '-' || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// returns the index of the parenthesis closing the one at the given index
+func matchingParenthesis(value string, open int) int {
+	depth := 0
+	for i := open; i < len(value); i++ {
+		switch value[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parse a color value, which may be a color name, a hex color such as
+// "#336699" or one of the color functions:
+//
+// 	rgb(r, g, b), rgba(r, g, b, a)     components from 0 to 255
+// 	shade(color, factor)               multiply lightness and saturation
+// 	lighter(color)                     shade(color, 1.3)
+// 	darker(color)                      shade(color, 0.7)
+// 	mix(color1, color2, factor)        blend from color1 to color2
+// 	alpha(color, factor)               terminals have no transparency, a
+// 	                                   factor of 0 is the terminal default
+//
+// The resulting color is degraded to the palette of the current StyleMedia.
+func parseStyleColor(value string) (color cdk.Color, err error) {
+	if color, err = evalStyleColor(strings.TrimSpace(value)); err != nil {
+		return
+	}
+	return degradeStyleColor(color, GetStyleMedia().Colors), nil
+}
+
+func evalStyleColor(value string) (color cdk.Color, err error) {
+	open := strings.IndexByte(value, '(')
+	if open < 0 {
+		name := strings.ToLower(value)
+		if name == "default" || name == "transparent" {
+			return cdk.ColorDefault, nil
+		}
+		if color = cdk.GetColor(name); color == cdk.ColorDefault {
+			return color, fmt.Errorf("invalid color value: %q", value)
+		}
+		return
+	}
+	if matchingParenthesis(value, open) != len(value)-1 {
+		return cdk.ColorDefault, fmt.Errorf("invalid color function: %q", value)
+	}
+	function := strings.ToLower(strings.TrimSpace(value[:open]))
+	args := splitSelectorList(value[open+1 : len(value)-1])
+	for idx := range args {
+		args[idx] = strings.TrimSpace(args[idx])
+	}
+	expect := func(n int) error {
+		if len(args) != n {
+			return fmt.Errorf("%v() takes %d arguments, %d given", function, n, len(args))
+		}
+		return nil
+	}
+	var colors []cdk.Color
+	var factor float64
+	colorArgs := func(n int) error {
+		for _, arg := range args[:n] {
+			if c, err := evalStyleColor(arg); err != nil {
+				return err
+			} else {
+				colors = append(colors, c)
+			}
+		}
+		if n < len(args) {
+			if factor, err = parseStyleFactor(args[n]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	switch function {
+	case "rgb", "rgba":
+		if err = expect(map[string]int{"rgb": 3, "rgba": 4}[function]); err != nil {
+			return
+		}
+		var rgb [3]int32
+		for idx := range rgb {
+			var v float64
+			if v, err = parseStyleFactor(args[idx]); err != nil {
+				return
+			}
+			if strings.HasSuffix(args[idx], "%") {
+				v *= 255
+			}
+			rgb[idx] = clampStyleComponent(v)
+		}
+		if function == "rgba" {
+			if factor, err = parseStyleFactor(args[3]); err != nil {
+				return
+			} else if factor <= 0 {
+				return cdk.ColorDefault, nil
+			}
+		}
+		return cdk.NewRGBColor(rgb[0], rgb[1], rgb[2]), nil
+	case "shade":
+		if err = expect(2); err == nil {
+			if err = colorArgs(1); err == nil {
+				return shadeStyleColor(colors[0], factor), nil
+			}
+		}
+	case "lighter", "darker":
+		if err = expect(1); err == nil {
+			if err = colorArgs(1); err == nil {
+				factor = 1.3
+				if function == "darker" {
+					factor = 0.7
+				}
+				return shadeStyleColor(colors[0], factor), nil
+			}
+		}
+	case "mix":
+		if err = expect(3); err == nil {
+			if err = colorArgs(2); err == nil {
+				return mixStyleColors(colors[0], colors[1], factor), nil
+			}
+		}
+	case "alpha":
+		if err = expect(2); err == nil {
+			if err = colorArgs(1); err == nil {
+				if factor <= 0 {
+					return cdk.ColorDefault, nil
+				}
+				return colors[0], nil
+			}
+		}
+	default:
+		err = fmt.Errorf("unknown color function: %v()", function)
+	}
+	return cdk.ColorDefault, err
+}
+
+// parse a number, or a percentage as a fraction of one
+func parseStyleFactor(value string) (factor float64, err error) {
+	value = strings.TrimSpace(value)
+	if strings.HasSuffix(value, "%") {
+		if factor, err = strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64); err == nil {
+			factor /= 100
+		}
+	} else {
+		factor, err = strconv.ParseFloat(value, 64)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: %q", value)
+	}
+	return
+}
+
+func clampStyleComponent(v float64) int32 {
+	return int32(math.Max(0, math.Min(255, math.Round(v))))
+}
+
+func styleColorComponents(color cdk.Color) (r, g, b float64, ok bool) {
+	if color == cdk.ColorDefault {
+		return 0, 0, 0, false
+	}
+	ir, ig, ib := color.RGB()
+	if ir < 0 || ig < 0 || ib < 0 {
+		return 0, 0, 0, false
+	}
+	return float64(ir) / 255, float64(ig) / 255, float64(ib) / 255, true
+}
+
+// multiply the lightness and saturation of the color by the factor given, as
+// GTK does for the shade() color function
+func shadeStyleColor(color cdk.Color, factor float64) cdk.Color {
+	r, g, b, ok := styleColorComponents(color)
+	if !ok {
+		return color
+	}
+	max, min := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	h, l, s := 0.0, (max+min)/2, 0.0
+	if max != min {
+		delta := max - min
+		if l <= 0.5 {
+			s = delta / (max + min)
+		} else {
+			s = delta / (2 - max - min)
+		}
+		switch max {
+		case r:
+			h = (g - b) / delta
+		case g:
+			h = 2 + (b-r)/delta
+		default:
+			h = 4 + (r-g)/delta
+		}
+		if h *= 60; h < 0 {
+			h += 360
+		}
+	}
+	l = math.Min(1, l*factor)
+	s = math.Min(1, s*factor)
+	if s == 0 {
+		return cdk.NewRGBColor(clampStyleComponent(l*255), clampStyleComponent(l*255), clampStyleComponent(l*255))
+	}
+	var m2 float64
+	if l <= 0.5 {
+		m2 = l * (1 + s)
+	} else {
+		m2 = l + s - l*s
+	}
+	m1 := 2*l - m2
+	hue := func(h float64) float64 {
+		h = math.Mod(h+360, 360)
+		switch {
+		case h < 60:
+			return m1 + (m2-m1)*h/60
+		case h < 180:
+			return m2
+		case h < 240:
+			return m1 + (m2-m1)*(240-h)/60
+		}
+		return m1
+	}
+	return cdk.NewRGBColor(
+		clampStyleComponent(hue(h+120)*255),
+		clampStyleComponent(hue(h)*255),
+		clampStyleComponent(hue(h-120)*255),
+	)
+}
+
+// blend from the first color to the second by the factor given
+func mixStyleColors(first, second cdk.Color, factor float64) cdk.Color {
+	r1, g1, b1, ok1 := styleColorComponents(first)
+	r2, g2, b2, ok2 := styleColorComponents(second)
+	if !ok1 || !ok2 {
+		if factor < 0.5 {
+			return first
+		}
+		return second
+	}
+	factor = math.Max(0, math.Min(1, factor))
+	mix := func(a, b float64) int32 {
+		return clampStyleComponent((a + (b-a)*factor) * 255)
+	}
+	return cdk.NewRGBColor(mix(r1, r2), mix(g1, g2), mix(b1, b2))
+}
+
+// returns the color of the terminal palette with the given number of colors
+// nearest to the color given, truecolor displays use the color as is
+func degradeStyleColor(color cdk.Color, colors int) cdk.Color {
+	if colors >= StyleMediaTrueColor {
+		return color
+	}
+	r, g, b, ok := styleColorComponents(color)
+	if !ok {
+		return color
+	}
+	var palette []cdk.Color
+	switch {
+	case colors <= StyleMediaMonochrome:
+		palette = []cdk.Color{cdk.ColorBlack, cdk.ColorWhite}
+	case colors > 256:
+		colors = 256
+		fallthrough
+	default:
+		for idx := 0; idx < colors; idx++ {
+			palette = append(palette, terminalColor(vtColor(idx)))
+		}
+	}
+	nearest, distance := color, math.MaxFloat64
+	for _, candidate := range palette {
+		if cr, cg, cb, ok := styleColorComponents(candidate); ok {
+			if d := (cr-r)*(cr-r) + (cg-g)*(cg-g) + (cb-b)*(cb-b); d < distance {
+				nearest, distance = candidate, d
+			}
+		}
+	}
+	return nearest
+}
+
+// returns the css value of the given color, "default" being the terminal
+// default color
+func styleColorValue(color cdk.Color) string {
+	r, g, b := color.RGB()
+	if color == cdk.ColorDefault || r < 0 || g < 0 || b < 0 {
+		return "default"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
@@ -0,0 +1,609 @@
+package ctk
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// StyleEngine renders the elements Widgets are drawn with onto a cdk.Canvas.
+// The Paint methods of Style work out the theme for the Widget and state given
+// and delegate to the engine of the current StyleTheme, named with the engine
+// property of the @theme block. Engines are registered by name with
+// RegisterStyleEngine, the built-in engines are "default", "ascii", "rounded"
+// and "heavy". Custom engines may embed a CStyleEngine and override only the
+// elements they draw differently.
+type StyleEngine interface {
+	Name() string
+	PaintArrow(canvas cdk.Canvas, theme cdk.Theme, arrowType ArrowType, fill bool, origin cdk.Point2I, size cdk.Rectangle)
+	PaintBorder(canvas cdk.Canvas, theme cdk.Theme, style BorderStyle, widths StyleSides, fill bool, origin cdk.Point2I, size cdk.Rectangle)
+	PaintBox(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle)
+	PaintBoxGap(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle, gapSide PositionType, gapX int, gapWidth int)
+	PaintCheck(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle)
+	PaintDiamond(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle)
+	PaintExpander(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, expanderStyle ExpanderStyle)
+	PaintExtension(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle, gapSide PositionType)
+	PaintFlatBox(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle)
+	PaintFocus(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle)
+	PaintHandle(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle, orientation cdk.Orientation)
+	PaintHLine(canvas cdk.Canvas, theme cdk.Theme, x1 int, x2 int, y int)
+	PaintOption(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle)
+	PaintPolygon(canvas cdk.Canvas, theme cdk.Theme, points []cdk.Point2I, fill bool)
+	PaintResizeGrip(canvas cdk.Canvas, theme cdk.Theme, edge WindowEdge, origin cdk.Point2I, size cdk.Rectangle)
+	PaintShadow(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle)
+	PaintShadowGap(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle, gapSide PositionType, gapX int, gapWidth int)
+	PaintSlider(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle, orientation cdk.Orientation)
+	PaintSpinner(canvas cdk.Canvas, theme cdk.Theme, step int, origin cdk.Point2I, size cdk.Rectangle)
+	PaintTab(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle)
+	PaintVLine(canvas cdk.Canvas, theme cdk.Theme, y1 int, y2 int, x int)
+}
+
+// StyleGlyphs are the runes a CStyleEngine draws with
+type StyleGlyphs struct {
+	// the runes of each BorderStyle, in the order top-left, top, top-right,
+	// left, right, bottom-left, bottom and bottom-right. Styles not given use
+	// the default runes, single lines being drawn with the cdk.Theme runes
+	Borders map[BorderStyle][8]rune
+	// the arrows pointing up, down, left and right, as ordered by ArrowType.
+	// Arrows not given use the cdk.Theme runes
+	Arrows [4]rune
+	// unchecked, checked and inconsistent check buttons
+	Check [3]string
+	// unselected, selected and inconsistent radio buttons
+	Option [3]string
+	// collapsed and expanded expanders
+	Expander [2]rune
+	Diamond  rune
+	// horizontal and vertical handles
+	Handle [2]rune
+	// resize grips in the north-west, north-east, south-west and south-east
+	Grip [4]rune
+	// the point of a polygon which is neither horizontal nor vertical
+	Point   rune
+	Spinner []rune
+}
+
+// DefaultStyleGlyphs are the glyphs of the default engine, using the cdk.Theme
+// runes for single lines and arrows
+var DefaultStyleGlyphs = StyleGlyphs{
+	Check:    [3]string{"[ ]", "[x]", "[-]"},
+	Option:   [3]string{"( )", "(*)", "(-)"},
+	Expander: [2]rune{'▸', '▾'},
+	Diamond:  '◆',
+	Handle:   [2]rune{HandleBoxHorizontalGrip, HandleBoxVerticalGrip},
+	Grip:     [4]rune{'◤', '◥', '◣', '◢'},
+	Point:    '•',
+	Spinner:  []rune{'⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '⠧', '⠇', '⠏'},
+}
+
+// AsciiStyleGlyphs draw with ASCII characters only, for terminals and fonts
+// lacking line drawing characters
+var AsciiStyleGlyphs = StyleGlyphs{
+	Borders: map[BorderStyle][8]rune{
+		BorderStyleSingle:  styleBorderRunes[BorderStyleAscii],
+		BorderStyleDouble:  {'#', '=', '#', 'H', 'H', '#', '=', '#'},
+		BorderStyleRounded: {'.', '-', '.', '|', '|', '\'', '-', '\''},
+		BorderStyleHeavy:   {'#', '=', '#', 'H', 'H', '#', '=', '#'},
+	},
+	Arrows:   [4]rune{'^', 'v', '<', '>'},
+	Check:    DefaultStyleGlyphs.Check,
+	Option:   DefaultStyleGlyphs.Option,
+	Expander: [2]rune{'>', 'v'},
+	Diamond:  '*',
+	Handle:   [2]rune{'=', 'H'},
+	Grip:     [4]rune{'\\', '/', '/', '\\'},
+	Point:    '*',
+	Spinner:  []rune{'|', '/', '-', '\\'},
+}
+
+// RoundedStyleGlyphs draw single lines with rounded corners
+var RoundedStyleGlyphs = StyleGlyphs{
+	Borders: map[BorderStyle][8]rune{
+		BorderStyleSingle: styleBorderRunes[BorderStyleRounded],
+	},
+	Check:    DefaultStyleGlyphs.Check,
+	Option:   DefaultStyleGlyphs.Option,
+	Expander: DefaultStyleGlyphs.Expander,
+	Diamond:  DefaultStyleGlyphs.Diamond,
+	Handle:   DefaultStyleGlyphs.Handle,
+	Grip:     DefaultStyleGlyphs.Grip,
+	Point:    DefaultStyleGlyphs.Point,
+	Spinner:  DefaultStyleGlyphs.Spinner,
+}
+
+// HeavyStyleGlyphs draw single lines as heavy lines
+var HeavyStyleGlyphs = StyleGlyphs{
+	Borders: map[BorderStyle][8]rune{
+		BorderStyleSingle: styleBorderRunes[BorderStyleHeavy],
+	},
+	Arrows:   [4]rune{'▲', '▼', '◀', '▶'},
+	Check:    DefaultStyleGlyphs.Check,
+	Option:   DefaultStyleGlyphs.Option,
+	Expander: DefaultStyleGlyphs.Expander,
+	Diamond:  DefaultStyleGlyphs.Diamond,
+	Handle:   [2]rune{'━', '┃'},
+	Grip:     DefaultStyleGlyphs.Grip,
+	Point:    DefaultStyleGlyphs.Point,
+	Spinner:  DefaultStyleGlyphs.Spinner,
+}
+
+const (
+	// the name of the engine used when the current theme names none
+	StyleEngineDefault = "default"
+	StyleEngineAscii   = "ascii"
+	StyleEngineRounded = "rounded"
+	StyleEngineHeavy   = "heavy"
+)
+
+var (
+	styleEngines     = make(map[string]StyleEngine)
+	styleEnginesLock = &sync.RWMutex{}
+)
+
+func init() {
+	RegisterStyleEngine(NewStyleEngine(StyleEngineDefault, DefaultStyleGlyphs))
+	RegisterStyleEngine(NewStyleEngine(StyleEngineAscii, AsciiStyleGlyphs))
+	RegisterStyleEngine(NewStyleEngine(StyleEngineRounded, RoundedStyleGlyphs))
+	RegisterStyleEngine(NewStyleEngine(StyleEngineHeavy, HeavyStyleGlyphs))
+}
+
+// RegisterStyleEngine makes the given engine available to themes by name,
+// replacing any engine already registered with the same name.
+func RegisterStyleEngine(engine StyleEngine) {
+	styleEnginesLock.Lock()
+	styleEngines[engine.Name()] = engine
+	styleEnginesLock.Unlock()
+	invalidateStyles()
+}
+
+// GetStyleEngine returns the registered engine with the given name, or nil if
+// there is no such engine.
+func GetStyleEngine(name string) (engine StyleEngine) {
+	styleEnginesLock.RLock()
+	defer styleEnginesLock.RUnlock()
+	return styleEngines[name]
+}
+
+// ListStyleEngines returns the names of all registered engines, sorted.
+func ListStyleEngines() (names []string) {
+	styleEnginesLock.RLock()
+	defer styleEnginesLock.RUnlock()
+	for name := range styleEngines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// GetCurrentStyleEngine returns the engine named by the current StyleTheme, or
+// the default engine if the theme names none or the engine is not registered.
+func GetCurrentStyleEngine() (engine StyleEngine) {
+	if theme := GetCurrentStyleTheme(); theme != nil && theme.Engine != "" {
+		if engine = GetStyleEngine(theme.Engine); engine != nil {
+			return
+		}
+	}
+	return GetStyleEngine(StyleEngineDefault)
+}
+
+// CStyleEngine implements the StyleEngine interface, drawing with a set of
+// StyleGlyphs
+type CStyleEngine struct {
+	name   string
+	glyphs StyleGlyphs
+}
+
+// NewStyleEngine returns a new engine with the given name, drawing with the
+// given glyphs.
+func NewStyleEngine(name string, glyphs StyleGlyphs) *CStyleEngine {
+	return &CStyleEngine{name: name, glyphs: glyphs}
+}
+
+// Name returns the name the engine is registered with
+func (e *CStyleEngine) Name() string {
+	return e.name
+}
+
+// GetGlyphs returns the runes the engine draws with
+func (e *CStyleEngine) GetGlyphs() StyleGlyphs {
+	return e.glyphs
+}
+
+// returns the runes of the given style, plain is TRUE when the runes of the
+// cdk.Theme are to be used
+func (e *CStyleEngine) borderRunes(style BorderStyle) (runes [8]rune, plain bool) {
+	if runes, ok := e.glyphs.Borders[style]; ok {
+		return runes, false
+	}
+	if style == BorderStyleSingle {
+		return styleBorderRunes[style], true
+	}
+	return styleBorderRunes[style], false
+}
+
+// shadows in and out are drawn with single lines and etched shadows with
+// double lines
+func shadowBorderStyle(shadowType ShadowType) BorderStyle {
+	switch shadowType {
+	case SHADOW_IN, SHADOW_OUT:
+		return BorderStyleSingle
+	case SHADOW_ETCHED_IN, SHADOW_ETCHED_OUT:
+		return BorderStyleDouble
+	}
+	return BorderStyleNone
+}
+
+func (e *CStyleEngine) arrowRune(theme cdk.Theme, arrowType ArrowType) (r rune) {
+	if arrowType > ArrowRight {
+		return 0
+	}
+	if r = e.glyphs.Arrows[arrowType]; r != 0 {
+		return
+	}
+	switch arrowType {
+	case ArrowUp:
+		r = theme.Border.ArrowRunes.Up
+	case ArrowDown:
+		r = theme.Border.ArrowRunes.Down
+	case ArrowLeft:
+		r = theme.Border.ArrowRunes.Left
+	case ArrowRight:
+		r = theme.Border.ArrowRunes.Right
+	}
+	return
+}
+
+// draw the given text starting at the given position, clipped to the width
+func paintStyleText(canvas cdk.Canvas, x, y, width int, text string, style cdk.Style) {
+	for _, r := range text {
+		if width <= 0 {
+			return
+		}
+		_ = canvas.SetRune(x, y, r, style)
+		x++
+		width--
+	}
+}
+
+// returns the center of the area given
+func centerOf(origin cdk.Point2I, size cdk.Rectangle) (x, y int) {
+	return origin.X + (size.W-1)/2, origin.Y + (size.H-1)/2
+}
+
+// Draws an arrow pointing in the given direction, centered within the area.
+// Filled and unfilled arrows are drawn the same.
+func (e *CStyleEngine) PaintArrow(canvas cdk.Canvas, theme cdk.Theme, arrowType ArrowType, fill bool, origin cdk.Point2I, size cdk.Rectangle) {
+	if size.W <= 0 || size.H <= 0 {
+		return
+	}
+	if r := e.arrowRune(theme, arrowType); r != 0 {
+		x, y := centerOf(origin, size)
+		_ = canvas.SetRune(x, y, r, theme.Content.Normal)
+	}
+}
+
+// Draws a border of the given style and widths around the edge of the area,
+// filling the area within when requested. Only the outermost line of a border
+// wider than one cell is drawn with runes.
+func (e *CStyleEngine) PaintBorder(canvas cdk.Canvas, theme cdk.Theme, style BorderStyle, widths StyleSides, fill bool, origin cdk.Point2I, size cdk.Rectangle) {
+	if size.W <= 0 || size.H <= 0 {
+		return
+	}
+	if style == BorderStyleNone {
+		widths = StyleSides{}
+	}
+	runes, plain := e.borderRunes(style)
+	// single lines of one cell are drawn with the theme runes
+	plain = plain && widths == MakeStyleSides(1)
+	if fill || plain {
+		canvas.Box(
+			origin,
+			size,
+			plain, fill,
+			theme.Content.Overlay,
+			theme.Content.FillRune,
+			theme.Content.Normal,
+			theme.Border.Normal,
+			theme.Border.BorderRunes,
+		)
+	}
+	if plain || widths == (StyleSides{}) {
+		return
+	}
+	set := func(x, y int, r rune) {
+		_ = canvas.SetRune(origin.X+x, origin.Y+y, r, theme.Border.Normal)
+	}
+	right, bottom := size.W-1, size.H-1
+	for y := 0; y < size.H; y++ {
+		for x := 0; x < size.W; x++ {
+			if y >= widths.Top && x >= widths.Left && y <= bottom-widths.Bottom && x <= right-widths.Right {
+				continue // within the border
+			}
+			onTop, onBottom := y == 0 && widths.Top > 0, y == bottom && widths.Bottom > 0
+			onLeft, onRight := x == 0 && widths.Left > 0, x == right && widths.Right > 0
+			switch {
+			case onTop && onLeft:
+				set(x, y, runes[0])
+			case onTop && onRight:
+				set(x, y, runes[2])
+			case onBottom && onLeft:
+				set(x, y, runes[5])
+			case onBottom && onRight:
+				set(x, y, runes[7])
+			case onTop:
+				set(x, y, runes[1])
+			case onBottom:
+				set(x, y, runes[6])
+			case onLeft:
+				set(x, y, runes[3])
+			case onRight:
+				set(x, y, runes[4])
+			default:
+				set(x, y, ' ')
+			}
+		}
+	}
+}
+
+// Draws a filled box, bordered according to the shadow type.
+func (e *CStyleEngine) PaintBox(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle) {
+	e.PaintBorder(canvas, theme, shadowBorderStyle(shadowType), MakeStyleSides(1), true, origin, size)
+}
+
+// Draws a filled box with a gap in the border of the given side.
+func (e *CStyleEngine) PaintBoxGap(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle, gapSide PositionType, gapX int, gapWidth int) {
+	e.PaintBox(canvas, theme, shadowType, origin, size)
+	e.paintGap(canvas, theme, origin, size, gapSide, gapX, gapWidth)
+}
+
+func (e *CStyleEngine) paintGap(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle, gapSide PositionType, gapX int, gapWidth int) {
+	for i := gapX; i < gapX+gapWidth; i++ {
+		x, y := origin.X+i, origin.Y+i
+		switch gapSide {
+		case POS_TOP:
+			y = origin.Y
+		case POS_BOTTOM:
+			y = origin.Y + size.H - 1
+		case POS_LEFT:
+			x = origin.X
+		case POS_RIGHT:
+			x = origin.X + size.W - 1
+		}
+		if x >= origin.X && x < origin.X+size.W && y >= origin.Y && y < origin.Y+size.H {
+			_ = canvas.SetRune(x, y, ' ', theme.Content.Normal)
+		}
+	}
+}
+
+// returns the index of the glyph for the given shadow type, shadows in are
+// checked and etched shadows in are inconsistent
+func checkGlyphIndex(shadowType ShadowType) int {
+	switch shadowType {
+	case SHADOW_IN:
+		return 1
+	case SHADOW_ETCHED_IN:
+		return 2
+	}
+	return 0
+}
+
+// Draws a check button indicator at the start of the area, vertically
+// centered. A shadow type of SHADOW_IN is checked and SHADOW_ETCHED_IN is
+// inconsistent.
+func (e *CStyleEngine) PaintCheck(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle) {
+	_, y := centerOf(origin, size)
+	paintStyleText(canvas, origin.X, y, size.W, e.glyphs.Check[checkGlyphIndex(shadowType)], theme.Content.Normal)
+}
+
+// Draws a diamond centered within the area.
+func (e *CStyleEngine) PaintDiamond(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle) {
+	if size.W <= 0 || size.H <= 0 {
+		return
+	}
+	x, y := centerOf(origin, size)
+	_ = canvas.SetRune(x, y, e.glyphs.Diamond, theme.Content.Normal)
+}
+
+// Draws an expander at the given position.
+func (e *CStyleEngine) PaintExpander(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, expanderStyle ExpanderStyle) {
+	r := e.glyphs.Expander[0]
+	if expanderStyle >= EXPANDER_SEMI_EXPANDED {
+		r = e.glyphs.Expander[1]
+	}
+	_ = canvas.SetRune(origin.X, origin.Y, r, theme.Content.Normal)
+}
+
+// Draws a filled box attached to its surroundings on the given side, the
+// border of that side is left open between the corners.
+func (e *CStyleEngine) PaintExtension(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle, gapSide PositionType) {
+	length := size.W
+	if gapSide == POS_LEFT || gapSide == POS_RIGHT {
+		length = size.H
+	}
+	e.PaintBoxGap(canvas, theme, shadowType, origin, size, gapSide, 1, length-2)
+}
+
+// Draws a filled box without any border.
+func (e *CStyleEngine) PaintFlatBox(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle) {
+	e.PaintBorder(canvas, theme, BorderStyleNone, StyleSides{}, true, origin, size)
+}
+
+// Draws a single line border around the edge of the area in the focused
+// border style of the theme.
+func (e *CStyleEngine) PaintFocus(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle) {
+	theme.Border.Normal = theme.Border.Focused
+	e.PaintBorder(canvas, theme, BorderStyleSingle, MakeStyleSides(1), false, origin, size)
+}
+
+// Fills the area with the handle glyph of the given orientation.
+func (e *CStyleEngine) PaintHandle(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle, orientation cdk.Orientation) {
+	r := e.glyphs.Handle[0]
+	if orientation == cdk.ORIENTATION_VERTICAL {
+		r = e.glyphs.Handle[1]
+	}
+	for y := origin.Y; y < origin.Y+size.H; y++ {
+		for x := origin.X; x < origin.X+size.W; x++ {
+			_ = canvas.SetRune(x, y, r, theme.Border.Normal)
+		}
+	}
+}
+
+// Draws a horizontal line from x1 to x2, inclusive.
+func (e *CStyleEngine) PaintHLine(canvas cdk.Canvas, theme cdk.Theme, x1 int, x2 int, y int) {
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+	runes, _ := e.borderRunes(BorderStyleSingle)
+	for x := x1; x <= x2; x++ {
+		_ = canvas.SetRune(x, y, runes[1], theme.Border.Normal)
+	}
+}
+
+// Draws a radio button indicator at the start of the area, vertically
+// centered. A shadow type of SHADOW_IN is selected and SHADOW_ETCHED_IN is
+// inconsistent.
+func (e *CStyleEngine) PaintOption(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle) {
+	_, y := centerOf(origin, size)
+	paintStyleText(canvas, origin.X, y, size.W, e.glyphs.Option[checkGlyphIndex(shadowType)], theme.Content.Normal)
+}
+
+// Draws the outline of the polygon through the given points, filling the cells
+// within when requested.
+func (e *CStyleEngine) PaintPolygon(canvas cdk.Canvas, theme cdk.Theme, points []cdk.Point2I, fill bool) {
+	if len(points) == 0 {
+		return
+	}
+	if fill && len(points) > 2 {
+		minY, maxY := points[0].Y, points[0].Y
+		for _, p := range points {
+			if p.Y < minY {
+				minY = p.Y
+			}
+			if p.Y > maxY {
+				maxY = p.Y
+			}
+		}
+		// even-odd scanline fill, sampled at the middle of each row
+		for y := minY; y <= maxY; y++ {
+			var crossings []int
+			for i := range points {
+				a, b := points[i], points[(i+1)%len(points)]
+				if (a.Y <= y) != (b.Y <= y) {
+					crossings = append(crossings, a.X+(y-a.Y)*(b.X-a.X)/(b.Y-a.Y))
+				}
+			}
+			sort.Ints(crossings)
+			for i := 0; i+1 < len(crossings); i += 2 {
+				for x := crossings[i]; x <= crossings[i+1]; x++ {
+					_ = canvas.SetRune(x, y, theme.Content.FillRune, theme.Content.Normal)
+				}
+			}
+		}
+	}
+	runes, _ := e.borderRunes(BorderStyleSingle)
+	line := func(a, b cdk.Point2I) {
+		r := e.glyphs.Point
+		switch {
+		case a.Y == b.Y:
+			r = runes[1]
+		case a.X == b.X:
+			r = runes[3]
+		}
+		dx, dy := b.X-a.X, b.Y-a.Y
+		steps := int(math.Max(math.Abs(float64(dx)), math.Abs(float64(dy))))
+		for i := 0; i <= steps; i++ {
+			x, y := a.X, a.Y
+			if steps > 0 {
+				x += int(math.Round(float64(dx*i) / float64(steps)))
+				y += int(math.Round(float64(dy*i) / float64(steps)))
+			}
+			_ = canvas.SetRune(x, y, r, theme.Border.Normal)
+		}
+	}
+	if len(points) == 1 {
+		line(points[0], points[0])
+		return
+	}
+	for i := range points {
+		line(points[i], points[(i+1)%len(points)])
+	}
+}
+
+// Draws a resize grip in the corner of the area given by the edge, edges other
+// than the corners are not drawn.
+func (e *CStyleEngine) PaintResizeGrip(canvas cdk.Canvas, theme cdk.Theme, edge WindowEdge, origin cdk.Point2I, size cdk.Rectangle) {
+	if size.W <= 0 || size.H <= 0 {
+		return
+	}
+	right, bottom := origin.X+size.W-1, origin.Y+size.H-1
+	switch edge {
+	case WindowEdgeNorthWest:
+		_ = canvas.SetRune(origin.X, origin.Y, e.glyphs.Grip[0], theme.Border.Normal)
+	case WindowEdgeNorthEast:
+		_ = canvas.SetRune(right, origin.Y, e.glyphs.Grip[1], theme.Border.Normal)
+	case WindowEdgeSouthWest:
+		_ = canvas.SetRune(origin.X, bottom, e.glyphs.Grip[2], theme.Border.Normal)
+	case WindowEdgeSouthEast:
+		_ = canvas.SetRune(right, bottom, e.glyphs.Grip[3], theme.Border.Normal)
+	}
+}
+
+// Draws a border according to the shadow type, leaving the area within alone.
+func (e *CStyleEngine) PaintShadow(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle) {
+	e.PaintBorder(canvas, theme, shadowBorderStyle(shadowType), MakeStyleSides(1), false, origin, size)
+}
+
+// Draws a border with a gap in the given side.
+func (e *CStyleEngine) PaintShadowGap(canvas cdk.Canvas, theme cdk.Theme, shadowType ShadowType, origin cdk.Point2I, size cdk.Rectangle, gapSide PositionType, gapX int, gapWidth int) {
+	e.PaintShadow(canvas, theme, shadowType, origin, size)
+	e.paintGap(canvas, theme, origin, size, gapSide, gapX, gapWidth)
+}
+
+// Fills the area of a slider, such as the slider of a Scrollbar.
+func (e *CStyleEngine) PaintSlider(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle, orientation cdk.Orientation) {
+	e.PaintFlatBox(canvas, theme, origin, size)
+}
+
+// Draws the given step of the spinner centered within the area.
+func (e *CStyleEngine) PaintSpinner(canvas cdk.Canvas, theme cdk.Theme, step int, origin cdk.Point2I, size cdk.Rectangle) {
+	if len(e.glyphs.Spinner) == 0 || size.W <= 0 || size.H <= 0 {
+		return
+	}
+	if step < 0 {
+		step = -step
+	}
+	x, y := centerOf(origin, size)
+	_ = canvas.SetRune(x, y, e.glyphs.Spinner[step%len(e.glyphs.Spinner)], theme.Content.Normal)
+}
+
+// Draws the up and down arrows of an option menu, stacked when there is room
+// and side by side otherwise.
+func (e *CStyleEngine) PaintTab(canvas cdk.Canvas, theme cdk.Theme, origin cdk.Point2I, size cdk.Rectangle) {
+	if size.W <= 0 || size.H <= 0 {
+		return
+	}
+	x, y := centerOf(origin, size)
+	up, down := e.arrowRune(theme, ArrowUp), e.arrowRune(theme, ArrowDown)
+	if size.H >= 2 {
+		y = origin.Y + (size.H-2)/2
+		_ = canvas.SetRune(x, y, up, theme.Content.Normal)
+		_ = canvas.SetRune(x, y+1, down, theme.Content.Normal)
+		return
+	}
+	x = origin.X + (size.W-2)/2
+	_ = canvas.SetRune(x, y, up, theme.Content.Normal)
+	_ = canvas.SetRune(x+1, y, down, theme.Content.Normal)
+}
+
+// Draws a vertical line from y1 to y2, inclusive.
+func (e *CStyleEngine) PaintVLine(canvas cdk.Canvas, theme cdk.Theme, y1 int, y2 int, x int) {
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	runes, _ := e.borderRunes(BorderStyleSingle)
+	for y := y1; y <= y2; y++ {
+		_ = canvas.SetRune(x, y, runes[3], theme.Border.Normal)
+	}
+}
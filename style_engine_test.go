@@ -0,0 +1,56 @@
+package ctk
+
+import (
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStyleEngine(t *testing.T) {
+	Convey("Testing Style Engines", t, func() {
+		Convey("built-in engines", func() {
+			So(ListStyleEngines(), ShouldResemble, []string{StyleEngineAscii, StyleEngineDefault, StyleEngineHeavy, StyleEngineRounded})
+			So(GetStyleEngine("missing"), ShouldBeNil)
+			So(GetCurrentStyleEngine().Name(), ShouldEqual, StyleEngineDefault)
+			ascii := GetStyleEngine(StyleEngineAscii).(*CStyleEngine)
+			runes, plain := ascii.borderRunes(BorderStyleSingle)
+			So(plain, ShouldBeFalse)
+			So(runes[0], ShouldEqual, '+')
+			_, plain = GetStyleEngine(StyleEngineDefault).(*CStyleEngine).borderRunes(BorderStyleSingle)
+			So(plain, ShouldBeTrue)
+			So(ascii.arrowRune(cdk.DefaultColorTheme, ArrowLeft), ShouldEqual, '<')
+			So(GetStyleEngine(StyleEngineDefault).(*CStyleEngine).arrowRune(cdk.DefaultColorTheme, ArrowLeft), ShouldEqual, cdk.DefaultColorTheme.Border.ArrowRunes.Left)
+		})
+		Convey("shadow glyphs", func() {
+			So(shadowBorderStyle(SHADOW_NONE), ShouldEqual, BorderStyleNone)
+			So(shadowBorderStyle(SHADOW_OUT), ShouldEqual, BorderStyleSingle)
+			So(shadowBorderStyle(SHADOW_ETCHED_IN), ShouldEqual, BorderStyleDouble)
+			So(checkGlyphIndex(SHADOW_OUT), ShouldEqual, 0)
+			So(checkGlyphIndex(SHADOW_IN), ShouldEqual, 1)
+			So(checkGlyphIndex(SHADOW_ETCHED_IN), ShouldEqual, 2)
+		})
+		Convey("themes select engines", func() {
+			theme, err := ParseStyleTheme(`@theme { name: "AsciiTest"; engine: ASCII; }`)
+			So(err, ShouldBeNil)
+			So(theme.Engine, ShouldEqual, StyleEngineAscii)
+			RegisterStyleTheme(theme)
+			defer func() { So(SwitchTheme(StyleThemeDefault), ShouldBeNil) }()
+			So(SwitchTheme("AsciiTest"), ShouldBeNil)
+			So(GetCurrentStyleEngine().Name(), ShouldEqual, StyleEngineAscii)
+			arrow := NewArrow(ArrowUp)
+			So(arrow.GetStyle(), ShouldNotBeNil)
+			So(arrow.GetStyle().GetEngine().Name(), ShouldEqual, StyleEngineAscii)
+			style := NewStyle()
+			style.SetEngine(GetStyleEngine(StyleEngineHeavy))
+			So(style.GetEngine().Name(), ShouldEqual, StyleEngineHeavy)
+			So(style.Copy().GetEngine().Name(), ShouldEqual, StyleEngineHeavy)
+			style.SetEngine(nil)
+			So(style.GetEngine().Name(), ShouldEqual, StyleEngineAscii)
+			arrow.SetAllocation(cdk.MakeRectangle(3, 1))
+			arrow.Show()
+			canvas := cdk.NewCanvas(cdk.MakePoint2I(0, 0), cdk.MakeRectangle(3, 1), cdk.DefaultMonoTheme.Content.Normal)
+			So(arrow.Draw(canvas), ShouldEqual, cdk.EVENT_STOP)
+		})
+	})
+}
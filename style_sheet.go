@@ -1,11 +1,13 @@
 package ctk
 
-// TODO: support full path traversal
-
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/tdewolff/parse/v2"
 	tcss "github.com/tdewolff/parse/v2/css"
@@ -15,67 +17,179 @@ type StyleSheet struct {
 	Lexer      *tcss.Lexer
 	Rules      []*StyleSheetRule
 	MediaRules []*StyleSheetMedia
+	// colors defined with @define-color, referenced as @name
+	Colors map[string]string
+	// the values declared within an @theme block, describing a StyleTheme
+	Metadata map[string]string
+	// the key bindings defined with @binding-set, see BindingSet
+	BindingSets []*BindingSet
+
+	source   string
+	offset   int
+	consumed int
+	order    int
+}
+
+// StyleSheetError describes a problem found while parsing a StyleSheet, with
+// the line and column (both starting from 1) of the token at fault
+type StyleSheetError struct {
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *StyleSheetError) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("%v:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("%d:%d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *StyleSheetError) Unwrap() error {
+	return e.Err
 }
 
 func NewStyleSheet() *StyleSheet {
-	return &StyleSheet{}
+	return &StyleSheet{
+		Colors:   make(map[string]string),
+		Metadata: make(map[string]string),
+	}
 }
 
 func (s StyleSheet) String() string {
 	str := ""
+	if len(s.Metadata) > 0 {
+		keys := make([]string, 0, len(s.Metadata))
+		for key := range s.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		str += "@theme {"
+		for _, key := range keys {
+			str += key + ":" + strconv.Quote(s.Metadata[key]) + ";"
+		}
+		str += "}\n"
+	}
+	names := make([]string, 0, len(s.Colors))
+	for name := range s.Colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		str += "@define-color " + name + " " + s.Colors[name] + ";\n"
+	}
 	for _, r := range s.Rules {
 		str += r.String() + "\n"
 	}
 	for _, m := range s.MediaRules {
 		str += m.String() + "\n"
 	}
+	for _, b := range s.BindingSets {
+		str += b.String() + "\n"
+	}
 	return str
 }
 
-func (s StyleSheet) SelectProperties(path string) (properties map[string]*StyleSheetProperty) {
-	if index := strings.Index(path, ">"); index > -1 {
-		path = path[index:]
-	}
+// SelectProperties returns the properties of all rules with selectors matching
+// the given Widget. Rules are applied in order of increasing specificity and
+// rules of equal specificity are applied in the order they were defined, so
+// the most specific and latest defined value for each property is returned
+func (s StyleSheet) SelectProperties(widget Widget) (properties map[string]*StyleSheetProperty) {
 	properties = make(map[string]*StyleSheetProperty)
-	selector := ParseSelector(path)
-	for _, r := range s.Rules {
-		rSelect := r.Selector
-		if index := strings.Index(rSelect, ">"); index > -1 {
-			rSelect = rSelect[index:]
+	applyStyleSheetMatches(s.matchRules(widget), properties)
+	return
+}
+
+type styleSheetMatch struct {
+	rule        *StyleSheetRule
+	specificity StyleSheetSpecificity
+}
+
+// returns all rules matching the given Widget, including those of @media
+// blocks matching the current StyleMedia, in the order defined
+func (s StyleSheet) matchRules(widget Widget) (matches []styleSheetMatch) {
+	match := func(rules []*StyleSheetRule) {
+		for _, r := range rules {
+			if specificity, ok := r.Match(widget); ok {
+				matches = append(matches, styleSheetMatch{rule: r, specificity: specificity})
+			}
 		}
-		partSelector := ParseSelector(rSelect)
-		if selector.Match(partSelector) > 0 {
-			for _, elem := range r.Properties {
-				properties[elem.Key] = elem
+	}
+	match(s.Rules)
+	if len(s.MediaRules) > 0 {
+		media := GetStyleMedia()
+		for _, m := range s.MediaRules {
+			if m.Match(media) {
+				match(m.Rules)
 			}
 		}
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].rule.order < matches[j].rule.order
+		})
 	}
 	return
 }
 
+// apply the properties of the rules matched, in order of increasing
+// specificity and then in the order given
+func applyStyleSheetMatches(matches []styleSheetMatch, properties map[string]*StyleSheetProperty) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].specificity.Compare(matches[j].specificity) < 0
+	})
+	for _, m := range matches {
+		for _, elem := range m.rule.Properties {
+			properties[elem.Key] = elem
+		}
+	}
+}
+
+// Parse the given CSS source, appending the rules found to the StyleSheet.
+// Errors returned are of type *StyleSheetError and report the line and column
+// at which the problem was found
 func (s *StyleSheet) ParseString(source string) (err error) {
+	s.source = source
+	s.offset, s.consumed = 0, 0
 	s.Lexer = tcss.NewLexer(parse.NewInput(bytes.NewBufferString(source)))
 	for {
-		tt, data := s.Lexer.Next()
+		tt, data := s.next()
 		switch tt {
 		case tcss.ErrorToken:
-			err = nil
-			return
+			if lerr := s.Lexer.Err(); lerr != nil && lerr != io.EOF {
+				return s.errorAt(lerr)
+			}
+			return nil
 		case tcss.WhitespaceToken:
 			continue // nop
 		case tcss.CommentToken:
 			continue // nop, ignore actual comments
 		case tcss.AtKeywordToken:
-			// data == "@media"
-			if cssMediaRule, err := s.recurseMedia(); err != nil {
-				return err
-			} else {
-				s.MediaRules = append(s.MediaRules, cssMediaRule)
+			switch strings.ToLower(string(data)) {
+			case "@media":
+				if cssMediaRule, err := s.recurseMedia(); err != nil {
+					return s.errorAt(err)
+				} else {
+					s.MediaRules = append(s.MediaRules, cssMediaRule)
+				}
+			case "@define-color":
+				if err := s.recurseDefineColor(); err != nil {
+					return s.errorAt(err)
+				}
+			case "@theme":
+				if err := s.recurseTheme(); err != nil {
+					return s.errorAt(err)
+				}
+			case "@binding-set":
+				if err := s.recurseBindingSet(); err != nil {
+					return s.errorAt(err)
+				}
+			default:
+				return s.errorAt(fmt.Errorf("unsupported at-rule: %v", string(data)))
 			}
 		default:
 			// data == selector
 			if cssRule, err := s.recurseRule(tt, data); err != nil {
-				return err
+				return s.errorAt(err)
 			} else {
 				s.Rules = append(s.Rules, cssRule)
 			}
@@ -83,29 +197,177 @@ func (s *StyleSheet) ParseString(source string) (err error) {
 	}
 }
 
-// consume up to (and including) the first opening bracket
-func (s *StyleSheet) recurseMedia() (mediaRule *StyleSheetMedia, err error) {
-	mediaRule = &StyleSheetMedia{}
-	var ruleMode bool
+// return the next token from the lexer, tracking the offset of the token
+// within the source for error reporting
+func (s *StyleSheet) next() (tt tcss.TokenType, data []byte) {
+	tt, data = s.Lexer.Next()
+	s.offset = s.consumed
+	s.consumed += len(data)
+	return
+}
+
+// wrap the given error with the line and column of the last token read
+func (s *StyleSheet) errorAt(err error) error {
+	if _, ok := err.(*StyleSheetError); ok {
+		return err
+	}
+	offset := s.offset
+	if offset > len(s.source) {
+		offset = len(s.source)
+	}
+	before := s.source[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:]) + 1
+	return &StyleSheetError{Line: line, Column: column, Err: err}
+}
+
+// consume the name and value of a @define-color statement, up to (and
+// including) the terminating semicolon
+func (s *StyleSheet) recurseDefineColor() (err error) {
+	var name, value string
 	for {
-		tt, data := s.Lexer.Next()
+		tt, data := s.next()
 		switch tt {
 		case tcss.ErrorToken:
-			return
-		case tcss.LeftParenthesisToken:
-			mediaRule.Conditions += "("
-			continue // nop
-		case tcss.RightParenthesisToken:
-			mediaRule.Conditions += ")"
+			return fmt.Errorf("recurseDefineColor: unexpected end of input")
+		case tcss.CommentToken:
 			continue // nop
 		case tcss.WhitespaceToken:
+			if len(value) > 0 && !strings.HasSuffix(value, " ") {
+				value += " "
+			}
+			continue
+		case tcss.SemicolonToken:
+			if value = strings.TrimSpace(value); name == "" || value == "" {
+				return fmt.Errorf("recurseDefineColor: expected a name and color")
+			}
+			if s.Colors == nil {
+				s.Colors = make(map[string]string)
+			}
+			s.Colors[name] = value
+			return nil
+		case tcss.IdentToken:
+			if name == "" {
+				name = string(data)
+				continue
+			}
+			value += string(data)
+		case tcss.HashToken, tcss.FunctionToken, tcss.AtKeywordToken, tcss.NumberToken,
+			tcss.PercentageToken, tcss.CommaToken, tcss.RightParenthesisToken:
+			if name == "" {
+				return fmt.Errorf("recurseDefineColor: expected a color name")
+			}
+			value += string(data)
+		default:
+			return fmt.Errorf("recurseDefineColor: unexpected token type: %v (%v)", tt, data)
+		}
+	}
+}
+
+// consume the declarations of an @theme block, up to (and including) the
+// closing curly brace. Quoted values are stored without the quotes
+func (s *StyleSheet) recurseTheme() (err error) {
+	var properties map[string]*StyleSheetProperty
+	if properties, err = s.recurseKeyValues(); err != nil {
+		return
+	}
+	if s.Metadata == nil {
+		s.Metadata = make(map[string]string)
+	}
+	for key, property := range properties {
+		value := property.Value
+		if property.Type == tcss.StringToken {
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			} else if len(value) >= 2 {
+				value = value[1 : len(value)-1]
+			}
+		}
+		s.Metadata[strings.ToLower(key)] = value
+	}
+	return
+}
+
+// consume the name and statements of a @binding-set block, up to (and
+// including) the closing curly brace. The statements are parsed by
+// BindingSet.AddFromString
+func (s *StyleSheet) recurseBindingSet() (err error) {
+	var name string
+	var start, depth int
+	for {
+		tt, data := s.next()
+		switch tt {
+		case tcss.ErrorToken:
+			return fmt.Errorf("recurseBindingSet: unexpected end of input")
+		case tcss.CommentToken, tcss.WhitespaceToken:
 			continue // nop
+		case tcss.IdentToken:
+			if depth == 0 {
+				if name != "" {
+					return fmt.Errorf("recurseBindingSet: unexpected identifier: %v", string(data))
+				}
+				name = string(data)
+			}
+		case tcss.LeftBraceToken:
+			if depth == 0 {
+				if name == "" {
+					return fmt.Errorf("recurseBindingSet: expected a binding set name")
+				}
+				start = s.consumed
+			}
+			depth++
 		case tcss.RightBraceToken:
+			if depth--; depth == 0 {
+				set := newBindingSet(name)
+				if err = set.AddFromString(s.source[start:s.offset]); err != nil {
+					return fmt.Errorf("recurseBindingSet: %v: %v", name, err)
+				}
+				s.BindingSets = append(s.BindingSets, set)
+				return nil
+			}
+		default:
+			if depth == 0 {
+				return fmt.Errorf("recurseBindingSet: unexpected token type: %v (%v)", tt, data)
+			}
+		}
+	}
+}
+
+// consume the conditions and rules of an @media block, up to (and including)
+// the closing curly brace
+func (s *StyleSheet) recurseMedia() (mediaRule *StyleSheetMedia, err error) {
+	mediaRule = &StyleSheetMedia{}
+	start := s.offset
+	var ruleMode bool
+	for {
+		tt, data := s.next()
+		switch tt {
+		case tcss.ErrorToken:
+			return nil, fmt.Errorf("recurseMedia: unexpected end of input")
+		case tcss.CommentToken:
 			continue // nop
+		case tcss.WhitespaceToken:
+			if !ruleMode && len(mediaRule.Conditions) > 0 && !strings.HasSuffix(mediaRule.Conditions, " ") {
+				mediaRule.Conditions += " "
+			}
+			continue
 		case tcss.LeftBraceToken:
+			if ruleMode {
+				return nil, fmt.Errorf("recurseMedia: unexpected opening brace")
+			}
+			mediaRule.Conditions = strings.TrimSpace(mediaRule.Conditions)
+			if mediaRule.Queries, err = parseMediaQueryList(mediaRule.Conditions); err != nil {
+				s.offset = start // report the start of the conditions
+				return nil, err
+			}
 			ruleMode = true
 			continue
-		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.ColonToken, tcss.NumberToken, tcss.DimensionToken, tcss.DelimToken, tcss.IdentToken:
+		case tcss.RightBraceToken:
+			if !ruleMode {
+				return nil, fmt.Errorf("recurseMedia: unexpected closing brace")
+			}
+			return // end of media block
+		default:
 			if ruleMode {
 				if cssRule, err := s.recurseRule(tt, data); err != nil {
 					return nil, err
@@ -113,23 +375,37 @@ func (s *StyleSheet) recurseMedia() (mediaRule *StyleSheetMedia, err error) {
 					mediaRule.Rules = append(mediaRule.Rules, cssRule)
 				}
 			} else {
-				mediaRule.Conditions += string(data)
+				switch tt {
+				case tcss.LeftParenthesisToken, tcss.RightParenthesisToken, tcss.ColonToken,
+					tcss.NumberToken, tcss.DimensionToken, tcss.DelimToken, tcss.IdentToken, tcss.CommaToken:
+					if len(mediaRule.Conditions) == 0 {
+						start = s.offset
+					}
+					mediaRule.Conditions += string(data)
+				default:
+					return nil, fmt.Errorf("recurseMedia: unexpected token type: %v (%v)", tt, data)
+				}
 			}
-			continue // key / value parsing
-		default:
-			return nil, fmt.Errorf("recurseMediaRule: unexpected token type: %v (%v)", tt, data)
+			continue
 		}
 	}
 }
 
 // consume up to (and including) the first opening curly brace
 func (s *StyleSheet) recurseRule(tt tcss.TokenType, data []byte) (cssRule *StyleSheetRule, err error) {
-	cssRule = &StyleSheetRule{}
+	s.order++
+	cssRule = &StyleSheetRule{order: s.order}
+	start := s.offset
 	for {
 		switch tt {
 		case tcss.ErrorToken:
 			return
 		case tcss.LeftBraceToken:
+			cssRule.Selector = strings.TrimSpace(cssRule.Selector)
+			if cssRule.Selectors, err = ParseSelectorList(cssRule.Selector); err != nil {
+				s.offset = start // report the start of the selector
+				return nil, err
+			}
 			if properties, err := s.recurseKeyValues(); err != nil {
 				return nil, err
 			} else {
@@ -140,12 +416,23 @@ func (s *StyleSheet) recurseRule(tt tcss.TokenType, data []byte) (cssRule *Style
 			return
 		case tcss.RightBraceToken:
 			return // end of rule block
-		case tcss.WhitespaceToken:
-			tt, data = s.Lexer.Next()
+		case tcss.CommentToken:
+			tt, data = s.next()
 			continue // nop
-		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.CommentToken, tcss.DelimToken, tcss.HashToken, tcss.ColonToken, tcss.NumberToken, tcss.IdentToken:
+		case tcss.WhitespaceToken:
+			// whitespace is the descendant combinator, collapse to one space
+			if len(cssRule.Selector) > 0 && !strings.HasSuffix(cssRule.Selector, " ") {
+				cssRule.Selector += " "
+			}
+			tt, data = s.next()
+			continue
+		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.LeftParenthesisToken, tcss.RightParenthesisToken,
+			tcss.FunctionToken, tcss.DelimToken, tcss.HashToken, tcss.ColonToken, tcss.CommaToken,
+			tcss.NumberToken, tcss.DimensionToken, tcss.IdentToken, tcss.StringToken,
+			tcss.IncludeMatchToken, tcss.DashMatchToken, tcss.PrefixMatchToken,
+			tcss.SuffixMatchToken, tcss.SubstringMatchToken:
 			cssRule.Selector += string(data)
-			tt, data = s.Lexer.Next()
+			tt, data = s.next()
 			continue // key / value parsing
 		default:
 			return nil, fmt.Errorf("RecurseRule: unexpected token type: %v (%v)", tt, data)
@@ -160,29 +447,50 @@ func (s *StyleSheet) recurseKeyValues() (properties map[string]*StyleSheetProper
 	var key, value string
 	var vType tcss.TokenType
 	var isValue bool
+	store := func() {
+		if key = strings.TrimSpace(key); key != "" {
+			properties[key] = &StyleSheetProperty{
+				Key:   key,
+				Value: strings.TrimSpace(value),
+				Type:  vType,
+			}
+		}
+		key, value = "", ""
+		vType = tcss.ErrorToken
+		isValue = false
+	}
 	for {
-		tt, data := s.Lexer.Next()
+		tt, data := s.next()
 		switch tt {
+		case tcss.ErrorToken:
+			return nil, fmt.Errorf("RecurseKeyValues: unexpected end of input")
 		case tcss.LeftBraceToken:
 			continue // ignore opening braces
 		case tcss.RightBraceToken:
-			return // closing brace completes
+			store() // the last declaration does not require a semicolon
+			return  // closing brace completes
 		case tcss.ColonToken:
+			if isValue {
+				value += string(data)
+				continue
+			}
 			isValue = true
 			continue // colons transition from key to value parsing
 		case tcss.SemicolonToken:
-			isValue = false
-			properties[key] = &StyleSheetProperty{
-				Key:   key,
-				Value: value,
-				Type:  vType,
-			}
-			key, value = "", ""
-			vType = tcss.ErrorToken
+			store()
 			continue // semicolons transition from current pair to new pair
-		case tcss.WhitespaceToken:
+		case tcss.CommentToken:
 			continue // nop
-		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.DelimToken, tcss.DimensionToken, tcss.HashToken, tcss.IdentToken:
+		case tcss.WhitespaceToken:
+			// preserve separators between value components
+			if isValue && len(value) > 0 && !strings.HasSuffix(value, " ") {
+				value += " "
+			}
+			continue
+		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.LeftParenthesisToken, tcss.RightParenthesisToken,
+			tcss.FunctionToken, tcss.CommaToken, tcss.DelimToken, tcss.NumberToken, tcss.PercentageToken,
+			tcss.DimensionToken, tcss.HashToken, tcss.IdentToken, tcss.StringToken,
+			tcss.CustomPropertyNameToken, tcss.AtKeywordToken:
 			if isValue {
 				vType = tt
 				value += string(data)
@@ -1,7 +1,51 @@
 package ctk
 
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// StyleMedia describes the terminal display that @media conditions are
+// evaluated against. Width and Height are measured in cells and Colors is the
+// number of colors the display supports, 0 or 1 being monochrome and 1 << 24
+// being truecolor. ColorScheme is either "light" or "dark".
+type StyleMedia struct {
+	Width       int
+	Height      int
+	Colors      int
+	ColorScheme string
+}
+
+const (
+	// the number of colors at or below which a display is monochrome
+	StyleMediaMonochrome = 2
+	// the number of colors of a truecolor display
+	StyleMediaTrueColor = 1 << 24
+)
+
+// StyleSheetMedia is an @media block, the Rules within only apply when the
+// Conditions match the current StyleMedia. The Conditions are a comma separated
+// list of media queries, any one of which must match:
+//
+// 	@media (min-width: 100) and (color-depth: truecolor), (monochrome) { ... }
+//
+// Supported media types are "all", "screen" and "tty", queries may be negated
+// with "not". Supported media features are:
+//
+// 	width, min-width, max-width        display width in cells
+// 	height, min-height, max-height     display height in cells
+// 	color-depth, min-color-depth,      "monochrome", "16", "256", "truecolor"
+// 	max-color-depth                    or any number of colors
+// 	color, monochrome                  display is (not) capable of color
+// 	prefers-color-scheme               "light" or "dark"
+//
+// Numeric features may also be compared with <, <=, =, >= and >, as in
+// "(width >= 80)".
 type StyleSheetMedia struct {
 	Conditions string
+	Queries    []*StyleMediaQuery
 	Rules      []*StyleSheetRule
 }
 
@@ -15,3 +59,288 @@ func (m StyleSheetMedia) String() string {
 	s += "}"
 	return s
 }
+
+// Match returns TRUE if any of the media queries match the given media, an
+// @media block without conditions always matches
+func (m StyleSheetMedia) Match(media StyleMedia) bool {
+	if len(m.Queries) == 0 {
+		return true
+	}
+	for _, query := range m.Queries {
+		if query.Match(media) {
+			return true
+		}
+	}
+	return false
+}
+
+// StyleMediaQuery is a single media query, an optional media type followed by
+// any number of media features which must all match
+type StyleMediaQuery struct {
+	Not      bool
+	Type     string
+	Features []*StyleMediaFeature
+}
+
+// Match returns TRUE if the media type and all features match the given media
+func (q *StyleMediaQuery) Match(media StyleMedia) (match bool) {
+	switch q.Type {
+	case "", "all", "screen", "tty":
+		match = true
+	}
+	for _, feature := range q.Features {
+		if !match {
+			break
+		}
+		match = feature.Match(media)
+	}
+	if q.Not {
+		return !match
+	}
+	return
+}
+
+func (q *StyleMediaQuery) String() (s string) {
+	var parts []string
+	if q.Type != "" {
+		parts = append(parts, q.Type)
+	}
+	for _, feature := range q.Features {
+		if len(parts) > 0 {
+			parts = append(parts, "and")
+		}
+		parts = append(parts, feature.String())
+	}
+	if q.Not {
+		parts = append([]string{"not"}, parts...)
+	}
+	return strings.Join(parts, " ")
+}
+
+// StyleMediaFeature is a parenthesized media feature such as "(min-width: 80)"
+// or "(monochrome)". Features may also be given in range form such as
+// "(width >= 80)", in which case the Operator is set.
+type StyleMediaFeature struct {
+	Name     string
+	Operator string
+	Value    string
+}
+
+func (f *StyleMediaFeature) String() string {
+	if f.Value == "" {
+		return "(" + f.Name + ")"
+	}
+	if f.Operator != "" {
+		return "(" + f.Name + " " + f.Operator + " " + f.Value + ")"
+	}
+	return "(" + f.Name + ": " + f.Value + ")"
+}
+
+// Match returns TRUE if the feature holds for the given media
+func (f *StyleMediaFeature) Match(media StyleMedia) bool {
+	name, operator := f.Name, f.Operator
+	if operator == "" {
+		operator = "="
+		if strings.HasPrefix(name, "min-") {
+			name, operator = name[4:], ">="
+		} else if strings.HasPrefix(name, "max-") {
+			name, operator = name[4:], "<="
+		}
+	}
+	switch name {
+	case "color":
+		return media.Colors > StyleMediaMonochrome
+	case "monochrome":
+		return media.Colors <= StyleMediaMonochrome
+	case "prefers-color-scheme":
+		return f.Value == "" || strings.ToLower(f.Value) == strings.ToLower(media.ColorScheme)
+	}
+	var actual int
+	switch name {
+	case "width":
+		actual = media.Width
+	case "height":
+		actual = media.Height
+	case "color-depth":
+		actual = media.Colors
+		if actual < StyleMediaMonochrome {
+			actual = StyleMediaMonochrome
+		}
+	}
+	if f.Value == "" {
+		return actual > 0
+	}
+	expected, err := parseMediaValue(name, f.Value)
+	if err != nil {
+		return false
+	}
+	switch operator {
+	case ">=":
+		return actual >= expected
+	case "<=":
+		return actual <= expected
+	case ">":
+		return actual > expected
+	case "<":
+		return actual < expected
+	}
+	return actual == expected
+}
+
+// parse a comma separated list of media queries
+func parseMediaQueryList(conditions string) (queries []*StyleMediaQuery, err error) {
+	conditions = strings.TrimSpace(conditions)
+	if conditions == "" {
+		return nil, nil
+	}
+	for _, text := range splitSelectorList(conditions) {
+		var query *StyleMediaQuery
+		if query, err = parseMediaQuery(text); err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	return
+}
+
+func parseMediaQuery(text string) (query *StyleMediaQuery, err error) {
+	query = &StyleMediaQuery{}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("empty media query")
+	}
+	expectFeature := true
+	for idx := 0; text != ""; idx++ {
+		if text[0] == '(' {
+			if !expectFeature {
+				return nil, fmt.Errorf("expected \"and\" before %q", text)
+			}
+			end := strings.IndexByte(text, ')')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated media feature: %q", text)
+			}
+			var feature *StyleMediaFeature
+			if feature, err = parseMediaFeature(text[1:end]); err != nil {
+				return nil, err
+			}
+			query.Features = append(query.Features, feature)
+			text = strings.TrimSpace(text[end+1:])
+			expectFeature = false
+			continue
+		}
+		word := text
+		if end := strings.IndexAny(text, " ("); end >= 0 {
+			word = text[:end]
+		}
+		text = strings.TrimSpace(text[len(word):])
+		switch word = strings.ToLower(word); {
+		case word == "and" && !expectFeature && (len(query.Features) > 0 || query.Type != ""):
+			expectFeature = true
+		case (word == "not" || word == "only") && idx == 0:
+			query.Not = word == "not"
+		case word != "and" && query.Type == "" && len(query.Features) == 0:
+			query.Type = word
+			expectFeature = false
+		default:
+			return nil, fmt.Errorf("unexpected %q in media query", word)
+		}
+	}
+	if expectFeature && (query.Type != "" || len(query.Features) > 0) {
+		return nil, fmt.Errorf("expected media feature after \"and\"")
+	}
+	if query.Type == "" && len(query.Features) == 0 {
+		return nil, fmt.Errorf("expected media type or feature")
+	}
+	return
+}
+
+func parseMediaFeature(text string) (feature *StyleMediaFeature, err error) {
+	feature = &StyleMediaFeature{}
+	text = strings.TrimSpace(text)
+	if idx := strings.IndexAny(text, "<>="); idx >= 0 {
+		feature.Name = strings.TrimSpace(text[:idx])
+		feature.Operator = text[idx : idx+1]
+		if idx+1 < len(text) && text[idx+1] == '=' && feature.Operator != "=" {
+			feature.Operator += "="
+		}
+		feature.Value = strings.TrimSpace(text[idx+len(feature.Operator):])
+		if strings.HasPrefix(feature.Name, "min-") || strings.HasPrefix(feature.Name, "max-") {
+			return nil, fmt.Errorf("range comparison with %q", feature.Name)
+		}
+	} else if idx := strings.IndexByte(text, ':'); idx >= 0 {
+		feature.Name = strings.TrimSpace(text[:idx])
+		feature.Value = strings.TrimSpace(text[idx+1:])
+	} else {
+		feature.Name = text
+	}
+	feature.Name = strings.ToLower(feature.Name)
+	if feature.Operator != "" && feature.Value == "" {
+		return nil, fmt.Errorf("missing value for media feature: %q", feature.Name)
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(feature.Name, "min-"), "max-")
+	switch name {
+	case "color", "monochrome":
+		if feature.Value != "" || name != feature.Name {
+			return nil, fmt.Errorf("media feature %q does not take a value", feature.Name)
+		}
+	case "prefers-color-scheme":
+		switch strings.ToLower(feature.Value) {
+		case "", "light", "dark":
+		default:
+			return nil, fmt.Errorf("invalid prefers-color-scheme: %q", feature.Value)
+		}
+		if feature.Operator != "" && feature.Operator != "=" {
+			return nil, fmt.Errorf("invalid prefers-color-scheme comparison: %q", feature.Operator)
+		}
+	case "width", "height", "color-depth":
+		if feature.Value == "" && name != feature.Name {
+			return nil, fmt.Errorf("missing value for media feature: %q", feature.Name)
+		}
+		if feature.Value != "" {
+			if _, err = parseMediaValue(name, feature.Value); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown media feature: %q", feature.Name)
+	}
+	return
+}
+
+// parse the value of a numeric media feature, cell sizes may be given with a
+// "ch" unit and color depths by name
+func parseMediaValue(name, value string) (parsed int, err error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if name == "color-depth" {
+		switch value {
+		case "monochrome":
+			return StyleMediaMonochrome, nil
+		case "truecolor":
+			return StyleMediaTrueColor, nil
+		}
+	} else {
+		value = strings.TrimSuffix(value, "ch")
+	}
+	if parsed, err = strconv.Atoi(value); err != nil {
+		return 0, fmt.Errorf("invalid %v media value: %q", name, value)
+	}
+	return
+}
+
+// determine the preferred color scheme from the environment, CTK_COLOR_SCHEME
+// may be set to "light" or "dark" and otherwise the terminal background color
+// given by COLORFGBG is used. Terminals are presumed dark
+func detectColorScheme() string {
+	switch scheme := strings.ToLower(os.Getenv("CTK_COLOR_SCHEME")); scheme {
+	case "light", "dark":
+		return scheme
+	}
+	if fgbg := os.Getenv("COLORFGBG"); fgbg != "" {
+		fields := strings.Split(fgbg, ";")
+		switch fields[len(fields)-1] {
+		case "7", "15":
+			return "light"
+		}
+	}
+	return "dark"
+}
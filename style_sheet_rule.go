@@ -2,7 +2,10 @@ package ctk
 
 type StyleSheetRule struct {
 	Selector   string
+	Selectors  []*StyleSheetSelector
 	Properties []*StyleSheetProperty
+
+	order int // position within the StyleSheet, including @media rules
 }
 
 func (r StyleSheetRule) String() string {
@@ -14,3 +17,17 @@ func (r StyleSheetRule) String() string {
 	s += "}"
 	return s
 }
+
+// Match reports whether any of the selectors of this rule match the given
+// Widget, returning the specificity of the most specific selector matched
+func (r StyleSheetRule) Match(widget Widget) (specificity StyleSheetSpecificity, matched bool) {
+	for _, selector := range r.Selectors {
+		if selector.Match(widget) {
+			if ss := selector.Specificity(); !matched || ss.Compare(specificity) > 0 {
+				specificity = ss
+			}
+			matched = true
+		}
+	}
+	return
+}
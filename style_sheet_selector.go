@@ -1,97 +1,761 @@
 package ctk
 
 import (
-	"regexp"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/kckrinke/go-cdk"
 )
 
+// StyleSheetCombinator describes how a compound selector relates to the
+// compound selector preceding it within a complex selector
+type StyleSheetCombinator uint8
+
+const (
+	// the first compound selector in a complex selector has no combinator
+	CombinatorNone StyleSheetCombinator = iota
+	// "a b", b is a descendant of a
+	CombinatorDescendant
+	// "a > b", b is a direct child of a
+	CombinatorChild
+	// "a + b", b immediately follows a within the same parent
+	CombinatorAdjacent
+	// "a ~ b", b follows a (not necessarily immediately) within the same parent
+	CombinatorSibling
+)
+
+func (c StyleSheetCombinator) String() string {
+	switch c {
+	case CombinatorDescendant:
+		return " "
+	case CombinatorChild:
+		return " > "
+	case CombinatorAdjacent:
+		return " + "
+	case CombinatorSibling:
+		return " ~ "
+	}
+	return ""
+}
+
+// StyleSheetSpecificity is the CSS specificity of a selector, counting the
+// number of ID selectors, class-like selectors (classes, attributes and
+// pseudo-classes) and type selectors, in that order of precedence
+type StyleSheetSpecificity [3]int
+
+// Compare returns -1, 0 or 1 if this specificity is less than, equal to or
+// greater than the other specificity given
+func (s StyleSheetSpecificity) Compare(other StyleSheetSpecificity) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] < other[i] {
+			return -1
+		}
+		if s[i] > other[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+func (s StyleSheetSpecificity) add(other StyleSheetSpecificity) StyleSheetSpecificity {
+	return StyleSheetSpecificity{s[0] + other[0], s[1] + other[1], s[2] + other[2]}
+}
+
+// StyleSheetAttribute is an attribute selector such as [name], [name=value]
+// or [class~=value]. Attribute names are looked up as Widget properties first
+// and CSS properties second
+type StyleSheetAttribute struct {
+	Name            string
+	Operator        string
+	Value           string
+	CaseInsensitive bool
+}
+
+func (a StyleSheetAttribute) String() string {
+	if a.Operator == "" {
+		return "[" + a.Name + "]"
+	}
+	str := "[" + a.Name + a.Operator + strconv.Quote(a.Value)
+	if a.CaseInsensitive {
+		str += " i"
+	}
+	return str + "]"
+}
+
+func (a StyleSheetAttribute) match(value string, present bool) bool {
+	if !present {
+		return false
+	}
+	want := a.Value
+	if a.CaseInsensitive {
+		value = strings.ToLower(value)
+		want = strings.ToLower(want)
+	}
+	switch a.Operator {
+	case "":
+		return true
+	case "=":
+		return value == want
+	case "~=":
+		for _, word := range strings.Fields(value) {
+			if word == want {
+				return true
+			}
+		}
+		return false
+	case "|=":
+		return value == want || strings.HasPrefix(value, want+"-")
+	case "^=":
+		return want != "" && strings.HasPrefix(value, want)
+	case "$=":
+		return want != "" && strings.HasSuffix(value, want)
+	case "*=":
+		return want != "" && strings.Contains(value, want)
+	}
+	return false
+}
+
+// StyleSheetPseudoClass is a pseudo-class such as :hover, :first-child,
+// :nth-child(2n+1) or :not(.class). The state pseudo-classes :hover
+// (:prelight), :active, :selected, :insensitive (:disabled) and :focus match
+// the current StateType flags and focus of the Widget, :default matches the
+// default widget of a Window
+type StyleSheetPseudoClass struct {
+	Name     string
+	Argument string
+	// selectors negated by :not()
+	Not []*StyleSheetSelector
+	// the An+B arguments of the :nth-* pseudo-classes
+	A, B int
+}
+
+func (p StyleSheetPseudoClass) String() string {
+	if p.Argument != "" {
+		return ":" + p.Name + "(" + p.Argument + ")"
+	}
+	return ":" + p.Name
+}
+
+// StyleSheetSelector is a single complex selector, for example
+// "window#main > vbox button.primary:focus". The selector itself is the
+// right-most compound selector and Previous links to the compound selector to
+// the left of it, related by Combinator
 type StyleSheetSelector struct {
-	Name  string
-	Type  string
-	Class string
-	State string
+	// the type selector, empty or "*" for any type
+	Type string
+	// the ID selector, matched against the Widget name
+	Name       string
+	Classes    []string
+	Attributes []*StyleSheetAttribute
+	Pseudo     []*StyleSheetPseudoClass
+
+	Combinator StyleSheetCombinator
+	Previous   *StyleSheetSelector
 }
 
 func (s StyleSheetSelector) String() string {
 	str := ""
-	if len(s.Type) > 0 {
-		str += s.Type
+	if s.Previous != nil {
+		str = s.Previous.String() + s.Combinator.String()
 	}
+	str += s.compoundString()
+	return str
+}
+
+func (s StyleSheetSelector) compoundString() string {
+	str := s.Type
 	if len(s.Name) > 0 {
 		str += "#" + s.Name
 	}
-	if len(s.Class) > 0 {
-		str += "." + s.Class
+	for _, class := range s.Classes {
+		str += "." + class
+	}
+	for _, attr := range s.Attributes {
+		str += attr.String()
 	}
-	if len(s.State) > 0 {
-		str += ":" + s.State
+	for _, pseudo := range s.Pseudo {
+		str += pseudo.String()
+	}
+	if str == "" {
+		str = "*"
 	}
 	return str
 }
 
-func (s StyleSheetSelector) Match(selector *StyleSheetSelector) (score int) {
-	if len(s.Type) > 0 && len(selector.Type) > 0 {
-		if s.Type == selector.Type {
-			score += 1
+// Specificity returns the CSS specificity of the entire complex selector
+func (s StyleSheetSelector) Specificity() (specificity StyleSheetSpecificity) {
+	if s.Name != "" {
+		specificity[0]++
+	}
+	specificity[1] += len(s.Classes) + len(s.Attributes)
+	if s.Type != "" && s.Type != "*" {
+		specificity[2]++
+	}
+	for _, pseudo := range s.Pseudo {
+		if pseudo.Name == "not" {
+			// :not() takes the specificity of its most specific argument
+			var most StyleSheetSpecificity
+			for _, not := range pseudo.Not {
+				if ns := not.Specificity(); ns.Compare(most) > 0 {
+					most = ns
+				}
+			}
+			specificity = specificity.add(most)
+			continue
+		}
+		specificity[1]++
+	}
+	if s.Previous != nil {
+		specificity = specificity.add(s.Previous.Specificity())
+	}
+	return
+}
+
+// Match reports whether the given Widget is matched by this selector, walking
+// the live Widget hierarchy from right to left for any combinators present
+func (s *StyleSheetSelector) Match(widget Widget) bool {
+	if widget == nil || !s.matchCompound(widget) {
+		return false
+	}
+	if s.Previous == nil {
+		return true
+	}
+	switch s.Combinator {
+	case CombinatorChild:
+		if parent := selectorParent(widget); parent != nil {
+			return s.Previous.Match(parent)
+		}
+	case CombinatorDescendant:
+		for parent := selectorParent(widget); parent != nil; parent = selectorParent(parent) {
+			if s.Previous.Match(parent) {
+				return true
+			}
 		}
+	case CombinatorAdjacent:
+		siblings, index := selectorSiblings(widget)
+		if index > 0 {
+			return s.Previous.Match(siblings[index-1])
+		}
+	case CombinatorSibling:
+		siblings, index := selectorSiblings(widget)
+		for i := index - 1; i >= 0; i-- {
+			if s.Previous.Match(siblings[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *StyleSheetSelector) matchCompound(widget Widget) bool {
+	if s.Type != "" && s.Type != "*" && !selectorTypeMatch(s.Type, widget) {
+		return false
 	}
-	if len(s.Name) > 0 && len(selector.Name) > 0 {
-		if s.Name == selector.Name {
-			score += 1
-		} else {
-			score -= 1
+	if s.Name != "" && widget.GetName() != s.Name {
+		return false
+	}
+	if len(s.Classes) > 0 {
+		classes := selectorClasses(widget)
+		for _, class := range s.Classes {
+			found := false
+			for _, have := range classes {
+				if have == class {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
 		}
 	}
-	if len(s.Class) > 0 && len(selector.Class) > 0 {
-		if s.Class == selector.Class {
-			score += 1
+	for _, attr := range s.Attributes {
+		value, present := selectorAttribute(widget, attr.Name)
+		if !attr.match(value, present) {
+			return false
 		}
 	}
-	if len(s.State) > 0 && len(selector.State) > 0 {
-		if s.State == selector.State {
-			score += 1
-		} else {
-			score -= 1
+	for _, pseudo := range s.Pseudo {
+		if !s.matchPseudo(pseudo, widget) {
+			return false
 		}
-	} else {
-		if len(s.State) > 0 || len(selector.State) > 0 {
-			score -= 1
+	}
+	return true
+}
+
+func (s *StyleSheetSelector) matchPseudo(pseudo *StyleSheetPseudoClass, widget Widget) bool {
+	switch pseudo.Name {
+	case "not":
+		for _, not := range pseudo.Not {
+			if not.Match(widget) {
+				return false
+			}
 		}
+		return true
+	case "first-child":
+		_, index := selectorSiblings(widget)
+		return index == 0
+	case "last-child":
+		siblings, index := selectorSiblings(widget)
+		return index > -1 && index == len(siblings)-1
+	case "only-child":
+		siblings, index := selectorSiblings(widget)
+		return index == 0 && len(siblings) == 1
+	case "nth-child":
+		_, index := selectorSiblings(widget)
+		return index > -1 && nthMatch(pseudo.A, pseudo.B, index+1)
+	case "nth-last-child":
+		siblings, index := selectorSiblings(widget)
+		return index > -1 && nthMatch(pseudo.A, pseudo.B, len(siblings)-index)
+	case "focus":
+		return widget.IsFocus()
+	case "default":
+		return widget.HasDefault()
+	case "hover", "prelight":
+		return widget.HasState(StatePrelight)
+	case "active":
+		return widget.HasState(StateActive)
+	case "selected":
+		return widget.HasState(StateSelected)
+	case "insensitive", "disabled":
+		return !widget.IsSensitive()
+	}
+	return false
+}
+
+// ParseSelector parses a single complex selector, returning an error if the
+// text given is not a valid selector. Use ParseSelectorList for selector
+// lists separated by commas
+func ParseSelector(text string) (selector *StyleSheetSelector, err error) {
+	p := &selectorParser{src: []rune(text)}
+	if selector, err = p.parseComplex(); err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.peek())
 	}
 	return
 }
 
-var (
-	rxSelectorName  = regexp.MustCompile(`#([a-zA-Z][-_a-zA-Z0-9]+)`)
-	rxSelectorClass = regexp.MustCompile(`\.([a-zA-Z][-_a-zA-Z0-9]+)`)
-	rxSelectorState = regexp.MustCompile(`:([a-zA-Z][-_a-zA-Z0-9]+)`)
-)
+// ParseSelectorList parses a comma separated list of complex selectors
+func ParseSelectorList(text string) (selectors []*StyleSheetSelector, err error) {
+	for _, part := range splitSelectorList(text) {
+		var selector *StyleSheetSelector
+		if selector, err = ParseSelector(part); err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+	if len(selectors) == 0 {
+		err = fmt.Errorf("empty selector")
+	}
+	return
+}
 
-func ParseSelector(path string) (selector *StyleSheetSelector) {
+// split on commas that are not nested within brackets, parentheses or quotes
+func splitSelectorList(text string) (parts []string) {
+	depth, start := 0, 0
+	var quote rune
+	runes := []rune(text)
+	for i, r := range runes {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, string(runes[start:i]))
+			start = i + 1
+		}
+	}
+	parts = append(parts, string(runes[start:]))
+	return
+}
+
+type selectorParser struct {
+	src []rune
+	pos int
+}
+
+func (p *selectorParser) errorf(format string, argv ...interface{}) error {
+	return fmt.Errorf("selector %q, offset %d: %v", string(p.src), p.pos, fmt.Sprintf(format, argv...))
+}
+
+func (p *selectorParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *selectorParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *selectorParser) skipSpace() (skipped bool) {
+	for !p.eof() && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+		skipped = true
+	}
+	return
+}
+
+func (p *selectorParser) parseComplex() (selector *StyleSheetSelector, err error) {
+	p.skipSpace()
+	for {
+		combinator := CombinatorNone
+		spaced := p.skipSpace()
+		if p.eof() || p.peek() == ')' || p.peek() == ',' {
+			if selector == nil {
+				return nil, p.errorf("empty selector")
+			}
+			return
+		}
+		switch p.peek() {
+		case '>':
+			combinator = CombinatorChild
+		case '+':
+			combinator = CombinatorAdjacent
+		case '~':
+			combinator = CombinatorSibling
+		}
+		if combinator != CombinatorNone {
+			if selector == nil {
+				return nil, p.errorf("selector begins with combinator %q", p.peek())
+			}
+			p.pos++
+			p.skipSpace()
+			if p.eof() {
+				return nil, p.errorf("selector ends with a combinator")
+			}
+		} else if selector != nil {
+			if !spaced {
+				return nil, p.errorf("unexpected %q", p.peek())
+			}
+			combinator = CombinatorDescendant
+		}
+		var compound *StyleSheetSelector
+		if compound, err = p.parseCompound(); err != nil {
+			return nil, err
+		}
+		if selector != nil {
+			compound.Combinator = combinator
+			compound.Previous = selector
+		}
+		selector = compound
+	}
+}
+
+func (p *selectorParser) parseCompound() (selector *StyleSheetSelector, err error) {
 	selector = &StyleSheetSelector{}
-	altered := path
-	if rxSelectorName.MatchString(path) {
-		m := rxSelectorName.FindStringSubmatch(path)
-		if len(m) > 1 {
-			selector.Name = m[1]
-			altered = rxSelectorName.ReplaceAllString(altered, "")
+	start := p.pos
+	if p.peek() == '*' {
+		p.pos++
+		selector.Type = "*"
+	} else if isSelectorIdentRune(p.peek()) {
+		selector.Type = p.parseIdent()
+	}
+	for !p.eof() {
+		switch p.peek() {
+		case '#':
+			p.pos++
+			name := p.parseIdent()
+			if name == "" {
+				return nil, p.errorf("expected a name after '#'")
+			}
+			if selector.Name != "" && selector.Name != name {
+				return nil, p.errorf("conflicting names %q and %q", selector.Name, name)
+			}
+			selector.Name = name
+		case '.':
+			p.pos++
+			class := p.parseIdent()
+			if class == "" {
+				return nil, p.errorf("expected a class after '.'")
+			}
+			selector.Classes = append(selector.Classes, class)
+		case '[':
+			p.pos++
+			var attr *StyleSheetAttribute
+			if attr, err = p.parseAttribute(); err != nil {
+				return nil, err
+			}
+			selector.Attributes = append(selector.Attributes, attr)
+		case ':':
+			p.pos++
+			var pseudo *StyleSheetPseudoClass
+			if pseudo, err = p.parsePseudo(); err != nil {
+				return nil, err
+			}
+			selector.Pseudo = append(selector.Pseudo, pseudo)
+		default:
+			if p.pos == start {
+				return nil, p.errorf("unexpected %q", p.peek())
+			}
+			return
 		}
 	}
-	if rxSelectorClass.MatchString(path) {
-		m := rxSelectorClass.FindStringSubmatch(path)
-		if len(m) > 1 {
-			selector.Class = m[1]
-			altered = rxSelectorClass.ReplaceAllString(altered, "")
+	return
+}
+
+func (p *selectorParser) parseAttribute() (attr *StyleSheetAttribute, err error) {
+	attr = &StyleSheetAttribute{}
+	p.skipSpace()
+	if attr.Name = p.parseIdent(); attr.Name == "" {
+		return nil, p.errorf("expected an attribute name")
+	}
+	p.skipSpace()
+	if p.peek() == ']' {
+		p.pos++
+		return
+	}
+	switch r := p.peek(); r {
+	case '=':
+		attr.Operator = "="
+		p.pos++
+	case '~', '|', '^', '$', '*':
+		if p.pos+1 >= len(p.src) || p.src[p.pos+1] != '=' {
+			return nil, p.errorf("invalid attribute operator")
 		}
+		attr.Operator = string(r) + "="
+		p.pos += 2
+	default:
+		return nil, p.errorf("invalid attribute operator")
 	}
-	if rxSelectorState.MatchString(path) {
-		m := rxSelectorState.FindStringSubmatch(path)
-		if len(m) > 1 {
-			selector.State = m[1]
-			altered = rxSelectorState.ReplaceAllString(altered, "")
+	p.skipSpace()
+	switch r := p.peek(); r {
+	case '"', '\'':
+		p.pos++
+		end := p.pos
+		for end < len(p.src) && p.src[end] != r {
+			end++
+		}
+		if end >= len(p.src) {
+			return nil, p.errorf("unterminated attribute value")
 		}
+		attr.Value = string(p.src[p.pos:end])
+		p.pos = end + 1
+	default:
+		if attr.Value = p.parseIdent(); attr.Value == "" {
+			return nil, p.errorf("expected an attribute value")
+		}
+	}
+	p.skipSpace()
+	if r := p.peek(); r == 'i' || r == 'I' {
+		attr.CaseInsensitive = true
+		p.pos++
+		p.skipSpace()
 	}
-	if len(altered) > 0 {
-		selector.Type = altered
+	if p.peek() != ']' {
+		return nil, p.errorf("expected ']'")
 	}
+	p.pos++
 	return
 }
+
+func (p *selectorParser) parsePseudo() (pseudo *StyleSheetPseudoClass, err error) {
+	if p.peek() == ':' {
+		return nil, p.errorf("pseudo-elements are not supported")
+	}
+	pseudo = &StyleSheetPseudoClass{Name: strings.ToLower(p.parseIdent())}
+	if pseudo.Name == "" {
+		return nil, p.errorf("expected a pseudo-class after ':'")
+	}
+	switch pseudo.Name {
+	case "not":
+		if p.peek() != '(' {
+			return nil, p.errorf(":not requires an argument")
+		}
+		p.pos++
+		start := p.pos
+		for {
+			var not *StyleSheetSelector
+			if not, err = p.parseComplex(); err != nil {
+				return nil, err
+			}
+			pseudo.Not = append(pseudo.Not, not)
+			p.skipSpace()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("expected ')'")
+		}
+		pseudo.Argument = strings.TrimSpace(string(p.src[start:p.pos]))
+		p.pos++
+	case "nth-child", "nth-last-child":
+		if p.peek() != '(' {
+			return nil, p.errorf(":%v requires an argument", pseudo.Name)
+		}
+		p.pos++
+		start := p.pos
+		for !p.eof() && p.peek() != ')' {
+			p.pos++
+		}
+		if p.eof() {
+			return nil, p.errorf("expected ')'")
+		}
+		pseudo.Argument = strings.TrimSpace(string(p.src[start:p.pos]))
+		p.pos++
+		if pseudo.A, pseudo.B, err = parseNth(pseudo.Argument); err != nil {
+			return nil, p.errorf("%v", err)
+		}
+	case "first-child", "last-child", "only-child":
+	case "focus", "default", "hover", "prelight", "active", "selected", "insensitive", "disabled":
+	default:
+		return nil, p.errorf("unsupported pseudo-class :%v", pseudo.Name)
+	}
+	return
+}
+
+func (p *selectorParser) parseIdent() string {
+	start := p.pos
+	for !p.eof() && isSelectorIdentRune(p.peek()) {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+func isSelectorIdentRune(r rune) bool {
+	return r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || r > unicode.MaxASCII
+}
+
+// parse the An+B notation used by :nth-child() and friends
+func parseNth(text string) (a, b int, err error) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), ""))
+	switch text {
+	case "odd":
+		return 2, 1, nil
+	case "even":
+		return 2, 0, nil
+	case "":
+		return 0, 0, fmt.Errorf("empty An+B expression")
+	}
+	if index := strings.Index(text, "n"); index > -1 {
+		switch coefficient := text[:index]; coefficient {
+		case "", "+":
+			a = 1
+		case "-":
+			a = -1
+		default:
+			if a, err = strconv.Atoi(coefficient); err != nil {
+				return 0, 0, fmt.Errorf("invalid An+B expression: %q", text)
+			}
+		}
+		if offset := text[index+1:]; offset != "" {
+			if offset[0] != '+' && offset[0] != '-' {
+				return 0, 0, fmt.Errorf("invalid An+B expression: %q", text)
+			}
+			if b, err = strconv.Atoi(offset); err != nil {
+				return 0, 0, fmt.Errorf("invalid An+B expression: %q", text)
+			}
+		}
+		return
+	}
+	if b, err = strconv.Atoi(text); err != nil {
+		return 0, 0, fmt.Errorf("invalid An+B expression: %q", text)
+	}
+	return
+}
+
+// report whether the 1-based position matches An+B for some n >= 0
+func nthMatch(a, b, position int) bool {
+	if a == 0 {
+		return position == b
+	}
+	delta := position - b
+	return delta%a == 0 && delta/a >= 0
+}
+
+// returns the parent of the given Widget, or nil for toplevel Widgets (which
+// are their own parent)
+func selectorParent(widget Widget) Widget {
+	parent := widget.GetParent()
+	if parent == nil || parent.ObjectID() == widget.ObjectID() {
+		return nil
+	}
+	return parent
+}
+
+// returns the children of the given Widget's parent, along with the index of
+// the Widget within them, or -1 if the Widget has no parent
+func selectorSiblings(widget Widget) (siblings []Widget, index int) {
+	if parent := selectorParent(widget); parent != nil {
+		if container, ok := parent.(Container); ok {
+			siblings = container.GetChildren()
+			for i, sibling := range siblings {
+				if sibling.ObjectID() == widget.ObjectID() {
+					return siblings, i
+				}
+			}
+		}
+	}
+	return nil, -1
+}
+
+// type selectors match the type tag ("ctk-button"), the tag without the
+// "ctk-" prefix ("button"), the class name ("Button") or the GTK class name
+// ("GtkButton"), ignoring case, dashes and underscores
+func selectorTypeMatch(name string, widget Widget) bool {
+	want := normalizeSelectorType(name)
+	tt := widget.GetTypeTag()
+	return want == normalizeSelectorType(tt.String()) ||
+		want == normalizeSelectorType(tt.ClassName())
+}
+
+func normalizeSelectorType(name string) string {
+	name = strings.ToLower(name)
+	name = strings.TrimPrefix(name, "ctk-")
+	name = strings.TrimPrefix(name, "gtk")
+	name = strings.ReplaceAll(name, "-", "")
+	return strings.ReplaceAll(name, "_", "")
+}
+
+// returns the style classes of the given Widget
+func selectorClasses(widget Widget) []string {
+	if classes, err := widget.GetCssString(PropertyClass); err == nil {
+		return strings.Fields(classes)
+	}
+	return nil
+}
+
+// look up the value of an attribute selector, "name" is the Widget name and
+// any other attribute is looked up as a Widget property and then as a CSS
+// property
+func selectorAttribute(widget Widget, name string) (value string, present bool) {
+	if name == "name" || name == "id" {
+		value = widget.GetName()
+		return value, value != ""
+	}
+	property := cdk.Property(name)
+	if v, err := widget.GetStringProperty(property); err == nil {
+		return v, true
+	}
+	if v, err := widget.GetBoolProperty(property); err == nil {
+		return strconv.FormatBool(v), true
+	}
+	if v, err := widget.GetIntProperty(property); err == nil {
+		return strconv.Itoa(v), true
+	}
+	if v, err := widget.GetFloatProperty(property); err == nil {
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	if css := widget.GetCssProperty(property); css != nil {
+		if v := css.Value(); v != nil {
+			return fmt.Sprintf("%v", v), true
+		}
+		return fmt.Sprintf("%v", css.Default()), true
+	}
+	return "", false
+}
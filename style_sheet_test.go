@@ -0,0 +1,131 @@
+package ctk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStyleSheet(t *testing.T) {
+	Convey("Testing StyleSheets", t, func() {
+		Convey("selector parsing", func() {
+			selector, err := ParseSelector("window#main > vbox  button.primary.big:focus")
+			So(err, ShouldBeNil)
+			So(selector.String(), ShouldEqual, "window#main > vbox button.primary.big:focus")
+			So(selector.Specificity(), ShouldResemble, StyleSheetSpecificity{1, 3, 3})
+			selector, err = ParseSelector("label:not(.a, #b)[xalign^='0']")
+			So(err, ShouldBeNil)
+			So(selector.Specificity(), ShouldResemble, StyleSheetSpecificity{1, 1, 1})
+			selectors, err := ParseSelectorList("a + b, c ~ d:nth-child(2n+1)")
+			So(err, ShouldBeNil)
+			So(selectors, ShouldHaveLength, 2)
+			So(selectors[1].Pseudo[0].A, ShouldEqual, 2)
+			So(selectors[1].Pseudo[0].B, ShouldEqual, 1)
+			for _, bad := range []string{"", "> a", "a >", "a::before", "a:bogus", "[a=", "a..b"} {
+				_, err = ParseSelector(bad)
+				So(err, ShouldNotBeNil)
+			}
+		})
+		Convey("matching the widget hierarchy", func() {
+			window := NewWindowWithTitle("test")
+			window.SetName("main")
+			vbox := NewVBox(false, 0)
+			window.Add(vbox)
+			first := NewButtonWithLabel("first")
+			_ = first.GetCssProperty(PropertyClass).Set("primary big")
+			second := NewButtonWithLabel("second")
+			second.SetName("second")
+			label := NewLabel("text")
+			vbox.PackStart(first, false, false, 0)
+			vbox.PackStart(second, false, false, 0)
+			vbox.PackStart(label, false, false, 0)
+			match := func(text string, widget Widget) bool {
+				selector, err := ParseSelector(text)
+				So(err, ShouldBeNil)
+				return selector.Match(widget)
+			}
+			So(match("window#main > vbox button", first), ShouldBeTrue)
+			So(match("window#main > button", first), ShouldBeFalse)
+			So(match("GtkButton.primary.big", first), ShouldBeTrue)
+			So(match("button.primary.small", first), ShouldBeFalse)
+			So(match("button + button", second), ShouldBeTrue)
+			So(match("button + button", first), ShouldBeFalse)
+			So(match("button ~ label", label), ShouldBeTrue)
+			So(match("vbox > :nth-child(2)", second), ShouldBeTrue)
+			So(match(":nth-last-child(-n+2)", first), ShouldBeFalse)
+			So(match("button:not(.primary)", second), ShouldBeTrue)
+			So(match("button:not(.primary, #second)", second), ShouldBeFalse)
+			So(match("button:first-child", first), ShouldBeTrue)
+			So(match("label:last-child", label), ShouldBeTrue)
+			So(match("[class~=big]", first), ShouldBeTrue)
+			So(match("[name|=sec]", second), ShouldBeFalse)
+			So(match("[name^=sec]", second), ShouldBeTrue)
+		})
+		Convey("specificity ordering", func() {
+			window := NewWindowWithTitle("test")
+			window.SetName("main")
+			button := NewButtonWithLabel("button")
+			window.Add(button)
+			ss := NewStyleSheet()
+			err := ss.ParseString(`
+window#main button { color: red; bold: true; }
+button { color: blue; dim: true; }
+window button, label { color: green; dim: false; }
+`)
+			So(err, ShouldBeNil)
+			So(ss.Rules, ShouldHaveLength, 3)
+			So(ss.Rules[2].Selectors, ShouldHaveLength, 2)
+			properties := ss.SelectProperties(button)
+			So(properties["color"].Value, ShouldEqual, "red")
+			So(properties["bold"].Value, ShouldEqual, "true")
+			So(properties["dim"].Value, ShouldEqual, "false")
+			So(ss.ParseString("window >> button { color: red; }"), ShouldNotBeNil)
+		})
+		Convey("media queries", func() {
+			ss := NewStyleSheet()
+			So(ss.ParseString(`
+label { color: blue; }
+@media (min-width: 100) and (color-depth: truecolor), (monochrome) {
+	label { color: red; }
+}
+@media not screen and (width < 40) {
+	label { bold: true; }
+}
+@media (prefers-color-scheme: light) { label { color: black; } }
+`), ShouldBeNil)
+			So(ss.Rules, ShouldHaveLength, 1)
+			So(ss.MediaRules, ShouldHaveLength, 3)
+			So(ss.MediaRules[0].Queries, ShouldHaveLength, 2)
+			So(ss.MediaRules[1].Queries[0].String(), ShouldEqual, "not screen and (width < 40)")
+			wide := StyleMedia{Width: 120, Height: 40, Colors: StyleMediaTrueColor, ColorScheme: "dark"}
+			So(ss.MediaRules[0].Match(wide), ShouldBeTrue)
+			So(ss.MediaRules[1].Match(wide), ShouldBeTrue)
+			So(ss.MediaRules[2].Match(wide), ShouldBeFalse)
+			tiny := StyleMedia{Width: 30, Height: 10, Colors: 0, ColorScheme: "light"}
+			So(ss.MediaRules[0].Match(tiny), ShouldBeTrue)
+			So(ss.MediaRules[1].Match(tiny), ShouldBeFalse)
+			So(ss.MediaRules[2].Match(tiny), ShouldBeTrue)
+			wide.Colors = 256
+			So(ss.MediaRules[0].Match(wide), ShouldBeFalse)
+			previous := GetStyleMedia()
+			defer SetStyleMedia(previous)
+			label := NewLabel("label")
+			SetStyleMedia(tiny)
+			So(ss.SelectProperties(label)["color"].Value, ShouldEqual, "black")
+			So(ss.SelectProperties(label)["bold"], ShouldBeNil)
+			SetStyleMedia(wide)
+			So(ss.SelectProperties(label)["color"].Value, ShouldEqual, "blue")
+			So(ss.SelectProperties(label)["bold"].Value, ShouldEqual, "true")
+			for _, bad := range []string{
+				"@media (min-width) { }",
+				"@media (bogus: 1) { }",
+				"@media screen (color) { }",
+				"@media (color-depth: lots) { }",
+				"@media (color) { label { bold: true; }",
+				"@font-face { }",
+			} {
+				So(NewStyleSheet().ParseString(bad), ShouldNotBeNil)
+			}
+		})
+	})
+}
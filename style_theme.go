@@ -0,0 +1,300 @@
+package ctk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// StyleTheme is a named StyleSheet, registered for all Windows at
+// STYLE_PROVIDER_PRIORITY_THEME while it is the current theme. Themes are CSS
+// files describing themselves within an @theme block:
+//
+// 	@theme {
+// 	    name: "Dark";
+// 	    description: "light text on a dark background";
+// 	    variant: dark;
+// 	    color-depth: 256;
+// 	    engine: rounded;
+// 	}
+// 	button:focus { background-color: #005f87; }
+//
+// The variant is either "light" or "dark" and the color-depth is the least
+// number of colors the display must support, given as for the color-depth
+// media feature. The engine names the StyleEngine the theme is drawn with,
+// the default engine being used if not given. The built-in themes are
+// StyleThemeDefault, StyleThemeDark, StyleThemeHighContrast and
+// StyleThemeMono.
+type StyleTheme struct {
+	Name        string
+	Description string
+	Variant     string
+	Colors      int
+	Engine      string
+	Sheet       *StyleSheet
+}
+
+const (
+	// the theme of the default cdk colors, which declares no style at all
+	StyleThemeDefault = "Default"
+	// light text on a dark background
+	StyleThemeDark = "Dark"
+	// white on black, with focused widgets highlighted in yellow
+	StyleThemeHighContrast = "HighContrast"
+	// the terminal default colors, focused widgets are shown in reverse
+	StyleThemeMono = "Mono"
+)
+
+// the environment variable naming the theme to use, which may also be the path
+// to a theme file
+const StyleThemeEnvironment = "CTK_THEME"
+
+var (
+	styleThemes      = make(map[string]*StyleTheme)
+	styleThemeActive *StyleTheme
+	styleThemeLock   = &sync.RWMutex{}
+	styleThemeOnce   = &sync.Once{}
+)
+
+var builtinStyleThemes = []string{
+	`@theme {
+	name: "Default";
+	description: "the default colors";
+	color-depth: 16;
+}
+`,
+	`@theme {
+	name: "Dark";
+	description: "light text on a dark background";
+	variant: dark;
+	color-depth: 256;
+}
+* {
+	color: #d0d0d0;
+	background-color: #1c1c1c;
+	border-color: #808080;
+	border-background-color: #1c1c1c;
+}
+button { background-color: #303030; }
+button:hover { background-color: #3a3a3a; }
+button:focus, button:active { color: #ffffff; background-color: #005f87; }
+button:default { bold: true; border-color: #5f87af; }
+*:insensitive { color: #6c6c6c; }
+`,
+	`@theme {
+	name: "HighContrast";
+	description: "white on black, focus in yellow";
+	variant: dark;
+	color-depth: 16;
+}
+* {
+	color: white;
+	background-color: black;
+	border-color: white;
+	border-background-color: black;
+}
+button:focus, button:hover, button:active {
+	color: black;
+	background-color: yellow;
+	border-color: yellow;
+	bold: true;
+}
+button:default { bold: true; border-color: yellow; }
+*:insensitive { dim: true; }
+`,
+	`@theme {
+	name: "Mono";
+	description: "the terminal default colors";
+	color-depth: monochrome;
+}
+* {
+	color: default;
+	background-color: default;
+	border-color: default;
+	border-background-color: default;
+}
+button:focus, button:hover, button:active { reverse: true; }
+button:default { bold: true; }
+*:insensitive { dim: true; }
+`,
+}
+
+func init() {
+	for _, source := range builtinStyleThemes {
+		theme, err := ParseStyleTheme(source)
+		if err != nil {
+			panic(fmt.Sprintf("built-in theme: %v", err))
+		}
+		RegisterStyleTheme(theme)
+	}
+}
+
+// ParseStyleTheme parses the given CSS source into a new StyleTheme. The
+// source must describe the theme within an @theme block, naming it at least.
+func ParseStyleTheme(source string) (theme *StyleTheme, err error) {
+	sheet := NewStyleSheet()
+	if err = sheet.ParseString(source); err != nil {
+		return
+	}
+	return newStyleTheme(sheet)
+}
+
+// LoadStyleThemeFromFile parses the CSS file at the given path into a new
+// StyleTheme, see ParseStyleTheme. Errors parsing the file are of type
+// *StyleSheetError, including the path given.
+func LoadStyleThemeFromFile(path string) (theme *StyleTheme, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+	if theme, err = ParseStyleTheme(string(data)); err != nil {
+		if se, ok := err.(*StyleSheetError); ok {
+			se.File = path
+		} else {
+			err = fmt.Errorf("%v: %v", path, err)
+		}
+	}
+	return
+}
+
+func newStyleTheme(sheet *StyleSheet) (theme *StyleTheme, err error) {
+	theme = &StyleTheme{
+		Name:        sheet.Metadata["name"],
+		Description: sheet.Metadata["description"],
+		Variant:     strings.ToLower(sheet.Metadata["variant"]),
+		Engine:      strings.ToLower(sheet.Metadata["engine"]),
+		Sheet:       sheet,
+	}
+	if theme.Name == "" {
+		return nil, fmt.Errorf("theme has no name, expected an @theme block")
+	}
+	switch theme.Variant {
+	case "", "light", "dark":
+	default:
+		return nil, fmt.Errorf("invalid theme variant: %q", theme.Variant)
+	}
+	if depth, ok := sheet.Metadata["color-depth"]; ok {
+		if theme.Colors, err = parseMediaValue("color-depth", depth); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// RegisterStyleTheme adds the given theme to the themes available by name,
+// replacing any theme already registered with the same name.
+func RegisterStyleTheme(theme *StyleTheme) {
+	styleThemeLock.Lock()
+	styleThemes[theme.Name] = theme
+	styleThemeLock.Unlock()
+}
+
+// GetStyleTheme returns the registered theme with the given name, or nil if
+// there is no such theme.
+func GetStyleTheme(name string) (theme *StyleTheme) {
+	styleThemeLock.RLock()
+	defer styleThemeLock.RUnlock()
+	return styleThemes[name]
+}
+
+// ListStyleThemes returns the names of all registered themes, sorted.
+func ListStyleThemes() (names []string) {
+	styleThemeLock.RLock()
+	defer styleThemeLock.RUnlock()
+	for name := range styleThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// GetCurrentStyleTheme returns the theme currently in use. Until SwitchTheme
+// is called, the theme is chosen by the CTK_THEME environment variable.
+func GetCurrentStyleTheme() (theme *StyleTheme) {
+	initStyleTheme()
+	styleThemeLock.RLock()
+	defer styleThemeLock.RUnlock()
+	return styleThemeActive
+}
+
+// SwitchTheme makes the registered theme with the given name the current
+// theme, restyling every Widget of every open Window. The name may also be the
+// path to a theme file, which is loaded and registered, and reloaded each time
+// it changes while hot reloading is enabled. An error is returned and the
+// current theme kept if the theme is not found or the display has fewer colors
+// than the theme requires.
+func SwitchTheme(name string) (err error) {
+	initStyleTheme()
+	return switchStyleTheme(name)
+}
+
+func switchStyleTheme(name string) (err error) {
+	theme := GetStyleTheme(name)
+	if theme == nil && strings.HasSuffix(name, ".css") {
+		if theme, err = LoadStyleThemeFromFile(name); err != nil {
+			return
+		}
+		RegisterStyleTheme(theme)
+		watchHotReload(theme, name, func() error { return reloadStyleTheme(theme, name) })
+	}
+	if theme == nil {
+		return fmt.Errorf("theme not found: %q", name)
+	}
+	if colors := GetStyleMedia().Colors; colors < theme.Colors {
+		return fmt.Errorf("theme %q requires %d colors, the display has %d", theme.Name, theme.Colors, colors)
+	}
+	styleThemeLock.Lock()
+	previous := styleThemeActive
+	styleThemeActive = theme
+	styleThemeLock.Unlock()
+	if previous == theme {
+		return
+	}
+	if previous != nil {
+		removeStyleSheet(previous.Sheet, -1)
+	}
+	addStyleSheet(theme.Sheet, STYLE_PROVIDER_PRIORITY_THEME, -1)
+	return
+}
+
+// parse the theme file at the given path again, replacing the style and
+// description of the theme loaded from it, the name is kept. The registered
+// sheet of the theme is replaced rather than modified, see replaceStyleSheet
+func reloadStyleTheme(theme *StyleTheme, path string) (err error) {
+	var reloaded *StyleTheme
+	if reloaded, err = LoadStyleThemeFromFile(path); err != nil {
+		return
+	}
+	styleThemeLock.Lock()
+	previous := theme.Sheet
+	theme.Description = reloaded.Description
+	theme.Variant = reloaded.Variant
+	theme.Colors = reloaded.Colors
+	theme.Engine = reloaded.Engine
+	theme.Sheet = reloaded.Sheet
+	replaceStyleSheet(previous, reloaded.Sheet)
+	styleThemeLock.Unlock()
+	return
+}
+
+// select the initial theme, once. The theme named by the environment is used
+// if the display has enough colors, falling back to the Mono theme on
+// monochrome displays and the Default theme otherwise. Windows select the
+// theme when first resized, once the colors of the display are known
+func initStyleTheme() {
+	styleThemeOnce.Do(func() {
+		if name := os.Getenv(StyleThemeEnvironment); name != "" {
+			if err := switchStyleTheme(name); err == nil {
+				return
+			}
+		}
+		if GetStyleMedia().Colors <= StyleMediaMonochrome {
+			_ = switchStyleTheme(StyleThemeMono)
+		} else {
+			_ = switchStyleTheme(StyleThemeDefault)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package ctk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStyleTheme(t *testing.T) {
+	Convey("Testing Style Themes", t, func() {
+		Convey("built-in themes", func() {
+			So(ListStyleThemes(), ShouldContain, StyleThemeDefault)
+			So(ListStyleThemes(), ShouldContain, StyleThemeDark)
+			So(ListStyleThemes(), ShouldContain, StyleThemeHighContrast)
+			So(ListStyleThemes(), ShouldContain, StyleThemeMono)
+			dark := GetStyleTheme(StyleThemeDark)
+			So(dark, ShouldNotBeNil)
+			So(dark.Variant, ShouldEqual, "dark")
+			So(dark.Colors, ShouldEqual, 256)
+			So(GetStyleTheme(StyleThemeMono).Colors, ShouldEqual, StyleMediaMonochrome)
+			So(GetStyleTheme("missing"), ShouldBeNil)
+		})
+		Convey("parsing themes", func() {
+			theme, err := ParseStyleTheme(`@theme { name: "Test"; variant: light; color-depth: truecolor; } label { bold: true; }`)
+			So(err, ShouldBeNil)
+			So(theme.Name, ShouldEqual, "Test")
+			So(theme.Variant, ShouldEqual, "light")
+			So(theme.Colors, ShouldEqual, StyleMediaTrueColor)
+			So(theme.Sheet.Rules, ShouldHaveLength, 1)
+			_, err = ParseStyleTheme(`label { bold: true; }`)
+			So(err, ShouldNotBeNil)
+			_, err = ParseStyleTheme(`@theme { name: Test; variant: grey; }`)
+			So(err, ShouldNotBeNil)
+			dir, err := ioutil.TempDir("", "ctk-theme")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "broken.css")
+			So(ioutil.WriteFile(path, []byte("@theme { name: Broken; }\nlabel {"), 0644), ShouldBeNil)
+			_, err = LoadStyleThemeFromFile(path)
+			So(err, ShouldNotBeNil)
+			So(err.(*StyleSheetError).File, ShouldEqual, path)
+		})
+		Convey("switching themes", func() {
+			window := NewWindowWithTitle("theme")
+			button := NewButtonWithLabel("button")
+			window.Add(button)
+			So(GetCurrentStyleTheme().Name, ShouldEqual, StyleThemeDefault)
+			defer func() {
+				So(GetDefaultSettings().SetStringProperty(PropertyCtkThemeName, ""), ShouldBeNil)
+				So(SwitchTheme(StyleThemeDefault), ShouldBeNil)
+			}()
+			So(button.GetComputedStyle().Get(PropertyBackgroundColor), ShouldBeNil)
+			So(SwitchTheme(StyleThemeDark), ShouldBeNil)
+			So(GetCurrentStyleTheme().Name, ShouldEqual, StyleThemeDark)
+			So(button.GetComputedStyle().Get(PropertyBackgroundColor).Value, ShouldEqual, "#303030")
+			So(SwitchTheme("missing"), ShouldNotBeNil)
+			So(GetCurrentStyleTheme().Name, ShouldEqual, StyleThemeDark)
+			media := GetStyleMedia()
+			defer SetStyleMedia(media)
+			mono := media
+			mono.Colors = StyleMediaMonochrome
+			SetStyleMedia(mono)
+			So(SwitchTheme(StyleThemeHighContrast), ShouldNotBeNil)
+			So(GetDefaultSettings().SetThemeName(StyleThemeHighContrast), ShouldNotBeNil)
+			So(GetCurrentStyleTheme().Name, ShouldEqual, StyleThemeDark)
+			So(GetDefaultSettings().SetThemeName(StyleThemeMono), ShouldBeNil)
+			So(GetDefaultSettings().GetThemeName(), ShouldEqual, StyleThemeMono)
+			So(button.GetComputedStyle().Get(PropertyBackgroundColor).Value, ShouldEqual, "default")
+			background, err := button.GetCssColor(PropertyBackgroundColor)
+			So(err, ShouldBeNil)
+			So(background, ShouldEqual, cdk.ColorDefault)
+			So(GetDefaultSettings().SetStringProperty(PropertyCtkThemeName, StyleThemeDefault), ShouldBeNil)
+			So(GetCurrentStyleTheme().Name, ShouldEqual, StyleThemeDefault)
+		})
+		Convey("reloading themes", func() {
+			window := NewWindowWithTitle("reload")
+			label := NewLabel("label")
+			window.Add(label)
+			dir, err := ioutil.TempDir("", "ctk-theme")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "reload.css")
+			So(ioutil.WriteFile(path, []byte("@theme { name: Reload; } label { bold: true; }"), 0644), ShouldBeNil)
+			So(SwitchTheme(path), ShouldBeNil)
+			defer func() { So(SwitchTheme(StyleThemeDefault), ShouldBeNil) }()
+			So(label.GetComputedStyle().Get(PropertyBold).Value, ShouldEqual, "true")
+			theme := GetCurrentStyleTheme()
+			previous := theme.Sheet
+			So(ioutil.WriteFile(path, []byte("@theme { name: Reload; } label { dim: true; }"), 0644), ShouldBeNil)
+			So(reloadStyleTheme(theme, path), ShouldBeNil)
+			So(theme.Sheet, ShouldNotEqual, previous)
+			So(previous.Rules[0].Properties[0].Key, ShouldEqual, "bold")
+			So(label.GetComputedStyle().Get(PropertyBold), ShouldBeNil)
+			So(label.GetComputedStyle().Get(PropertyDim).Value, ShouldEqual, "true")
+		})
+	})
+}
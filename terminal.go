@@ -0,0 +1,814 @@
+package ctk
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"unicode/utf8"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for Terminal objects
+const TypeTerminal cdk.CTypeTag = "ctk-terminal"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTerminal, func() interface{} { return MakeTerminal() })
+}
+
+// Terminal Hierarchy:
+//	Object
+//	  +- Widget
+//	    +- Terminal
+//
+// The Terminal widget is a virtual terminal emulator, modeled after the VTE
+// library. A command is spawned within a pseudo-terminal with ForkCommand and
+// its output is interpreted as a VT100/xterm compatible stream, maintaining a
+// grid of character cells which is drawn onto the widget canvas. While the
+// Terminal has the keyboard focus, key events are translated into the input
+// sequences expected by terminal applications and written to the child. The
+// pseudo-terminal is resized along with the widget. Lines scrolled off the top
+// of the screen are kept in a scrollback history which is exposed through the
+// vertical Adjustment, allowing the Terminal to be placed directly within a
+// ScrolledViewport.
+type Terminal interface {
+	Widget
+	Scrollable
+
+	Init() (already bool)
+	ForkCommand(argv []string, envv []string, workingDirectory string) (pid int, err error)
+	Feed(data []byte)
+	FeedChild(data []byte)
+	GetChildPid() (pid int)
+	GetColumnCount() (columns int)
+	GetRowCount() (rows int)
+	GetCursorPosition() (column, row int)
+	GetWindowTitle() (title string)
+	GetText() (text string)
+	Reset(clearHistory bool)
+	SetScrollbackLines(lines int)
+	GetScrollbackLines() (lines int)
+	SetScrollOnOutput(scroll bool)
+	GetScrollOnOutput() (scroll bool)
+	SetScrollOnKeystroke(scroll bool)
+	GetScrollOnKeystroke() (scroll bool)
+	GetVAdjustment() (adjustment *CAdjustment)
+	SetVAdjustment(adjustment *CAdjustment)
+	SetScrollAdjustments(hAdjustment, vAdjustment *CAdjustment)
+	ProcessEvent(evt cdk.Event) cdk.EventFlag
+	GetSizeRequest() (width, height int)
+	Resize() cdk.EventFlag
+	Draw(canvas cdk.Canvas) cdk.EventFlag
+	Destroy()
+}
+
+// The CTerminal structure implements the Terminal interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with Terminal objects
+type CTerminal struct {
+	CWidget
+
+	screen    *vtScreen
+	pty       *os.File
+	cmd       *exec.Cmd
+	adjHandle string
+}
+
+// Default constructor for Terminal objects
+func MakeTerminal() *CTerminal {
+	return NewTerminal()
+}
+
+// Creates a new terminal widget with a default screen size of 80 columns by
+// 24 rows. Use ForkCommand to start a child process within the terminal.
+// Returns:
+// 	a new Terminal
+func NewTerminal() (value *CTerminal) {
+	t := new(CTerminal)
+	t.Init()
+	return t
+}
+
+// Terminal object initialization. This must be called at least once to setup
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the Terminal instance
+func (t *CTerminal) Init() (already bool) {
+	if t.InitTypeItem(TypeTerminal, t) {
+		return true
+	}
+	t.CWidget.Init()
+	t.flags = NULL_WIDGET_FLAG
+	t.SetFlags(SENSITIVE | PARENT_SENSITIVE | CAN_FOCUS | APP_PAINTABLE)
+	t.pty = nil
+	t.cmd = nil
+	t.adjHandle = fmt.Sprintf("%v.scrollback", t.ObjectName())
+	_ = t.InstallBuildableProperty(PropertyScrollbackLines, cdk.IntProperty, true, 512)
+	_ = t.InstallBuildableProperty(PropertyScrollOnOutput, cdk.BoolProperty, true, false)
+	_ = t.InstallBuildableProperty(PropertyScrollOnKeystroke, cdk.BoolProperty, true, true)
+	_ = t.InstallProperty(PropertyWindowTitle, cdk.StringProperty, true, "")
+	_ = t.InstallProperty(PropertyHAdjustment, cdk.StructProperty, true, nil)
+	_ = t.InstallProperty(PropertyVAdjustment, cdk.StructProperty, true, nil)
+	t.screen = newVtScreen(80, 24, 512)
+	t.SetVAdjustment(NewAdjustment(0, 0, 0, 1, 12, 24))
+	return false
+}
+
+// Starts the given command within a new pseudo-terminal, with the terminal
+// as its controlling terminal. The TERM, COLUMNS and LINES variables are
+// added to the environment given. The output of the child is read in the
+// background and the "child-exited" signal is emitted once the child has
+// exited.
+// Parameters:
+// 	argv	the command and arguments to run
+// 	envv	the environment for the child, or nil to inherit the environment
+// 	workingDirectory	the directory to start the child in, or "" for the
+// 	current directory
+// Returns:
+// 	the process ID of the child, or an error
+func (t *CTerminal) ForkCommand(argv []string, envv []string, workingDirectory string) (pid int, err error) {
+	if len(argv) == 0 {
+		return -1, fmt.Errorf("no command given")
+	}
+	if t.cmd != nil {
+		return -1, fmt.Errorf("terminal child already running: %v", t.cmd.Process.Pid)
+	}
+	var master, slave *os.File
+	var slavePath string
+	if master, slavePath, err = openPty(); err != nil {
+		return -1, err
+	}
+	if slave, err = os.OpenFile(slavePath, os.O_RDWR, 0); err != nil {
+		_ = master.Close()
+		return -1, err
+	}
+	t.Lock()
+	cols, rows := t.screen.cols, t.screen.rows
+	t.Unlock()
+	if err = setPtySize(master, rows, cols); err != nil {
+		t.LogErr(err)
+	}
+	if envv == nil {
+		envv = os.Environ()
+	}
+	envv = append(envv, "TERM=xterm-256color", fmt.Sprintf("COLUMNS=%d", cols), fmt.Sprintf("LINES=%d", rows))
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Env = envv
+	cmd.Dir = workingDirectory
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+	cmd.SysProcAttr = ptySysProcAttr()
+	err = cmd.Start()
+	_ = slave.Close()
+	if err != nil {
+		_ = master.Close()
+		return -1, err
+	}
+	t.Lock()
+	t.pty, t.cmd = master, cmd
+	t.Unlock()
+	go t.readChild(master, cmd)
+	return cmd.Process.Pid, nil
+}
+
+// Interprets data as if it were data received from a child process. This
+// can either be used to drive the terminal without a connected process, or
+// just to mess with your users.
+// Parameters:
+// 	data	a string in the terminal's current encoding
+func (t *CTerminal) Feed(data []byte) {
+	t.Lock()
+	title := t.screen.title
+	t.screen.feed(data)
+	bell, changed := t.screen.bell, t.screen.title != title
+	replies := t.screen.replies
+	t.screen.bell, t.screen.replies = false, nil
+	title = t.screen.title
+	t.Unlock()
+	if len(replies) > 0 {
+		t.FeedChild(replies)
+	}
+	if changed {
+		if err := t.SetStringProperty(PropertyWindowTitle, title); err != nil {
+			t.LogErr(err)
+		}
+		t.Emit(SignalWindowTitleChanged, t, title)
+	}
+	if bell {
+		t.Emit(SignalBell, t)
+	}
+	t.updateAdjustment(t.GetScrollOnOutput())
+	t.Emit(SignalContentsChanged, t)
+	t.Invalidate()
+}
+
+// Sends a block of UTF-8 text to the child as if it were entered by the
+// user at the keyboard.
+// Parameters:
+// 	data	data to send to the child
+func (t *CTerminal) FeedChild(data []byte) {
+	t.Lock()
+	pty := t.pty
+	t.Unlock()
+	if pty != nil {
+		if _, err := pty.Write(data); err != nil {
+			t.LogErr(err)
+		}
+	}
+}
+
+// Destroys the terminal, killing the running child and closing the
+// pseudo-terminal. See: Widget.Destroy
+func (t *CTerminal) Destroy() {
+	t.Lock()
+	pty, cmd := t.pty, t.cmd
+	t.pty, t.cmd = nil, nil
+	t.Unlock()
+	if cmd != nil && cmd.Process != nil {
+		if err := cmd.Process.Kill(); err != nil {
+			t.LogErr(err)
+		}
+	}
+	if pty != nil {
+		_ = pty.Close()
+	}
+	t.CWidget.Destroy()
+}
+
+// Returns the process ID of the running child, or -1 if there is none.
+func (t *CTerminal) GetChildPid() (pid int) {
+	t.Lock()
+	defer t.Unlock()
+	if t.cmd != nil && t.cmd.Process != nil {
+		return t.cmd.Process.Pid
+	}
+	return -1
+}
+
+// Returns the number of columns of the terminal screen.
+func (t *CTerminal) GetColumnCount() (columns int) {
+	t.Lock()
+	defer t.Unlock()
+	return t.screen.cols
+}
+
+// Returns the number of rows of the terminal screen.
+func (t *CTerminal) GetRowCount() (rows int) {
+	t.Lock()
+	defer t.Unlock()
+	return t.screen.rows
+}
+
+// Reads the location of the insertion cursor and returns it. The row
+// coordinate is relative to the top of the screen, excluding the scrollback
+// history.
+// Returns:
+// 	column	the column the cursor is in
+// 	row	the row the cursor is in
+func (t *CTerminal) GetCursorPosition() (column, row int) {
+	t.Lock()
+	defer t.Unlock()
+	return t.screen.cursor.x, t.screen.cursor.y
+}
+
+// Returns the window title most recently set by the child using the xterm
+// OSC 0 or OSC 2 sequences.
+func (t *CTerminal) GetWindowTitle() (title string) {
+	var err error
+	if title, err = t.GetStringProperty(PropertyWindowTitle); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// Returns the text displayed on the terminal screen, one line per row with
+// trailing whitespace removed.
+func (t *CTerminal) GetText() (text string) {
+	t.Lock()
+	defer t.Unlock()
+	return t.screen.text()
+}
+
+// Resets the terminal state, as if the child sent a full reset sequence.
+// Parameters:
+// 	clearHistory	whether to empty the terminal's scrollback buffer
+func (t *CTerminal) Reset(clearHistory bool) {
+	t.Lock()
+	history := t.screen.history
+	t.screen.reset(t.screen.cols, t.screen.rows)
+	if !clearHistory {
+		t.screen.history = history
+	}
+	t.Unlock()
+	t.updateAdjustment(true)
+	t.Invalidate()
+}
+
+// Sets the length of the scrollback buffer used by the terminal. A value of
+// zero disables the scrollback and a negative value makes it unlimited.
+// Parameters:
+// 	lines	the length of the history buffer
+func (t *CTerminal) SetScrollbackLines(lines int) {
+	if err := t.SetIntProperty(PropertyScrollbackLines, lines); err != nil {
+		t.LogErr(err)
+		return
+	}
+	t.Lock()
+	t.screen.maxHistory = lines
+	if lines == 0 {
+		t.screen.history = nil
+	} else if lines > 0 && len(t.screen.history) > lines {
+		t.screen.history = t.screen.history[len(t.screen.history)-lines:]
+	}
+	t.Unlock()
+	t.updateAdjustment(false)
+}
+
+// Returns the length of the scrollback buffer used by the terminal.
+func (t *CTerminal) GetScrollbackLines() (lines int) {
+	var err error
+	if lines, err = t.GetIntProperty(PropertyScrollbackLines); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// Controls whether or not the terminal will forcibly scroll to the bottom
+// of the viewable history when the new data is received from the child.
+// Output always follows when the view is already at the bottom.
+// Parameters:
+// 	scroll	whether the terminal should scroll on output
+func (t *CTerminal) SetScrollOnOutput(scroll bool) {
+	if err := t.SetBoolProperty(PropertyScrollOnOutput, scroll); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// Returns whether or not the terminal will forcibly scroll to the bottom of
+// the viewable history when the new data is received from the child.
+func (t *CTerminal) GetScrollOnOutput() (scroll bool) {
+	var err error
+	if scroll, err = t.GetBoolProperty(PropertyScrollOnOutput); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// Controls whether or not the terminal will forcibly scroll to the bottom
+// of the viewable history when the user presses a key.
+// Parameters:
+// 	scroll	whether the terminal should scroll on keystrokes
+func (t *CTerminal) SetScrollOnKeystroke(scroll bool) {
+	if err := t.SetBoolProperty(PropertyScrollOnKeystroke, scroll); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// Returns whether or not the terminal will forcibly scroll to the bottom of
+// the viewable history when the user presses a key.
+func (t *CTerminal) GetScrollOnKeystroke() (scroll bool) {
+	var err error
+	if scroll, err = t.GetBoolProperty(PropertyScrollOnKeystroke); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// Returns the vertical adjustment of the terminal. The lower bound is the
+// first line of the scrollback history and the upper bound is the number of
+// history lines, where the value is the first line displayed.
+func (t *CTerminal) GetVAdjustment() (adjustment *CAdjustment) {
+	var ok bool
+	if value, err := t.GetStructProperty(PropertyVAdjustment); err != nil {
+		t.LogErr(err)
+	} else if value != nil {
+		if adjustment, ok = value.(*CAdjustment); !ok {
+			t.LogError("value stored in %v property is not of *CAdjustment type: %v (%T)", PropertyVAdjustment, value, value)
+		}
+	}
+	return
+}
+
+// Sets the vertical adjustment of the terminal, the terminal configures the
+// adjustment to reflect the scrollback history.
+// Parameters:
+// 	adjustment	an Adjustment.
+func (t *CTerminal) SetVAdjustment(adjustment *CAdjustment) {
+	if prev := t.GetVAdjustment(); prev != nil {
+		_ = prev.Disconnect(SignalValueChanged, t.adjHandle)
+	}
+	if err := t.SetStructProperty(PropertyVAdjustment, adjustment); err != nil {
+		t.LogErr(err)
+		return
+	}
+	if adjustment != nil {
+		adjustment.Connect(SignalValueChanged, t.adjHandle, func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+			t.Invalidate()
+			return cdk.EVENT_PASS
+		})
+		t.updateAdjustment(true)
+	}
+}
+
+// Sets the horizontal and vertical adjustments used by the terminal. The
+// terminal never scrolls horizontally, the horizontal adjustment is kept
+// empty. A nil vertical adjustment restores a private adjustment. This is
+// called by ScrolledViewport when the terminal is added or removed.
+// Parameters:
+// 	hAdjustment	the horizontal adjustment
+// 	vAdjustment	the vertical adjustment
+func (t *CTerminal) SetScrollAdjustments(hAdjustment, vAdjustment *CAdjustment) {
+	if err := t.SetStructProperty(PropertyHAdjustment, hAdjustment); err != nil {
+		t.LogErr(err)
+	} else if hAdjustment != nil {
+		hAdjustment.Configure(0, 0, 0, 0, 0, 0)
+	}
+	if vAdjustment == nil {
+		vAdjustment = NewAdjustment(0, 0, 0, 1, 0, 0)
+	}
+	t.SetVAdjustment(vAdjustment)
+}
+
+func (t *CTerminal) ProcessEvent(evt cdk.Event) cdk.EventFlag {
+	switch e := evt.(type) {
+	case *cdk.EventMouse:
+		if e.IsWheelImpulse() {
+			switch e.WheelImpulse() {
+			case cdk.WheelUp:
+				return t.scrollBy(-3)
+			case cdk.WheelDown:
+				return t.scrollBy(3)
+			}
+			break
+		}
+		if e.State() == cdk.BUTTON_PRESS {
+			t.GrabFocus()
+			return cdk.EVENT_STOP
+		}
+	case *cdk.EventKey:
+		if !t.IsFocus() {
+			break
+		}
+		if e.Modifiers().Has(cdk.ModShift) {
+			t.Lock()
+			page := t.screen.rows / 2
+			t.Unlock()
+			switch e.Key() {
+			case cdk.KeyPgUp:
+				return t.scrollBy(-page)
+			case cdk.KeyPgDn:
+				return t.scrollBy(page)
+			}
+		}
+		t.Lock()
+		appCursor := t.screen.appCursor
+		t.Unlock()
+		if data := terminalKeySequence(e, appCursor); len(data) > 0 {
+			if f := t.Emit(SignalCommit, t, string(data)); f == cdk.EVENT_PASS {
+				if t.GetScrollOnKeystroke() {
+					t.updateAdjustment(true)
+				}
+				t.FeedChild(data)
+			}
+			return cdk.EVENT_STOP
+		}
+	}
+	return cdk.EVENT_PASS
+}
+
+// Returns the size requested by the Terminal, which defaults to the current
+// size of the terminal screen.
+func (t *CTerminal) GetSizeRequest() (width, height int) {
+	size := cdk.NewRectangle(t.CWidget.GetSizeRequest())
+	t.Lock()
+	cols, rows := t.screen.cols, t.screen.rows
+	t.Unlock()
+	if size.W <= -1 {
+		size.W = cols
+	}
+	if size.H <= -1 {
+		size.H = rows
+	}
+	return size.W, size.H
+}
+
+// Resizes the terminal screen to the allocation of the widget and informs
+// the child of the new window size.
+func (t *CTerminal) Resize() cdk.EventFlag {
+	alloc := t.GetAllocation()
+	if alloc.W <= 0 || alloc.H <= 0 {
+		return cdk.EVENT_PASS
+	}
+	t.Lock()
+	t.screen.resize(alloc.W, alloc.H)
+	pty := t.pty
+	t.Unlock()
+	if pty != nil {
+		if err := setPtySize(pty, alloc.H, alloc.W); err != nil {
+			t.LogErr(err)
+		}
+	}
+	t.updateAdjustment(false)
+	t.Invalidate()
+	return t.Emit(SignalResize, t)
+}
+
+func (t *CTerminal) Draw(canvas cdk.Canvas) cdk.EventFlag {
+	alloc := t.GetAllocation()
+	if !t.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+		t.LogTrace("Terminal.Draw(): not visible, zero width or zero height")
+		return cdk.EVENT_PASS
+	}
+	top := 0
+	if adjustment := t.GetVAdjustment(); adjustment != nil {
+		top = adjustment.GetValue()
+	}
+	focused := t.IsFocus()
+	theme := t.GetThemeRequest()
+	canvas.Fill(theme)
+	t.Lock()
+	defer t.Unlock()
+	s := t.screen
+	cursorLine := len(s.history) + s.cursor.y
+	for y := 0; y < alloc.H && y < s.rows; y++ {
+		line := s.line(top + y)
+		for x := 0; x < alloc.W && x < len(line); x++ {
+			cell := line[x]
+			style := terminalCellStyle(theme.Content.Normal, cell)
+			if s.showCursor && focused && top+y == cursorLine && x == s.cursor.x {
+				style = style.Reverse(cell.attrs&vtAttrReverse == 0)
+			}
+			r := cell.r
+			if cell.attrs&vtAttrHidden != 0 || r == 0 {
+				r = ' '
+			}
+			_ = canvas.SetRune(x, y, r, style)
+		}
+	}
+	if debug, _ := t.GetBoolProperty(cdk.PropertyDebug); debug {
+		canvas.DebugBox(cdk.ColorSilver, t.ObjectInfo())
+	}
+	return cdk.EVENT_STOP
+}
+
+// read the output of the child until the pseudo-terminal is closed, then
+// reap the child and emit the child-exited signal. The output is fed to the
+// terminal and the signal emitted from the main loop, see queueMainCall.
+func (t *CTerminal) readChild(master *os.File, cmd *exec.Cmd) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := master.Read(buf)
+		if n > 0 {
+			data := make([]byte, n)
+			copy(data, buf[:n])
+			queueMainCall(func() {
+				t.Feed(data)
+			})
+		}
+		if err != nil {
+			break
+		}
+	}
+	status := -1
+	if err := cmd.Wait(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			status = exitErr.ExitCode()
+		}
+	} else {
+		status = 0
+	}
+	_ = master.Close()
+	t.Lock()
+	if t.cmd == cmd {
+		t.pty, t.cmd = nil, nil
+	}
+	t.Unlock()
+	queueMainCall(func() {
+		t.Emit(SignalChildExited, t, status)
+	})
+}
+
+// configure the vertical adjustment for the current scrollback history, the
+// view follows the output when it was at the bottom already or follow is set
+func (t *CTerminal) updateAdjustment(follow bool) {
+	adjustment := t.GetVAdjustment()
+	if adjustment == nil {
+		return
+	}
+	t.Lock()
+	history, rows := len(t.screen.history), t.screen.rows
+	t.Unlock()
+	value, _, upper, _, _, _ := adjustment.Settings()
+	if follow || value >= upper || value > history {
+		value = history
+	}
+	adjustment.Configure(value, 0, history, 1, rows/2, rows)
+}
+
+func (t *CTerminal) scrollBy(lines int) cdk.EventFlag {
+	if adjustment := t.GetVAdjustment(); adjustment != nil {
+		value := adjustment.GetValue() + lines
+		if value < adjustment.GetLower() {
+			value = adjustment.GetLower()
+		} else if value > adjustment.GetUpper() {
+			value = adjustment.GetUpper()
+		}
+		adjustment.SetValue(value)
+		return cdk.EVENT_STOP
+	}
+	return cdk.EVENT_PASS
+}
+
+// the sixteen standard terminal colors
+var terminalPalette = []cdk.Color{
+	cdk.ColorBlack, cdk.ColorMaroon, cdk.ColorGreen, cdk.ColorOlive,
+	cdk.ColorNavy, cdk.ColorPurple, cdk.ColorTeal, cdk.ColorSilver,
+	cdk.ColorGray, cdk.ColorRed, cdk.ColorLime, cdk.ColorYellow,
+	cdk.ColorBlue, cdk.ColorFuchsia, cdk.ColorAqua, cdk.ColorWhite,
+}
+
+func terminalColor(color vtColor) cdk.Color {
+	if color&vtColorRGB != 0 {
+		return cdk.NewRGBColor(int32(color>>16&0xff), int32(color>>8&0xff), int32(color&0xff))
+	}
+	switch {
+	case color < 16:
+		return terminalPalette[color]
+	case color < 232:
+		// 6x6x6 color cube
+		c := int32(color - 16)
+		level := func(v int32) int32 {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return cdk.NewRGBColor(level(c/36), level(c/6%6), level(c%6))
+	}
+	gray := int32(8 + (color-232)*10)
+	return cdk.NewRGBColor(gray, gray, gray)
+}
+
+func terminalCellStyle(base cdk.Style, cell vtCell) (style cdk.Style) {
+	style = base
+	if cell.fg != vtColorDefault {
+		style = style.Foreground(terminalColor(cell.fg))
+	}
+	if cell.bg != vtColorDefault {
+		style = style.Background(terminalColor(cell.bg))
+	}
+	if cell.attrs&vtAttrBold != 0 {
+		style = style.Bold(true)
+	}
+	if cell.attrs&vtAttrDim != 0 {
+		style = style.Dim(true)
+	}
+	if cell.attrs&vtAttrUnderline != 0 {
+		style = style.Underline(true)
+	}
+	if cell.attrs&vtAttrReverse != 0 {
+		style = style.Reverse(true)
+	}
+	return
+}
+
+// translate a key event into the input sequence sent to a terminal
+// application, following the xterm conventions
+func terminalKeySequence(e *cdk.EventKey, appCursor bool) (data []byte) {
+	mods := e.Modifiers()
+	modifier := 1
+	if mods.Has(cdk.ModShift) {
+		modifier += 1
+	}
+	if mods.Has(cdk.ModAlt) {
+		modifier += 2
+	}
+	if mods.Has(cdk.ModCtrl) {
+		modifier += 4
+	}
+	cursorKey := func(final byte) []byte {
+		if modifier > 1 {
+			return []byte(fmt.Sprintf("\x1b[1;%d%c", modifier, final))
+		}
+		if appCursor {
+			return []byte{0x1b, 'O', final}
+		}
+		return []byte{0x1b, '[', final}
+	}
+	tildeKey := func(code int) []byte {
+		if modifier > 1 {
+			return []byte(fmt.Sprintf("\x1b[%d;%d~", code, modifier))
+		}
+		return []byte(fmt.Sprintf("\x1b[%d~", code))
+	}
+	switch e.Key() {
+	case cdk.KeyRune:
+		buf := make([]byte, utf8.UTFMax)
+		data = buf[:utf8.EncodeRune(buf, e.Rune())]
+		if mods.Has(cdk.ModAlt) {
+			data = append([]byte{0x1b}, data...)
+		}
+		return
+	case cdk.KeyUp:
+		return cursorKey('A')
+	case cdk.KeyDown:
+		return cursorKey('B')
+	case cdk.KeyRight:
+		return cursorKey('C')
+	case cdk.KeyLeft:
+		return cursorKey('D')
+	case cdk.KeyHome:
+		return cursorKey('H')
+	case cdk.KeyEnd:
+		return cursorKey('F')
+	case cdk.KeyInsert:
+		return tildeKey(2)
+	case cdk.KeyDelete:
+		return tildeKey(3)
+	case cdk.KeyPgUp:
+		return tildeKey(5)
+	case cdk.KeyPgDn:
+		return tildeKey(6)
+	case cdk.KeyBacktab:
+		return []byte("\x1b[Z")
+	case cdk.KeyF1:
+		return []byte("\x1bOP")
+	case cdk.KeyF2:
+		return []byte("\x1bOQ")
+	case cdk.KeyF3:
+		return []byte("\x1bOR")
+	case cdk.KeyF4:
+		return []byte("\x1bOS")
+	case cdk.KeyF5:
+		return tildeKey(15)
+	case cdk.KeyF6:
+		return tildeKey(17)
+	case cdk.KeyF7:
+		return tildeKey(18)
+	case cdk.KeyF8:
+		return tildeKey(19)
+	case cdk.KeyF9:
+		return tildeKey(20)
+	case cdk.KeyF10:
+		return tildeKey(21)
+	case cdk.KeyF11:
+		return tildeKey(23)
+	case cdk.KeyF12:
+		return tildeKey(24)
+	}
+	// control characters, including Enter, Tab, Backspace and Escape
+	if k := int(e.Key()); k >= 0 && k < 0x80 {
+		data = []byte{byte(k)}
+		if mods.Has(cdk.ModAlt) {
+			data = append([]byte{0x1b}, data...)
+		}
+	}
+	return
+}
+
+// The length of the scrollback history, a negative value makes the history
+// unlimited.
+// Flags: Read / Write
+// Default value: 512
+const PropertyScrollbackLines cdk.Property = "scrollback-lines"
+
+// Controls whether or not the terminal will forcibly scroll to the bottom
+// of the viewable history when new data is received from the child.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyScrollOnOutput cdk.Property = "scroll-on-output"
+
+// Controls whether or not the terminal will forcibly scroll to the bottom
+// of the viewable history when the user presses a key.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyScrollOnKeystroke cdk.Property = "scroll-on-keystroke"
+
+// The window title as set by the child process.
+// Flags: Read
+// Default value: ""
+const PropertyWindowTitle cdk.Property = "window-title"
+
+// Emitted when the terminal receives a bell character from the child.
+const SignalBell cdk.Signal = "bell"
+
+// Emitted whenever the terminal receives input from the user and prepares
+// to send it to the child process. Listeners returning EVENT_STOP prevent the
+// input from being sent.
+// Listener function arguments:
+// 	text string	the input text
+const SignalCommit cdk.Signal = "commit"
+
+// Emitted whenever the visible appearance of the terminal has changed.
+const SignalContentsChanged cdk.Signal = "contents-changed"
+
+// This signal is emitted when the terminal detects that a child started
+// using ForkCommand has exited.
+// Listener function arguments:
+// 	status int	the child's exit status
+const SignalChildExited cdk.Signal = "child-exited"
+
+// Emitted when the terminal's window title has been changed by the child.
+// Listener function arguments:
+// 	title string	the new window title
+const SignalWindowTitleChanged cdk.Signal = "window-title-changed"
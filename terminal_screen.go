@@ -0,0 +1,832 @@
+package ctk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// vtColor is a terminal color, vtColorDefault refers to the default
+// foreground or background, values below 256 are palette indices and
+// anything with vtColorRGB set is a packed 24-bit color
+type vtColor int32
+
+const (
+	vtColorDefault vtColor = -1
+	vtColorRGB     vtColor = 1 << 24
+)
+
+type vtAttrs uint8
+
+const (
+	vtAttrBold vtAttrs = 1 << iota
+	vtAttrDim
+	vtAttrItalic
+	vtAttrUnderline
+	vtAttrBlink
+	vtAttrReverse
+	vtAttrHidden
+)
+
+// a single character cell of the terminal screen
+type vtCell struct {
+	r     rune
+	fg    vtColor
+	bg    vtColor
+	attrs vtAttrs
+}
+
+type vtCursor struct {
+	x, y    int
+	fg, bg  vtColor
+	attrs   vtAttrs
+	charset bool
+}
+
+type vtParseState int
+
+const (
+	vtGround vtParseState = iota
+	vtEscape
+	vtEscapeIntermediate
+	vtCsi
+	vtOsc
+	vtOscEscape
+	vtCharset
+)
+
+// vtScreen is the VT100/xterm emulation state of a Terminal. Output from the
+// child process is fed to the screen which maintains the cell grid, the
+// cursor and the scrollback history. Replies to queries (such as cursor
+// position reports) are collected for the Terminal to send to the child.
+type vtScreen struct {
+	cols, rows int
+	grid       [][]vtCell
+	history    [][]vtCell
+	maxHistory int
+
+	cursor      vtCursor
+	saved       vtCursor
+	wrapPending bool
+	autoWrap    bool
+	insertMode  bool
+	appCursor   bool
+	showCursor  bool
+	top, bottom int
+	tabs        []bool
+
+	altGrid   [][]vtCell
+	altSaved  vtCursor
+	altActive bool
+
+	state   vtParseState
+	params  string
+	inter   string
+	osc     []byte
+	partial []byte
+
+	title   string
+	bell    bool
+	replies []byte
+}
+
+func newVtScreen(cols, rows, maxHistory int) (s *vtScreen) {
+	s = &vtScreen{maxHistory: maxHistory}
+	s.reset(cols, rows)
+	return
+}
+
+func (s *vtScreen) reset(cols, rows int) {
+	if cols < 1 {
+		cols = 1
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	s.cols, s.rows = cols, rows
+	s.grid = s.makeGrid(cols, rows)
+	s.history = nil
+	s.altGrid = nil
+	s.altActive = false
+	s.cursor = vtCursor{fg: vtColorDefault, bg: vtColorDefault}
+	s.saved = s.cursor
+	s.wrapPending = false
+	s.autoWrap = true
+	s.insertMode = false
+	s.appCursor = false
+	s.showCursor = true
+	s.top, s.bottom = 0, rows-1
+	s.resetTabs()
+	s.state = vtGround
+	s.partial = nil
+}
+
+func (s *vtScreen) resetTabs() {
+	s.tabs = make([]bool, s.cols)
+	for x := 8; x < s.cols; x += 8 {
+		s.tabs[x] = true
+	}
+}
+
+func (s *vtScreen) blank() vtCell {
+	return vtCell{r: ' ', fg: s.cursor.fg, bg: s.cursor.bg}
+}
+
+func (s *vtScreen) makeLine(cols int) (line []vtCell) {
+	line = make([]vtCell, cols)
+	for x := range line {
+		line[x] = vtCell{r: ' ', fg: vtColorDefault, bg: vtColorDefault}
+	}
+	return
+}
+
+func (s *vtScreen) makeGrid(cols, rows int) (grid [][]vtCell) {
+	grid = make([][]vtCell, rows)
+	for y := range grid {
+		grid[y] = s.makeLine(cols)
+	}
+	return
+}
+
+// resize the screen, lines scrolled off the top to keep the cursor visible
+// are moved into the scrollback history
+func (s *vtScreen) resize(cols, rows int) {
+	if cols < 1 {
+		cols = 1
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	if cols == s.cols && rows == s.rows {
+		return
+	}
+	if shift := s.cursor.y - rows + 1; shift > 0 {
+		for _, line := range s.grid[:shift] {
+			s.pushHistory(line)
+		}
+		s.grid = s.grid[shift:]
+		s.cursor.y -= shift
+		s.saved.y -= shift
+	}
+	s.grid = resizeVtGrid(s.grid, cols, rows, s.makeLine)
+	if s.altGrid != nil {
+		s.altGrid = resizeVtGrid(s.altGrid, cols, rows, s.makeLine)
+	}
+	for idx, line := range s.history {
+		if len(line) < cols {
+			s.history[idx] = append(line, s.makeLine(cols-len(line))...)
+		}
+	}
+	s.cols, s.rows = cols, rows
+	s.top, s.bottom = 0, rows-1
+	s.resetTabs()
+	s.cursor.x = clampVt(s.cursor.x, 0, cols-1)
+	s.cursor.y = clampVt(s.cursor.y, 0, rows-1)
+	s.saved.x = clampVt(s.saved.x, 0, cols-1)
+	s.saved.y = clampVt(s.saved.y, 0, rows-1)
+	s.altSaved.x = clampVt(s.altSaved.x, 0, cols-1)
+	s.altSaved.y = clampVt(s.altSaved.y, 0, rows-1)
+	s.wrapPending = false
+}
+
+func resizeVtGrid(grid [][]vtCell, cols, rows int, makeLine func(int) []vtCell) [][]vtCell {
+	for len(grid) < rows {
+		grid = append(grid, makeLine(cols))
+	}
+	grid = grid[:rows]
+	for y, line := range grid {
+		if len(line) < cols {
+			grid[y] = append(line, makeLine(cols-len(line))...)
+		} else {
+			grid[y] = line[:cols]
+		}
+	}
+	return grid
+}
+
+func (s *vtScreen) pushHistory(line []vtCell) {
+	if s.altActive || s.maxHistory == 0 {
+		return
+	}
+	s.history = append(s.history, line)
+	if s.maxHistory > 0 && len(s.history) > s.maxHistory {
+		s.history = s.history[len(s.history)-s.maxHistory:]
+	}
+}
+
+// feed data received from the child process
+func (s *vtScreen) feed(data []byte) {
+	if len(s.partial) > 0 {
+		data = append(s.partial, data...)
+		s.partial = nil
+	}
+	for len(data) > 0 {
+		b := data[0]
+		if s.state == vtGround && b >= 0x80 {
+			if !utf8.FullRune(data) {
+				s.partial = append([]byte{}, data...)
+				return
+			}
+			r, size := utf8.DecodeRune(data)
+			data = data[size:]
+			s.put(r)
+			continue
+		}
+		data = data[1:]
+		s.feedByte(b)
+	}
+}
+
+func (s *vtScreen) feedByte(b byte) {
+	switch s.state {
+	case vtOsc:
+		switch b {
+		case 0x07:
+			s.finishOsc()
+		case 0x1b:
+			s.state = vtOscEscape
+		default:
+			s.osc = append(s.osc, b)
+		}
+		return
+	case vtOscEscape:
+		if b == '\\' {
+			s.finishOsc()
+			return
+		}
+		s.finishOsc()
+		s.state = vtEscape
+		s.inter = ""
+	case vtCharset:
+		s.cursor.charset = b == '0'
+		s.state = vtGround
+		return
+	}
+	if b < 0x20 || b == 0x7f {
+		s.control(b)
+		return
+	}
+	switch s.state {
+	case vtGround:
+		s.put(rune(b))
+	case vtEscape, vtEscapeIntermediate:
+		s.escape(b)
+	case vtCsi:
+		switch {
+		case b >= 0x30 && b <= 0x3f:
+			s.params += string(b)
+		case b >= 0x20 && b <= 0x2f:
+			s.inter += string(b)
+		default:
+			s.state = vtGround
+			s.csi(b)
+		}
+	}
+}
+
+func (s *vtScreen) control(b byte) {
+	switch b {
+	case 0x07:
+		s.bell = true
+	case 0x08:
+		if s.cursor.x > 0 {
+			s.cursor.x--
+		}
+		s.wrapPending = false
+	case 0x09:
+		s.tab(1)
+	case 0x0a, 0x0b, 0x0c:
+		s.lineFeed()
+	case 0x0d:
+		s.cursor.x = 0
+		s.wrapPending = false
+	case 0x18, 0x1a:
+		s.state = vtGround
+	case 0x1b:
+		s.state = vtEscape
+		s.inter = ""
+	}
+}
+
+func (s *vtScreen) escape(b byte) {
+	if b >= 0x20 && b <= 0x2f {
+		if b == '(' {
+			s.state = vtCharset
+			return
+		}
+		s.inter += string(b)
+		s.state = vtEscapeIntermediate
+		return
+	}
+	s.state = vtGround
+	if s.inter != "" {
+		// designate other charsets, DECALN and friends are ignored
+		return
+	}
+	switch b {
+	case '[':
+		s.state = vtCsi
+		s.params, s.inter = "", ""
+	case ']':
+		s.state = vtOsc
+		s.osc = s.osc[:0]
+	case '7':
+		s.saved = s.cursor
+	case '8':
+		s.cursor = s.saved
+		s.wrapPending = false
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.cursor.x = 0
+		s.lineFeed()
+	case 'H':
+		if s.cursor.x < s.cols {
+			s.tabs[s.cursor.x] = true
+		}
+	case 'M':
+		s.reverseLineFeed()
+	case 'c':
+		s.reset(s.cols, s.rows)
+	}
+}
+
+func (s *vtScreen) finishOsc() {
+	s.state = vtGround
+	parts := strings.SplitN(string(s.osc), ";", 2)
+	if len(parts) == 2 && (parts[0] == "0" || parts[0] == "2") {
+		s.title = parts[1]
+	}
+}
+
+func (s *vtScreen) csiParams(defaultValue int) (values []int) {
+	for _, part := range strings.Split(strings.TrimPrefix(s.params, "?"), ";") {
+		if idx := strings.IndexByte(part, ':'); idx >= 0 {
+			part = part[:idx]
+		}
+		if v, err := strconv.Atoi(part); err == nil {
+			values = append(values, v)
+		} else {
+			values = append(values, defaultValue)
+		}
+	}
+	return
+}
+
+func (s *vtScreen) csi(final byte) {
+	private := strings.HasPrefix(s.params, "?")
+	p := s.csiParams(0)
+	arg := func(idx, def int) int {
+		if idx < len(p) && p[idx] > 0 {
+			return p[idx]
+		}
+		return def
+	}
+	if s.inter != "" {
+		return
+	}
+	switch final {
+	case '@':
+		s.insertBlanks(arg(0, 1))
+	case 'A':
+		s.moveTo(s.cursor.x, s.cursor.y-arg(0, 1), true)
+	case 'B', 'e':
+		s.moveTo(s.cursor.x, s.cursor.y+arg(0, 1), true)
+	case 'C', 'a':
+		s.moveTo(s.cursor.x+arg(0, 1), s.cursor.y, true)
+	case 'D':
+		s.moveTo(s.cursor.x-arg(0, 1), s.cursor.y, true)
+	case 'E':
+		s.moveTo(0, s.cursor.y+arg(0, 1), true)
+	case 'F':
+		s.moveTo(0, s.cursor.y-arg(0, 1), true)
+	case 'G', '`':
+		s.moveTo(arg(0, 1)-1, s.cursor.y, false)
+	case 'H', 'f':
+		s.moveTo(arg(1, 1)-1, arg(0, 1)-1, false)
+	case 'I':
+		s.tab(arg(0, 1))
+	case 'J':
+		s.eraseDisplay(arg(0, 0))
+	case 'K':
+		s.eraseLine(arg(0, 0))
+	case 'L':
+		s.insertLines(arg(0, 1))
+	case 'M':
+		s.deleteLines(arg(0, 1))
+	case 'P':
+		s.deleteChars(arg(0, 1))
+	case 'S':
+		for n := s.clampLines(arg(0, 1)); n > 0; n-- {
+			s.scrollUp()
+		}
+	case 'T':
+		for n := s.clampLines(arg(0, 1)); n > 0; n-- {
+			s.scrollDown()
+		}
+	case 'X':
+		line := s.grid[s.cursor.y]
+		for x := s.cursor.x; x < s.cursor.x+arg(0, 1) && x < s.cols; x++ {
+			line[x] = s.blank()
+		}
+	case 'Z':
+		for n := s.clampColumns(arg(0, 1)); n > 0; n-- {
+			x := s.cursor.x - 1
+			for x > 0 && !s.tabs[x] {
+				x--
+			}
+			s.cursor.x = clampVt(x, 0, s.cols-1)
+		}
+	case 'c':
+		if !private {
+			s.send("\x1b[?1;2c")
+		}
+	case 'd':
+		s.moveTo(s.cursor.x, arg(0, 1)-1, false)
+	case 'g':
+		switch arg(0, 0) {
+		case 0:
+			s.tabs[s.cursor.x] = false
+		case 3:
+			s.tabs = make([]bool, s.cols)
+		}
+	case 'h', 'l':
+		for _, mode := range p {
+			s.setMode(private, mode, final == 'h')
+		}
+	case 'm':
+		s.sgr(p)
+	case 'n':
+		switch arg(0, 0) {
+		case 5:
+			s.send("\x1b[0n")
+		case 6:
+			s.send(fmt.Sprintf("\x1b[%d;%dR", s.cursor.y+1, s.cursor.x+1))
+		}
+	case 'r':
+		top, bottom := arg(0, 1)-1, arg(1, s.rows)-1
+		if top < bottom && bottom < s.rows {
+			s.top, s.bottom = top, bottom
+			s.moveTo(0, 0, false)
+		}
+	case 's':
+		s.saved = s.cursor
+	case 'u':
+		s.cursor = s.saved
+		s.wrapPending = false
+	}
+}
+
+func (s *vtScreen) setMode(private bool, mode int, set bool) {
+	if !private {
+		if mode == 4 {
+			s.insertMode = set
+		}
+		return
+	}
+	switch mode {
+	case 1:
+		s.appCursor = set
+	case 7:
+		s.autoWrap = set
+	case 25:
+		s.showCursor = set
+	case 47, 1047, 1049:
+		if mode == 1049 && set {
+			s.altSaved = s.cursor
+		}
+		s.setAltScreen(set)
+		if mode == 1049 && !set {
+			s.restoreAltSaved()
+		}
+	case 1048:
+		if set {
+			s.altSaved = s.cursor
+		} else {
+			s.restoreAltSaved()
+		}
+	}
+}
+
+// restore the cursor saved by DECSET 1048 or 1049, within the screen
+func (s *vtScreen) restoreAltSaved() {
+	s.cursor = s.altSaved
+	s.cursor.x = clampVt(s.cursor.x, 0, s.cols-1)
+	s.cursor.y = clampVt(s.cursor.y, 0, s.rows-1)
+	s.wrapPending = false
+}
+
+func (s *vtScreen) setAltScreen(active bool) {
+	if active == s.altActive {
+		return
+	}
+	if active {
+		s.altGrid = s.grid
+		s.grid = s.makeGrid(s.cols, s.rows)
+	} else {
+		s.grid = s.altGrid
+		s.altGrid = nil
+	}
+	s.altActive = active
+	s.wrapPending = false
+}
+
+func (s *vtScreen) sgr(p []int) {
+	if len(p) == 0 {
+		p = []int{0}
+	}
+	for idx := 0; idx < len(p); idx++ {
+		switch v := p[idx]; {
+		case v == 0:
+			s.cursor.fg, s.cursor.bg, s.cursor.attrs = vtColorDefault, vtColorDefault, 0
+		case v == 1:
+			s.cursor.attrs |= vtAttrBold
+		case v == 2:
+			s.cursor.attrs |= vtAttrDim
+		case v == 3:
+			s.cursor.attrs |= vtAttrItalic
+		case v == 4:
+			s.cursor.attrs |= vtAttrUnderline
+		case v == 5 || v == 6:
+			s.cursor.attrs |= vtAttrBlink
+		case v == 7:
+			s.cursor.attrs |= vtAttrReverse
+		case v == 8:
+			s.cursor.attrs |= vtAttrHidden
+		case v == 22:
+			s.cursor.attrs &^= vtAttrBold | vtAttrDim
+		case v == 23:
+			s.cursor.attrs &^= vtAttrItalic
+		case v == 24:
+			s.cursor.attrs &^= vtAttrUnderline
+		case v == 25:
+			s.cursor.attrs &^= vtAttrBlink
+		case v == 27:
+			s.cursor.attrs &^= vtAttrReverse
+		case v == 28:
+			s.cursor.attrs &^= vtAttrHidden
+		case v >= 30 && v <= 37:
+			s.cursor.fg = vtColor(v - 30)
+		case v == 38 || v == 48:
+			var color vtColor
+			color, idx = parseVtExtendedColor(p, idx)
+			if v == 38 {
+				s.cursor.fg = color
+			} else {
+				s.cursor.bg = color
+			}
+		case v == 39:
+			s.cursor.fg = vtColorDefault
+		case v >= 40 && v <= 47:
+			s.cursor.bg = vtColor(v - 40)
+		case v == 49:
+			s.cursor.bg = vtColorDefault
+		case v >= 90 && v <= 97:
+			s.cursor.fg = vtColor(v - 90 + 8)
+		case v >= 100 && v <= 107:
+			s.cursor.bg = vtColor(v - 100 + 8)
+		}
+	}
+}
+
+// parse the "5;n" and "2;r;g;b" forms following an SGR 38 or 48, returning
+// the color and the index of the last parameter consumed
+func parseVtExtendedColor(p []int, idx int) (color vtColor, last int) {
+	color, last = vtColorDefault, idx
+	if idx+1 >= len(p) {
+		return
+	}
+	switch p[idx+1] {
+	case 5:
+		if idx+2 < len(p) {
+			color, last = vtColor(clampVt(p[idx+2], 0, 255)), idx+2
+		}
+	case 2:
+		if idx+4 < len(p) {
+			r, g, b := clampVt(p[idx+2], 0, 255), clampVt(p[idx+3], 0, 255), clampVt(p[idx+4], 0, 255)
+			color, last = vtColorRGB|vtColor(r<<16|g<<8|b), idx+4
+		}
+	}
+	return
+}
+
+func (s *vtScreen) send(reply string) {
+	s.replies = append(s.replies, reply...)
+}
+
+// DEC special graphics, used for line drawing when selected with ESC ( 0
+var vtLineDrawing = map[rune]rune{
+	'`': '◆', 'a': '▒', 'f': '°', 'g': '±', 'j': '┘', 'k': '┐', 'l': '┌',
+	'm': '└', 'n': '┼', 'q': '─', 't': '├', 'u': '┤', 'v': '┴', 'w': '┬',
+	'x': '│', 'y': '≤', 'z': '≥', '{': 'π', '|': '≠', '}': '£', '~': '·',
+}
+
+func (s *vtScreen) put(r rune) {
+	if s.cursor.charset {
+		if v, ok := vtLineDrawing[r]; ok {
+			r = v
+		}
+	}
+	if s.wrapPending {
+		if s.autoWrap {
+			s.cursor.x = 0
+			s.lineFeed()
+		}
+		s.wrapPending = false
+	}
+	line := s.grid[s.cursor.y]
+	if s.insertMode {
+		copy(line[s.cursor.x+1:], line[s.cursor.x:])
+	}
+	line[s.cursor.x] = vtCell{r: r, fg: s.cursor.fg, bg: s.cursor.bg, attrs: s.cursor.attrs}
+	if s.cursor.x+1 < s.cols {
+		s.cursor.x++
+	} else {
+		s.wrapPending = true
+	}
+}
+
+func (s *vtScreen) tab(n int) {
+	for n = s.clampColumns(n); n > 0; n-- {
+		x := s.cursor.x + 1
+		for x < s.cols-1 && !s.tabs[x] {
+			x++
+		}
+		s.cursor.x = clampVt(x, 0, s.cols-1)
+	}
+	s.wrapPending = false
+}
+
+func (s *vtScreen) moveTo(x, y int, relative bool) {
+	top, bottom := 0, s.rows-1
+	if relative && s.cursor.y >= s.top && s.cursor.y <= s.bottom {
+		top, bottom = s.top, s.bottom
+	}
+	s.cursor.x = clampVt(x, 0, s.cols-1)
+	s.cursor.y = clampVt(y, top, bottom)
+	s.wrapPending = false
+}
+
+func (s *vtScreen) lineFeed() {
+	if s.cursor.y == s.bottom {
+		s.scrollUp()
+	} else if s.cursor.y < s.rows-1 {
+		s.cursor.y++
+	}
+	s.wrapPending = false
+}
+
+func (s *vtScreen) reverseLineFeed() {
+	if s.cursor.y == s.top {
+		s.scrollDown()
+	} else if s.cursor.y > 0 {
+		s.cursor.y--
+	}
+	s.wrapPending = false
+}
+
+// scroll the scrolling region up one line, the top line of a full-screen
+// region is kept in the scrollback history
+func (s *vtScreen) scrollUp() {
+	if s.top == 0 {
+		s.pushHistory(s.grid[0])
+	}
+	copy(s.grid[s.top:], s.grid[s.top+1:s.bottom+1])
+	s.grid[s.bottom] = s.blankLine()
+}
+
+func (s *vtScreen) scrollDown() {
+	copy(s.grid[s.top+1:s.bottom+1], s.grid[s.top:s.bottom])
+	s.grid[s.top] = s.blankLine()
+}
+
+func (s *vtScreen) blankLine() (line []vtCell) {
+	line = make([]vtCell, s.cols)
+	for x := range line {
+		line[x] = s.blank()
+	}
+	return
+}
+
+func (s *vtScreen) insertLines(n int) {
+	if s.cursor.y < s.top || s.cursor.y > s.bottom {
+		return
+	}
+	for n = s.clampLines(n); n > 0; n-- {
+		copy(s.grid[s.cursor.y+1:s.bottom+1], s.grid[s.cursor.y:s.bottom])
+		s.grid[s.cursor.y] = s.blankLine()
+	}
+	s.cursor.x = 0
+}
+
+func (s *vtScreen) deleteLines(n int) {
+	if s.cursor.y < s.top || s.cursor.y > s.bottom {
+		return
+	}
+	for n = s.clampLines(n); n > 0; n-- {
+		copy(s.grid[s.cursor.y:], s.grid[s.cursor.y+1:s.bottom+1])
+		s.grid[s.bottom] = s.blankLine()
+	}
+	s.cursor.x = 0
+}
+
+func (s *vtScreen) insertBlanks(n int) {
+	line := s.grid[s.cursor.y]
+	for n = s.clampColumns(n); n > 0; n-- {
+		copy(line[s.cursor.x+1:], line[s.cursor.x:])
+		line[s.cursor.x] = s.blank()
+	}
+}
+
+func (s *vtScreen) deleteChars(n int) {
+	line := s.grid[s.cursor.y]
+	for n = s.clampColumns(n); n > 0; n-- {
+		copy(line[s.cursor.x:], line[s.cursor.x+1:])
+		line[s.cols-1] = s.blank()
+	}
+}
+
+func (s *vtScreen) eraseLine(mode int) {
+	line := s.grid[s.cursor.y]
+	from, to := s.cursor.x, s.cols
+	switch mode {
+	case 1:
+		from, to = 0, s.cursor.x+1
+	case 2:
+		from = 0
+	}
+	for x := from; x < to && x < s.cols; x++ {
+		line[x] = s.blank()
+	}
+}
+
+func (s *vtScreen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+		for y := s.cursor.y + 1; y < s.rows; y++ {
+			s.grid[y] = s.blankLine()
+		}
+	case 1:
+		s.eraseLine(1)
+		for y := 0; y < s.cursor.y; y++ {
+			s.grid[y] = s.blankLine()
+		}
+	case 2:
+		for y := 0; y < s.rows; y++ {
+			s.grid[y] = s.blankLine()
+		}
+	case 3:
+		s.history = nil
+	}
+}
+
+// returns the line at the given index of the combined scrollback history
+// and screen grid
+func (s *vtScreen) line(idx int) []vtCell {
+	if idx < 0 {
+		return nil
+	}
+	if idx < len(s.history) {
+		return s.history[idx]
+	}
+	if idx -= len(s.history); idx < len(s.grid) {
+		return s.grid[idx]
+	}
+	return nil
+}
+
+// returns the text of the screen grid with trailing spaces removed
+func (s *vtScreen) text() string {
+	lines := make([]string, len(s.grid))
+	for y, line := range s.grid {
+		runes := make([]rune, len(line))
+		for x, cell := range line {
+			runes[x] = cell.r
+		}
+		lines[y] = strings.TrimRight(string(runes), " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// limit a line repeat count to the height of the scrolling region, repeating
+// beyond it has no further effect on the screen
+func (s *vtScreen) clampLines(n int) int {
+	return clampVt(n, 0, s.bottom-s.top+1)
+}
+
+// limit a character or tab repeat count to the width of the screen
+func (s *vtScreen) clampColumns(n int) int {
+	return clampVt(n, 0, s.cols)
+}
+
+func clampVt(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
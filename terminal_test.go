@@ -0,0 +1,143 @@
+package ctk
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTerminal(t *testing.T) {
+	Convey("Testing Terminals", t, func() {
+		Convey("basics", func() {
+			term := NewTerminal()
+			So(term, ShouldNotBeNil)
+			So(term.GetColumnCount(), ShouldEqual, 80)
+			So(term.GetRowCount(), ShouldEqual, 24)
+			So(term.GetChildPid(), ShouldEqual, -1)
+			So(term.GetScrollbackLines(), ShouldEqual, 512)
+			So(term.GetVAdjustment(), ShouldNotBeNil)
+		})
+		Convey("output parsing", func() {
+			term := NewTerminal()
+			term.SetAllocation(cdk.MakeRectangle(10, 3))
+			term.Resize()
+			So(term.GetColumnCount(), ShouldEqual, 10)
+			term.Feed([]byte("hello\r\n\x1b[1;31mworld\x1b[0m"))
+			So(term.GetText(), ShouldEqual, "hello\nworld")
+			col, row := term.GetCursorPosition()
+			So(col, ShouldEqual, 5)
+			So(row, ShouldEqual, 1)
+			term.Feed([]byte("\x1b[2J\x1b[2;3Hx\x1b]2;title\x07"))
+			So(term.GetText(), ShouldEqual, "\n  x")
+			So(term.GetWindowTitle(), ShouldEqual, "title")
+			term.Feed([]byte("\x1b[H0123456789ab"))
+			So(term.GetText(), ShouldEqual, "0123456789\nabx")
+			term.Feed([]byte("\x1b(0lqk\x1b(B"))
+			So(term.GetText(), ShouldEqual, "0123456789\nab┌─┐")
+		})
+		Convey("scrollback", func() {
+			term := NewTerminal()
+			term.SetAllocation(cdk.MakeRectangle(10, 2))
+			term.Resize()
+			term.Feed([]byte("1\r\n2\r\n3\r\n4"))
+			So(term.GetText(), ShouldEqual, "3\n4")
+			adjustment := term.GetVAdjustment()
+			So(adjustment.GetUpper(), ShouldEqual, 2)
+			So(adjustment.GetValue(), ShouldEqual, 2)
+			So(adjustment.GetPageSize(), ShouldEqual, 2)
+			adjustment.SetValue(0)
+			term.Feed([]byte("\r\n5"))
+			So(adjustment.GetUpper(), ShouldEqual, 3)
+			So(adjustment.GetValue(), ShouldEqual, 0)
+			term.SetScrollOnOutput(true)
+			term.Feed([]byte("\r\n6"))
+			So(adjustment.GetValue(), ShouldEqual, 4)
+			term.SetScrollbackLines(1)
+			So(adjustment.GetUpper(), ShouldEqual, 1)
+			term.Reset(true)
+			So(term.GetText(), ShouldEqual, "")
+			So(adjustment.GetUpper(), ShouldEqual, 0)
+		})
+		Convey("alternate screen resize", func() {
+			term := NewTerminal()
+			term.SetAllocation(cdk.MakeRectangle(20, 10))
+			term.Resize()
+			term.Feed([]byte("\x1b[10;20H\x1b[?1049h"))
+			term.SetAllocation(cdk.MakeRectangle(5, 3))
+			term.Resize()
+			So(func() { term.Feed([]byte("\x1b[?1049lx")) }, ShouldNotPanic)
+			_, row := term.GetCursorPosition()
+			So(row, ShouldEqual, 2)
+			So(term.GetText(), ShouldEndWith, "x")
+		})
+		Convey("huge repeat counts", func() {
+			term := NewTerminal()
+			term.SetScrollbackLines(-1)
+			term.SetAllocation(cdk.MakeRectangle(10, 4))
+			term.Resize()
+			start := time.Now()
+			term.Feed([]byte("abc\r\nde\x1b[2000000000S"))
+			So(term.GetText(), ShouldEqual, "")
+			So(term.screen.history, ShouldHaveLength, 4)
+			col, row := term.GetCursorPosition()
+			So(col, ShouldEqual, 2)
+			So(row, ShouldEqual, 1)
+			term.Feed([]byte("\x1b[Hxyz\x1b[1;2H\x1b[999999999P"))
+			So(term.GetText(), ShouldEqual, "x")
+			term.Feed([]byte("\x1b[Hxyz\x1b[1;2H\x1b[999999999@"))
+			So(term.GetText(), ShouldEqual, "x")
+			term.Feed([]byte("\x1b[999999999I"))
+			col, _ = term.GetCursorPosition()
+			So(col, ShouldEqual, 9)
+			term.Feed([]byte("\x1b[999999999Z"))
+			col, _ = term.GetCursorPosition()
+			So(col, ShouldEqual, 0)
+			term.Feed([]byte("\x1b[2;1Hab\x1b[999999999L"))
+			So(term.GetText(), ShouldEqual, "x")
+			term.Feed([]byte("\x1b[2;1Hab\x1b[1;1H\x1b[999999999M"))
+			So(term.GetText(), ShouldEqual, "")
+			term.Feed([]byte("\x1b[4;1Hab\x1b[999999999T"))
+			So(term.GetText(), ShouldEqual, "")
+			So(term.screen.history, ShouldHaveLength, 4)
+			col, row = term.GetCursorPosition()
+			So(col, ShouldEqual, 2)
+			So(row, ShouldEqual, 3)
+			So(time.Since(start), ShouldBeLessThan, time.Second)
+		})
+		if runtime.GOOS == "linux" {
+			Convey("child process", func() {
+				term := NewTerminal()
+				term.SetAllocation(cdk.MakeRectangle(40, 5))
+				term.Resize()
+				exited := make(chan int, 1)
+				term.Connect(SignalChildExited, "test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+					if status, ok := argv[1].(int); ok {
+						exited <- status
+					}
+					return cdk.EVENT_PASS
+				})
+				pid, err := term.ForkCommand([]string{"sh", "-c", "stty size; exit 3"}, nil, "")
+				So(err, ShouldBeNil)
+				So(pid, ShouldBeGreaterThan, 0)
+				// output and signals are handed over to the main loop
+				timeout := time.After(5 * time.Second)
+				for done := false; !done; {
+					runMainCalls()
+					select {
+					case status := <-exited:
+						So(status, ShouldEqual, 3)
+						done = true
+					case <-timeout:
+						So("timeout", ShouldBeEmpty)
+						done = true
+					case <-time.After(10 * time.Millisecond):
+					}
+				}
+				So(term.GetText(), ShouldEqual, "5 40")
+			})
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package ctk
+
+import (
+	"fmt"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// CDK type-tag for ToggleAction objects
+const TypeToggleAction cdk.CTypeTag = "ctk-toggle-action"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeToggleAction, func() interface{} { return MakeToggleAction() })
+}
+
+// ToggleAction Hierarchy:
+//	Object
+//	  +- Action
+//	    +- ToggleAction
+//	      +- RadioAction
+//
+// A ToggleAction corresponds roughly to a CheckMenuItem. It has an
+// "active" state specifying whether the action has been checked or not.
+// Activating the action toggles the active state, proxies with a SetActive
+// method reflect the state.
+type ToggleAction interface {
+	Action
+
+	Init() (already bool)
+	Toggled()
+	SetActive(isActive bool)
+	GetActive() (value bool)
+}
+
+// The CToggleAction structure implements the ToggleAction interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with ToggleAction objects
+type CToggleAction struct {
+	CAction
+
+	toggleHandle string
+}
+
+// Default constructor for ToggleAction objects
+func MakeToggleAction() *CToggleAction {
+	return NewToggleAction("", "", "", "")
+}
+
+// Creates a new ToggleAction object. To add the action to a ActionGroup
+// and set the accelerator for the action, call
+// ActionGroup.AddActionWithAccel.
+// Parameters:
+// 	name	A unique name for the action
+// 	label	the label displayed in menu items and on buttons, or empty
+// 	tooltip	a tooltip for the action, or empty
+// 	stockId	the stock icon to display in widgets representing the
+// action, or empty
+func NewToggleAction(name string, label string, tooltip string, stockId StockID) (value *CToggleAction) {
+	t := new(CToggleAction)
+	t.Init()
+	t.initAction(name, label, tooltip, stockId)
+	return t
+}
+
+// ToggleAction object initialization. This must be called at least once to
+// setup the necessary defaults and allocate any memory structures. Calling this
+// more than once is safe though unnecessary. Only the first call will result in
+// any effect upon the ToggleAction instance
+func (t *CToggleAction) Init() (already bool) {
+	if t.InitTypeItem(TypeToggleAction, t) {
+		return true
+	}
+	t.CAction.Init()
+	_ = t.InstallBuildableProperty(PropertyActive, cdk.BoolProperty, true, false)
+	t.toggleHandle = fmt.Sprintf("%v.toggle-action", t.ObjectName())
+	t.Connect(SignalActivate, t.toggleHandle, func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+		t.SetActive(!t.GetActive())
+		return cdk.EVENT_PASS
+	})
+	return false
+}
+
+// Emits the "toggled" signal on the toggle action.
+func (t *CToggleAction) Toggled() {
+	t.Emit(SignalToggled, t)
+}
+
+// Sets the checked state on the toggle action, emitting the "toggled" signal
+// and updating the proxies of the action if the state changed.
+// Parameters:
+// 	isActive	whether the action should be checked or not
+func (t *CToggleAction) SetActive(isActive bool) {
+	if t.GetActive() == isActive {
+		return
+	}
+	if err := t.SetBoolProperty(PropertyActive, isActive); err != nil {
+		t.LogErr(err)
+		return
+	}
+	t.Toggled()
+	t.SyncProxies()
+}
+
+// Returns the checked state of the toggle action.
+func (t *CToggleAction) GetActive() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyActive); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// Connects a widget to the action object as a proxy, see
+// Action.ConnectProxy. Proxies with a SetActive method reflect the checked
+// state of the action.
+// Parameters:
+// 	proxy	the proxy widget
+func (t *CToggleAction) ConnectProxy(proxy Widget) {
+	t.CAction.ConnectProxy(proxy)
+	t.syncActive(proxy)
+}
+
+// Updates all proxies of the action with the current state of the action,
+// see Action.SyncProxies.
+func (t *CToggleAction) SyncProxies() {
+	t.CAction.SyncProxies()
+	for _, proxy := range t.proxies {
+		t.syncActive(proxy)
+	}
+}
+
+func (t *CToggleAction) syncActive(proxy Widget) {
+	if p, ok := proxy.(interface{ SetActive(isActive bool) }); ok {
+		p.SetActive(t.GetActive())
+	}
+}
+
+// Whether the toggle action should be active.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyActive cdk.Property = "active"
+
+// The "toggled" signal is emitted when the checked state of the toggle
+// action changes.
+const SignalToggled cdk.Signal = "toggled"
@@ -0,0 +1,159 @@
+package ctk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kckrinke/go-cdk"
+)
+
+// TreeModel Hierarchy:
+//	Interface
+//	  +- TreeModel
+//
+// The TreeModel interface defines a generic tree interface for use by the
+// model-driven widgets, such as IconView. It is an abstract interface, and
+// is designed to be usable with any appropriate data structure. The model is
+// represented as a hierarchical tree of strongly-typed, columned data. In
+// other words, the model can be seen as a tree where every node has
+// different values depending on which column is being queried. In order to
+// make life simpler for programmers who do not need to write their own
+// specialized model, the ListStore is provided.
+//
+// Models are accessed on a node/column level of granularity. One can query
+// for the value of a model at a certain node and a certain column on that
+// node. There are two structures used to reference a particular node in a
+// model. They are the TreePath and the TreeIter. Most of the interface
+// consists of operations on a TreeIter.
+type TreeModel interface {
+	Object
+
+	GetFlags() (value TreeModelFlags)
+	GetNColumns() (value int)
+	GetIter(path TreePath) (iter *TreeIter, ok bool)
+	GetIterFirst() (iter *TreeIter, ok bool)
+	GetPath(iter *TreeIter) (value TreePath)
+	GetValue(iter *TreeIter, column int) (value interface{})
+	IterNext(iter *TreeIter) (ok bool)
+	IterChildren(parent *TreeIter) (iter *TreeIter, ok bool)
+	IterHasChild(iter *TreeIter) (value bool)
+	IterNChildren(iter *TreeIter) (value int)
+	IterNthChild(parent *TreeIter, n int) (iter *TreeIter, ok bool)
+	IterParent(child *TreeIter) (iter *TreeIter, ok bool)
+	ForEach(fn TreeModelForeachFunc)
+}
+
+// The TreeIter is the primary structure for accessing a TreeModel. Models
+// are expected to put a unique integer in the stamp member, and put
+// model-specific data in the user data member.
+type TreeIter struct {
+	Stamp    int
+	UserData interface{}
+}
+
+// Type of the callback passed to TreeModel.ForEach to iterate over the rows
+// in a tree model. Returning TRUE stops the iteration.
+type TreeModelForeachFunc = func(model TreeModel, path TreePath, iter *TreeIter) (stop bool)
+
+// A TreePath is a list of indices, referring to a specific node within a
+// TreeModel. The string form of a path is a list of numbers separated by
+// colons, for example "0:4:2" refers to the third child of the fifth child
+// of the first root node.
+type TreePath []int
+
+// Creates a new TreePath initialized to the given indices.
+func NewTreePath(indices ...int) (path TreePath) {
+	path = make(TreePath, len(indices))
+	copy(path, indices)
+	return
+}
+
+// Creates a new TreePath initialized to path, which is expected to be a
+// colon separated list of numbers. For example, the string "10:4:0" would
+// create a path of depth 3 pointing to the 11th child of the root node, the
+// 5th child of that 11th child, and the 1st child of that 5th child.
+func NewTreePathFromString(path string) (value TreePath, err error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty tree path")
+	}
+	for _, part := range strings.Split(path, ":") {
+		var idx int
+		if idx, err = strconv.Atoi(part); err != nil || idx < 0 {
+			return nil, fmt.Errorf("invalid tree path: %v", path)
+		}
+		value = append(value, idx)
+	}
+	return
+}
+
+// Returns the depth of the path.
+func (p TreePath) GetDepth() int {
+	return len(p)
+}
+
+// Returns the current indices of the path.
+func (p TreePath) GetIndices() []int {
+	return []int(p)
+}
+
+// Compares two paths. If a appears before b in a tree, then -1 is returned.
+// If b appears before a, then 1 is returned. If the two nodes are equal,
+// then 0 is returned.
+func (p TreePath) Compare(b TreePath) int {
+	for i := 0; i < len(p) && i < len(b); i++ {
+		if p[i] < b[i] {
+			return -1
+		} else if p[i] > b[i] {
+			return 1
+		}
+	}
+	if len(p) < len(b) {
+		return -1
+	} else if len(p) > len(b) {
+		return 1
+	}
+	return 0
+}
+
+// Creates a new TreePath as a copy of the path.
+func (p TreePath) Copy() TreePath {
+	return NewTreePath(p...)
+}
+
+// Generates a string representation of the path. This string is a ':'
+// separated list of numbers. For example, "4:10:0:3" would be an acceptable
+// return value for this string.
+func (p TreePath) String() string {
+	parts := make([]string, len(p))
+	for idx, v := range p {
+		parts[idx] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ":")
+}
+
+// This signal is emitted when a row in the model has changed.
+// Listener function arguments:
+// 	path TreePath	a TreePath identifying the changed row
+// 	iter *TreeIter	a valid TreeIter pointing to the changed row
+const SignalRowChanged cdk.Signal = "row-changed"
+
+// This signal is emitted when a row has been deleted. Note that no iterator
+// is passed to the signal handler, since the row is already deleted.
+// Listener function arguments:
+// 	path TreePath	a TreePath identifying the row
+const SignalRowDeleted cdk.Signal = "row-deleted"
+
+// This signal is emitted when a new row has been inserted in the model.
+// Listener function arguments:
+// 	path TreePath	a TreePath identifying the new row
+// 	iter *TreeIter	a valid TreeIter pointing to the new row
+const SignalRowInserted cdk.Signal = "row-inserted"
+
+// This signal is emitted when the children of a node in the TreeModel have
+// been reordered.
+// Listener function arguments:
+// 	path TreePath	a TreePath identifying the tree node whose children have been reordered
+// 	iter *TreeIter	a valid TreeIter pointing to the node whose children have been reordered, or nil
+// 	newOrder []int	an array of integers mapping the current position of each child to its old position before the re-ordering
+const SignalRowsReordered cdk.Signal = "rows-reordered"
@@ -2,6 +2,7 @@ package ctk
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kckrinke/go-cdk"
 )
@@ -152,6 +153,7 @@ type Widget interface {
 	ThawChildNotify()
 	SetNoShowAll(noShowAll bool)
 	GetNoShowAll() (value bool)
+	MnemonicActivate(groupCycling bool) (value bool)
 	ListMnemonicLabels() (value cdk.CList)
 	AddMnemonicLabel(label Widget)
 	RemoveMnemonicLabel(label Widget)
@@ -214,6 +216,18 @@ type Widget interface {
 	ReleaseEventFocus()
 	GetTopParent() (parent Container)
 	GetWidgetAt(p *cdk.Point2I) Widget
+	SetInlineStyle(declarations string) (err error)
+	GetInlineStyle() (declarations string)
+	InvalidateStyle()
+	GetComputedStyle() (style *ComputedStyle)
+	AddClass(class string)
+	RemoveClass(class string)
+	HasClass(class string) (present bool)
+	ListClasses() (classes []string)
+	GetCssMargin() (margin StyleSides)
+	GetCssPadding() (padding StyleSides)
+	GetCssBorder() (border StyleSides, style BorderStyle)
+	GetCssInsets() (insets StyleSides)
 }
 
 // The CWidget structure implements the Widget interface and is exported to
@@ -223,10 +237,22 @@ type Widget interface {
 type CWidget struct {
 	CObject
 
-	parent   interface{}
-	state    StateType
-	flags    WidgetFlags
-	fcHandle string
+	parent     interface{}
+	state      StateType
+	flags      WidgetFlags
+	fcHandle   string
+	sizeGroups []SizeGroup
+	accelGroup AccelGroup
+	accelId    int
+
+	inlineSource  string
+	inlineStyle   []*StyleSheetProperty
+	modifierStyle RcStyle
+	computedStyle *ComputedStyle
+	parentStyle   *ComputedStyle
+	styleGen      uint64
+	styleDirty    bool
+	boxDefaults   styleBox
 }
 
 // CTK widget initialization. This must be called at least once to setup the
@@ -254,7 +280,7 @@ func (w *CWidget) Init() (already bool) {
 	_ = w.InstallProperty(PropertyParent, cdk.StructProperty, true, nil)
 	_ = w.InstallProperty(PropertyReceivesDefault, cdk.BoolProperty, true, false)
 	_ = w.InstallProperty(PropertySensitive, cdk.BoolProperty, true, true)
-	_ = w.InstallProperty(PropertyStyle, cdk.StructProperty, true, &CStyle{})
+	_ = w.InstallProperty(PropertyStyle, cdk.StructProperty, true, nil)
 	_ = w.InstallProperty(PropertyTooltipMarkup, cdk.StringProperty, true, "")
 	_ = w.InstallProperty(PropertyTooltipText, cdk.StringProperty, true, "")
 	_ = w.InstallProperty(PropertyVisible, cdk.BoolProperty, true, false)
@@ -262,6 +288,7 @@ func (w *CWidget) Init() (already bool) {
 	_ = w.InstallProperty(PropertyWindow, cdk.StructProperty, true, nil)
 	w.state = StateNormal
 	w.flags = NULL_WIDGET_FLAG
+	w.styleDirty = true
 	w.fcHandle = fmt.Sprintf("%v.focus-changed", w.ObjectName())
 	w.Connect(SignalLostFocus, w.fcHandle, w.handleLostFocus)
 	w.Connect(SignalGainedFocus, w.fcHandle, w.handleGainedFocus)
@@ -286,7 +313,13 @@ func (w *CWidget) GtkCallback(data interface{}) {}
 // the widget with g_object_ref. In most cases, only toplevel widgets
 // (windows) require explicit destruction, because when you destroy a
 // toplevel its children will be destroyed as well.
-func (w *CWidget) Destroy() {}
+//
+// Emits: SignalDestroyEvent, Argv=[Widget instance]
+func (w *CWidget) Destroy() {
+	self := w.getSelf()
+	GrabRemove(self)
+	w.Emit(SignalDestroyEvent, self)
+}
 
 // This function sets *widget_pointer to NULL if widget_pointer != NULL. It's
 // intended to be used as a callback connected to the "destroy" signal of a
@@ -340,6 +373,7 @@ func (w *CWidget) ShowNow() {}
 func (w *CWidget) Hide() {
 	if w.HasFlags(VISIBLE) {
 		if r := w.Emit(SignalHide, w); r == cdk.EVENT_PASS {
+			GrabRemove(w.getSelf())
 			w.UnsetFlags(VISIBLE)
 			w.Invalidate()
 		}
@@ -454,7 +488,7 @@ func (w *CWidget) RemoveAccelerator(accelGroup AccelGroup, accelKey int, accelMo
 // activated. This removes any accelerators (for any accelerator group)
 // installed by previous calls to SetAccelPath. Associating
 // accelerators with paths allows them to be modified by the user and the
-// modifications to be saved for future use. (See AccelMapSave.) This
+// modifications to be saved for future use. (See AccelMap.Save.) This
 // function is a low level function that would most likely be used by a menu
 // creation system like UIManager. If you use UIManager, setting up
 // accelerator paths will be done automatically. Even when you you aren't
@@ -463,10 +497,28 @@ func (w *CWidget) RemoveAccelerator(accelGroup AccelGroup, accelKey int, accelMo
 // interface. Note that accel_path string will be stored in a GQuark.
 // Therefore, if you pass a static string, you can save some memory by
 // interning it first with g_intern_static_string.
+// Passing an empty accel_path or a nil accel_group only removes the
+// accelerator installed previously. The widget is activated only while it is
+// sensitive, see Activate.
 // Parameters:
 // 	accelPath	path used to look up the accelerator.
 // 	accelGroup	a AccelGroup.
-func (w *CWidget) SetAccelPath(accelPath string, accelGroup AccelGroup) {}
+func (w *CWidget) SetAccelPath(accelPath string, accelGroup AccelGroup) {
+	if w.accelGroup != nil {
+		w.accelGroup.AccelDisconnect(w.accelId)
+		w.accelGroup = nil
+	}
+	if accelPath == "" || accelGroup == nil {
+		return
+	}
+	self := w.getSelf()
+	id := accelGroup.ConnectByPath(accelPath, func(argv ...interface{}) (handled bool) {
+		return self.Activate()
+	})
+	if id >= 0 {
+		w.accelGroup, w.accelId = accelGroup, id
+	}
+}
 
 // Lists the closures used by widget for accelerator group connections with
 // AccelGroupConnectByPath or AccelGroupConnect. The
@@ -600,12 +652,13 @@ func (w *CWidget) IsFocus() (value bool) {
 // Emits: SignalGainedFocus, Argv=[Widget instance, previous focus Widget instance]
 func (w *CWidget) GrabFocus() {
 	if w.CanFocus() {
-		if r := w.Emit(SignalGrabFocus, w); r == cdk.EVENT_PASS {
+		self := w.getSelf()
+		if r := w.Emit(SignalGrabFocus, self); r == cdk.EVENT_PASS {
 			tl := w.GetWindow()
 			if tl != nil {
 				var fw Widget
 				focused := tl.GetFocus()
-				tl.SetFocus(w)
+				tl.SetFocus(self)
 				if focused != nil {
 					var ok bool
 					if fw, ok = focused.(Widget); ok && fw.ObjectID() != w.ObjectID() {
@@ -614,7 +667,7 @@ func (w *CWidget) GrabFocus() {
 						}
 					}
 				}
-				if f := w.Emit(SignalGainedFocus, w, focused); f == cdk.EVENT_STOP {
+				if f := w.Emit(SignalGainedFocus, self, focused); f == cdk.EVENT_STOP {
 					if fw != nil {
 						tl.SetFocus(focused)
 					}
@@ -629,8 +682,20 @@ func (w *CWidget) GrabFocus() {
 // GTK_CAN_DEFAULT flag set; typically you have to set this flag yourself by
 // calling SetCanDefault (widget , TRUE). The default widget is
 // activated when the user presses Enter in a window. Default widgets must be
-// activatable, that is, Activate should affect them.
-func (w *CWidget) GrabDefault() {}
+// activatable, that is, Activate should affect them. A widget not yet within
+// a window keeps the HAS_DEFAULT flag and becomes the default when added to a
+// window without a default widget.
+func (w *CWidget) GrabDefault() {
+	if !w.HasFlags(CAN_DEFAULT) {
+		w.LogError("widget cannot be the default")
+		return
+	}
+	if window := w.GetWindow(); window != nil {
+		window.SetDefault(w.getSelf())
+	} else {
+		w.SetFlags(HAS_DEFAULT)
+	}
+}
 
 // Widgets can be named, which allows you to refer to them from a gtkrc file.
 // You can apply a style to widgets with a particular name in the gtkrc file.
@@ -642,6 +707,8 @@ func (w *CWidget) GrabDefault() {}
 func (w *CWidget) SetName(name string) {
 	if err := w.SetStringProperty(PropertyName, name); err != nil {
 		w.LogErr(err)
+	} else {
+		w.InvalidateStyle()
 	}
 }
 
@@ -672,6 +739,7 @@ func (w *CWidget) GetName() (value string) {
 func (w *CWidget) SetState(state StateType) {
 	if f := w.Emit(SignalSetState, w, state); f == cdk.EVENT_PASS {
 		w.state = w.state | state
+		w.InvalidateStyle()
 	}
 }
 
@@ -712,6 +780,7 @@ func (w *CWidget) SetParent(parent Container) {
 		if err := w.SetStructProperty(PropertyParent, parent); err != nil {
 			w.LogErr(err)
 		} else {
+			w.InvalidateStyle()
 			if cw, ok := parent.(Widget); parent != nil && ok && w.HasFlags(PARENT_SENSITIVE) {
 				cw.Connect(SignalLostFocus, w.fcHandle, w.handleLostFocus)
 				cw.Connect(SignalGainedFocus, w.fcHandle, w.handleGainedFocus)
@@ -871,6 +940,16 @@ func (w *CWidget) GetPointer(x int, y int) {}
 // 	TRUE if ancestor contains widget as a child, grandchild, great
 // 	grandchild, etc.
 func (w *CWidget) IsAncestor(ancestor Widget) (value bool) {
+	if ancestor == nil {
+		return false
+	}
+	var child Widget = w
+	for parent := w.GetParent(); parent != nil && parent.ObjectID() != child.ObjectID(); parent = parent.GetParent() {
+		if parent.ObjectID() == ancestor.ObjectID() {
+			return true
+		}
+		child = parent
+	}
 	return false
 }
 
@@ -932,6 +1011,8 @@ func (w *CWidget) GetStyle() (value Style) {
 	var ok bool
 	if v, err := w.GetStructProperty(PropertyStyle); err != nil {
 		w.LogErr(err)
+	} else if v == nil {
+		value = w.GetDefaultStyle()
 	} else if value, ok = v.(Style); !ok {
 		w.LogError("value stored in %v property is not of Style type: %v (%T)", PropertyStyle, v, v)
 	}
@@ -966,7 +1047,12 @@ func (w *CWidget) SetDefaultColormap(colormap cdk.Colormap) {}
 // 	should not be modified or freed.
 // 	[transfer none]
 func (w *CWidget) GetDefaultStyle() (value Style) {
-	return nil
+	defaultStyleLock.Lock()
+	defer defaultStyleLock.Unlock()
+	if defaultStyle == nil {
+		defaultStyle = NewStyle()
+	}
+	return defaultStyle
 }
 
 // Obtains the default colormap used to create widgets.
@@ -1104,9 +1190,8 @@ func (w *CWidget) GetCompositeName() (value string) {
 }
 
 // Modifies style values on the widget. Modifications made using this
-// technique take precedence over style values set via an RC file, however,
-// they will be overriden if a style is explicitely set on the widget using
-// SetStyle. The RcStyle structure is designed so each field
+// technique take precedence over all StyleSheets and the inline style of the
+// widget, see SetInlineStyle. The RcStyle structure is designed so each field
 // can either be set or unset, so it is possible, using this function, to
 // modify some style values and leave the others unchanged. Note that
 // modifications made with this function are not cumulative with previous
@@ -1118,8 +1203,16 @@ func (w *CWidget) GetCompositeName() (value string) {
 // calls to such functions ModifyFg will have a cumulative
 // effect with the initial modifications.
 // Parameters:
-// 	style	the RcStyle holding the style modifications
-func (w *CWidget) ModifyStyle(style RcStyle) {}
+// 	style	the RcStyle holding the style modifications, or nil to remove
+// all modifications
+func (w *CWidget) ModifyStyle(style RcStyle) {
+	if style == nil || style.IsEmpty() {
+		w.modifierStyle = nil
+	} else {
+		w.modifierStyle = style.Copy()
+	}
+	w.InvalidateStyle()
+}
 
 // Returns the current modifier style for the widget. (As set by
 // ModifyStyle.) If no style has previously set, a new
@@ -1137,7 +1230,10 @@ func (w *CWidget) ModifyStyle(style RcStyle) {}
 // 	you must add a refcount using g_object_ref.
 // 	[transfer none]
 func (w *CWidget) GetModifierStyle() (value RcStyle) {
-	return nil
+	if w.modifierStyle == nil {
+		w.modifierStyle = NewRcStyle()
+	}
+	return w.modifierStyle
 }
 
 // Sets the foreground color for a widget in a particular state. All other
@@ -1145,9 +1241,11 @@ func (w *CWidget) GetModifierStyle() (value RcStyle) {
 // Parameters:
 // 	state	the state for which to set the foreground color
 // 	color	the color to assign (does not need to be allocated),
-// or NULL to undo the effect of previous calls to
+// use RcStyle.UnsetColor to undo the effect of previous calls to
 // of ModifyFg.
-func (w *CWidget) ModifyFg(state StateType, color cdk.Color) {}
+func (w *CWidget) ModifyFg(state StateType, color cdk.Color) {
+	w.modifyColor(RC_FG, state, color)
+}
 
 // Sets the background color for a widget in a particular state. All other
 // style values are left untouched. See also ModifyStyle. Note
@@ -1161,9 +1259,11 @@ func (w *CWidget) ModifyFg(state StateType, color cdk.Color) {}
 // Parameters:
 // 	state	the state for which to set the background color
 // 	color	the color to assign (does not need to be allocated),
-// or NULL to undo the effect of previous calls to
+// use RcStyle.UnsetColor to undo the effect of previous calls to
 // of ModifyBg.
-func (w *CWidget) ModifyBg(state StateType, color cdk.Color) {}
+func (w *CWidget) ModifyBg(state StateType, color cdk.Color) {
+	w.modifyColor(RC_BG, state, color)
+}
 
 // Sets the text color for a widget in a particular state. All other style
 // values are left untouched. The text color is the foreground color used
@@ -1172,9 +1272,11 @@ func (w *CWidget) ModifyBg(state StateType, color cdk.Color) {}
 // Parameters:
 // 	state	the state for which to set the text color
 // 	color	the color to assign (does not need to be allocated),
-// or NULL to undo the effect of previous calls to
+// use RcStyle.UnsetColor to undo the effect of previous calls to
 // of ModifyText.
-func (w *CWidget) ModifyText(state StateType, color cdk.Color) {}
+func (w *CWidget) ModifyText(state StateType, color cdk.Color) {
+	w.modifyColor(RC_TEXT, state, color)
+}
 
 // Sets the base color for a widget in a particular state. All other style
 // values are left untouched. The base color is the background color used
@@ -1189,9 +1291,11 @@ func (w *CWidget) ModifyText(state StateType, color cdk.Color) {}
 // Parameters:
 // 	state	the state for which to set the base color
 // 	color	the color to assign (does not need to be allocated),
-// or NULL to undo the effect of previous calls to
+// use RcStyle.UnsetColor to undo the effect of previous calls to
 // of ModifyBase.
-func (w *CWidget) ModifyBase(state StateType, color cdk.Color) {}
+func (w *CWidget) ModifyBase(state StateType, color cdk.Color) {
+	w.modifyColor(RC_BASE, state, color)
+}
 
 // Sets the font to use for a widget. All other style values are left
 // untouched. See also ModifyStyle.
@@ -1205,12 +1309,22 @@ func (w *CWidget) ModifyBase(state StateType, color cdk.Color) {}
 // other style values are left untouched. See also ModifyStyle.
 // Parameters:
 // 	primary	the color to use for primary cursor (does not need to be
-// allocated), or NULL to undo the effect of previous calls to
-// of ModifyCursor.
+// allocated), use RcStyle.UnsetCursorColors to undo the effect of
+// previous calls to of ModifyCursor.
 // 	secondary	the color to use for secondary cursor (does not need to be
-// allocated), or NULL to undo the effect of previous calls to
-// of ModifyCursor.
-func (w *CWidget) ModifyCursor(primary cdk.Color, secondary cdk.Color) {}
+// allocated).
+func (w *CWidget) ModifyCursor(primary cdk.Color, secondary cdk.Color) {
+	style := w.GetModifierStyle()
+	style.SetCursorColors(primary, secondary)
+	w.ModifyStyle(style)
+}
+
+// set one color of the modifier style and apply it
+func (w *CWidget) modifyColor(component RcFlags, state StateType, color cdk.Color) {
+	style := w.GetModifierStyle()
+	style.SetColor(component, state, color)
+	w.ModifyStyle(style)
+}
 
 // Creates a new PangoContext with the appropriate font map, font
 // description, and base direction for drawing text for this widget. See also
@@ -1385,16 +1499,18 @@ func (w *CWidget) SetScrollAdjustments(hadjustment Adjustment, vadjustment Adjus
 	return false
 }
 
-// Emits the “mnemonic-activate” signal. The default handler for this
-// signal activates the widget if group_cycling is FALSE, and just grabs the
-// focus if group_cycling is TRUE.
+// Emits the “mnemonic-activate” signal. When the signal is not handled, the
+// Window activating the mnemonic activates the widget if group_cycling is
+// FALSE, and just grabs the focus if group_cycling is TRUE.
 // Parameters:
 // 	groupCycling	TRUE if there are other widgets with the same mnemonic
 // Returns:
 // 	TRUE if the signal has been handled
-// func (w *CWidget) MnemonicActivate(groupCycling bool) (value bool) {
-// 	return false
-// }
+//
+// Emits: SignalMnemonicActivate, Argv=[Widget instance, groupCycling]
+func (w *CWidget) MnemonicActivate(groupCycling bool) (value bool) {
+	return w.Emit(SignalMnemonicActivate, w, groupCycling) == cdk.EVENT_STOP
+}
 
 // Installs a style property on a widget class. The parser for the style
 // property is determined by the value type of pspec .
@@ -1540,8 +1656,13 @@ func (w *CWidget) SendFocusChange(event cdk.Event) (value bool) {
 // 	direction	direction of focus movement
 // Returns:
 // 	TRUE if focus ended up inside widget
+//
+// Emits: SignalFocus, Argv=[Widget instance, direction]
 func (w *CWidget) ChildFocus(direction DirectionType) (value bool) {
-	return false
+	if !w.IsVisible() || !w.IsSensitive() {
+		return false
+	}
+	return w.Emit(SignalFocus, w, direction) == cdk.EVENT_STOP
 }
 
 // Emits a “child-notify” signal for the on widget . This is the analogue
@@ -1664,7 +1785,9 @@ func (w *CWidget) HasScreen() (value bool) {
 // indicates that that dimension has not been set explicitly and the natural
 // requisition of the widget will be used intead. See
 // SetSizeRequest. To get the size a widget will actually use,
-// call SizeRequest instead of this function.
+// call SizeRequest instead of this function. If the widget belongs to any
+// SizeGroup, the maximum size requested within each group is reported in
+// place of smaller explicit values.
 // Parameters:
 // 	width	return location for width, or NULL.
 // 	height	return location for height, or NULL.
@@ -1676,9 +1799,36 @@ func (w *CWidget) GetSizeRequest() (width, height int) {
 	if height, err = w.GetIntProperty(PropertyHeightRequest); err != nil {
 		w.LogErr(err)
 	}
+	for _, group := range w.sizeGroups {
+		gw, gh := group.GetSizeRequest()
+		if gw > width {
+			width = gw
+		}
+		if gh > height {
+			height = gh
+		}
+	}
 	return
 }
 
+func (w *CWidget) addSizeGroup(group SizeGroup) {
+	for _, g := range w.sizeGroups {
+		if g.ObjectID() == group.ObjectID() {
+			return
+		}
+	}
+	w.sizeGroups = append(w.sizeGroups, group)
+}
+
+func (w *CWidget) removeSizeGroup(group SizeGroup) {
+	for idx, g := range w.sizeGroups {
+		if g.ObjectID() == group.ObjectID() {
+			w.sizeGroups = append(w.sizeGroups[:idx], w.sizeGroups[idx+1:]...)
+			return
+		}
+	}
+}
+
 // Returns the currently requested size
 func (w *CWidget) SizeRequest() cdk.Rectangle {
 	return cdk.MakeRectangle(w.GetSizeRequest())
@@ -1813,32 +1963,43 @@ func (w *CWidget) ErrorBell() {}
 
 // This function should be called whenever keyboard navigation within a
 // single widget hits a boundary. The function emits the “keynav-failed”
-// signal on the widget and its return value should be interpreted in a way
-// similar to the return value of ChildFocus: When TRUE is
-// returned, stay in the widget, the failed keyboard navigation is Ok and/or
-// there is nowhere we can/should move the focus to. When FALSE is returned,
-// the caller should continue with keyboard navigation outside the widget,
-// e.g. by calling ChildFocus on the widget's toplevel. The
-// default ::keynav-failed handler returns TRUE for GTK_DIR_TAB_FORWARD and
-// GTK_DIR_TAB_BACKWARD. For the other values of DirectionType, it looks
-// at the “gtk-keynav-cursor-only” setting and returns FALSE if the
-// setting is TRUE. This way the entire user interface becomes
-// cursor-navigatable on input devices such as mobile phones which only have
-// cursor keys but no tab key. Whenever the default handler returns TRUE, it
-// also calls ErrorBell to notify the user of the failed
-// keyboard navigation. A use case for providing an own implementation of
-// ::keynav-failed (either by connecting to it or by overriding it) would be
-// a row of Entry widgets where the user should be able to navigate the
-// entire row with the cursor keys, as e.g. known from user interfaces that
-// require entering license keys.
+// signal on the widget and, while not handled, on each of its ancestors. Its
+// return value should be interpreted in a way similar to the return value of
+// ChildFocus: When TRUE is returned, stay in the widget, the failed keyboard
+// navigation is Ok and/or there is nowhere we can/should move the focus to.
+// When FALSE is returned, the caller should continue with keyboard
+// navigation, e.g. by wrapping around to the far side of the Window. When no
+// listener handles the signal, FALSE is returned for GTK_DIR_TAB_FORWARD and
+// GTK_DIR_TAB_BACKWARD. For the other values of DirectionType, FALSE is
+// returned if the “ctk-keynav-wrap-around” setting is TRUE, otherwise
+// ErrorBell is called and TRUE is returned. A use case for connecting to
+// ::keynav-failed would be a row of Entry widgets where the user should be
+// able to navigate the entire row with the cursor keys, as e.g. known from
+// user interfaces that require entering license keys.
 // Parameters:
 // 	direction	direction of focus movement
 // Returns:
 // 	TRUE if stopping keyboard navigation is fine, FALSE if the
 // 	emitting widget should try to handle the keyboard navigation
 // 	attempt in its parent container(s).
+//
+// Emits: SignalKeynavFailed, Argv=[Widget instance, direction]
 func (w *CWidget) KeynavFailed(direction DirectionType) (value bool) {
-	return false
+	if f := w.Emit(SignalKeynavFailed, w, direction); f == cdk.EVENT_STOP {
+		return true
+	}
+	if parent := w.GetParent(); parent != nil && parent.ObjectID() != w.ObjectID() {
+		return parent.KeynavFailed(direction)
+	}
+	switch direction {
+	case DIR_TAB_FORWARD, DIR_TAB_BACKWARD:
+		return false
+	}
+	if GetDefaultSettings().GetKeynavWrapAround() {
+		return false
+	}
+	w.ErrorBell()
+	return true
 }
 
 // Gets the contents of the tooltip for widget .
@@ -2042,11 +2203,7 @@ func (w *CWidget) GetAppPaintable() (value bool) {
 // Returns:
 // 	TRUE if widget can be a default widget, FALSE otherwise
 func (w *CWidget) GetCanDefault() (value bool) {
-	var err error
-	if value, err = w.GetBoolProperty(PropertyCanDefault); err != nil {
-		w.LogErr(err)
-	}
-	return
+	return w.HasFlags(CAN_DEFAULT)
 }
 
 // Specifies whether widget can be a default widget. See
@@ -2057,7 +2214,19 @@ func (w *CWidget) GetCanDefault() (value bool) {
 func (w *CWidget) SetCanDefault(canDefault bool) {
 	if err := w.SetBoolProperty(PropertyCanDefault, canDefault); err != nil {
 		w.LogErr(err)
+		return
 	}
+	if canDefault {
+		w.SetFlags(CAN_DEFAULT)
+		return
+	}
+	if w.HasFlags(HAS_DEFAULT) {
+		if window := w.GetWindow(); window != nil {
+			window.SetDefault(nil)
+		}
+		w.UnsetFlags(HAS_DEFAULT)
+	}
+	w.UnsetFlags(CAN_DEFAULT)
 }
 
 // Determines whether widget can own the input focus. See
@@ -2185,7 +2354,7 @@ func (w *CWidget) SetVisible(visible bool) {
 // 	TRUE if widget is the current default widget within its
 // 	toplevel, FALSE otherwise
 func (w *CWidget) HasDefault() (value bool) {
-	return false
+	return w.HasFlags(HAS_DEFAULT)
 }
 
 // Determines if the widget has the global input focus. See
@@ -2203,7 +2372,7 @@ func (w *CWidget) HasFocus() (value bool) {
 // Returns:
 // 	TRUE if the widget is in the grab_widgets stack
 func (w *CWidget) HasGrab() (value bool) {
-	return false
+	return grabStackHas(w.ObjectID())
 }
 
 // Determines if the widget style has been looked up through the rc
@@ -2261,6 +2430,10 @@ func (w *CWidget) SetWindow(window Window) {
 func (w *CWidget) SetReceivesDefault(receivesDefault bool) {
 	if err := w.SetBoolProperty(PropertyReceivesDefault, receivesDefault); err != nil {
 		w.LogErr(err)
+	} else if receivesDefault {
+		w.SetFlags(RECEIVES_DEFAULT)
+	} else {
+		w.UnsetFlags(RECEIVES_DEFAULT)
 	}
 }
 
@@ -2271,11 +2444,7 @@ func (w *CWidget) SetReceivesDefault(receivesDefault bool) {
 // 	TRUE if widget acts as default widget when focussed, FALSE
 // 	otherwise
 func (w *CWidget) GetReceivesDefault() (value bool) {
-	var err error
-	if value, err = w.GetBoolProperty(PropertyReceivesDefault); err != nil {
-		w.LogErr(err)
-	}
-	return
+	return w.HasFlags(RECEIVES_DEFAULT)
 }
 
 // Marks the widget as being realized. This function should only ever be
@@ -2327,12 +2496,265 @@ func (w *CWidget) GetMapped() (value bool) {
 // 	requisition	a Requisition
 // func (w *CWidget) RequisitionFree(requisition Requisition) {}
 
+// Sets the inline style of the Widget, a list of CSS declarations such as
+// "color: red; bold: true" which take precedence over all StyleSheets.
+// Passing an empty string clears the inline style.
+func (w *CWidget) SetInlineStyle(declarations string) (err error) {
+	var properties []*StyleSheetProperty
+	if properties, err = parseInlineStyle(declarations); err != nil {
+		return
+	}
+	w.inlineSource = declarations
+	w.inlineStyle = properties
+	w.InvalidateStyle()
+	return
+}
+
+// Returns the inline style declarations set with SetInlineStyle.
+func (w *CWidget) GetInlineStyle() (declarations string) {
+	return w.inlineSource
+}
+
+// Flags the computed style of the Widget as out of date. The style is
+// recomputed the next time it is requested, along with the styles of all
+// descendants as their parent style will have changed. Widgets invalidate
+// their style when their name, state or parent changes, custom Widget
+// implementations should call this when anything else a selector may match
+// upon changes.
+func (w *CWidget) InvalidateStyle() {
+	if !w.styleDirty {
+		w.styleDirty = true
+		w.Invalidate()
+	}
+}
+
+// Returns the cascaded style properties of the Widget, computed from all
+// registered StyleSheets, the inline style, the modifier style (see
+// ModifyStyle) and those properties inherited from the parent Widget. The
+// computed values are also stored in the CSS properties of the Widget, see
+// GetCssColor and friends.
+func (w *CWidget) GetComputedStyle() (style *ComputedStyle) {
+	var parentStyle *ComputedStyle
+	if parent := w.GetParent(); parent != nil && parent.ObjectID() != w.ObjectID() {
+		parentStyle = parent.GetComputedStyle()
+	}
+	generation := getStyleGeneration()
+	if w.computedStyle == nil || w.styleDirty || w.styleGen != generation || w.parentStyle != parentStyle {
+		inline := w.inlineStyle
+		if w.modifierStyle != nil {
+			inline = append(append([]*StyleSheetProperty{}, inline...), w.modifierStyle.GetProperties(w.GetState())...)
+		}
+		w.computedStyle = computeStyle(w, parentStyle, inline)
+		w.parentStyle = parentStyle
+		w.styleGen = generation
+		w.styleDirty = false
+		w.applyComputedStyle(w.computedStyle)
+	}
+	return w.computedStyle
+}
+
+// Adds a style class to the Widget, so that style sheet rules with a selector
+// such as ".destructive-action" apply to the Widget. Class names are case
+// sensitive and adding a class already present does nothing.
+func (w *CWidget) AddClass(class string) {
+	class = strings.TrimSpace(class)
+	if class == "" || w.HasClass(class) {
+		return
+	}
+	w.setClasses(append(w.ListClasses(), class))
+}
+
+// Removes a style class previously added with AddClass. Removing a class
+// which is not present does nothing.
+func (w *CWidget) RemoveClass(class string) {
+	classes := w.ListClasses()
+	for idx, c := range classes {
+		if c == class {
+			w.setClasses(append(classes[:idx], classes[idx+1:]...))
+			return
+		}
+	}
+}
+
+// Returns TRUE if the Widget currently has the given style class.
+func (w *CWidget) HasClass(class string) (present bool) {
+	for _, c := range w.ListClasses() {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the style classes of the Widget, in the order added.
+func (w *CWidget) ListClasses() (classes []string) {
+	if value, err := w.GetCssString(PropertyClass); err == nil {
+		classes = strings.Fields(value)
+	}
+	return
+}
+
+// Returns the margin of the Widget, the space outside of the border which the
+// Widget leaves blank. The margin is set with the "margin" CSS property.
+func (w *CWidget) GetCssMargin() (margin StyleSides) {
+	return w.getSelfStyleBox().margin
+}
+
+// Returns the padding of the Widget, the space between the border and the
+// content. The padding is set with the "padding" CSS property, Widgets such as
+// Button have a default padding when none is declared and the padding
+// properties of Misc and Alignment are added to it.
+func (w *CWidget) GetCssPadding() (padding StyleSides) {
+	return w.getSelfStyleBox().padding
+}
+
+// Returns the width of each side of the border and the style the border is
+// drawn with, as set by the "border-width" and "border-style" CSS properties.
+// Widgets such as Button and Frame have a single line border when none is
+// declared, a border-style of "none" has no width.
+func (w *CWidget) GetCssBorder() (border StyleSides, style BorderStyle) {
+	box := w.getSelfStyleBox()
+	return box.border, box.style
+}
+
+// Returns the total space on each side of the Widget taken by the margin,
+// border and padding. Widgets lay out their content within their allocation
+// less these insets and add them to their natural size request.
+func (w *CWidget) GetCssInsets() (insets StyleSides) {
+	return w.getSelfStyleBox().insets()
+}
+
+// returns the box model of the outermost instance embedding this CWidget, so
+// the padding added by types such as Misc and Alignment is included
+func (w *CWidget) getSelfStyleBox() (box styleBox) {
+	if sb, ok := w.getSelf().(interface{ getStyleBox() styleBox }); ok {
+		return sb.getStyleBox()
+	}
+	return w.getStyleBox()
+}
+
+// set the padding and border style used when no stylesheet declares any
+func (w *CWidget) setStyleBoxDefaults(padding StyleSides, style BorderStyle) {
+	w.boxDefaults.padding = padding
+	w.boxDefaults.style = style
+}
+
+// returns the box model of the Widget from the computed style, falling back to
+// the defaults of the Widget for any properties not declared
+func (w *CWidget) getStyleBox() (box styleBox) {
+	box = w.boxDefaults
+	w.GetComputedStyle()
+	sides := func(name cdk.Property, sides *StyleSides) {
+		if value, err := w.GetCssString(name); err == nil && value != "" {
+			if parsed, err := parseStyleSides(value); err != nil {
+				w.LogError("%v css property: %v", name, err)
+			} else {
+				*sides = parsed
+			}
+		}
+	}
+	if value, err := w.GetCssString(PropertyBorderStyle); err == nil && value != "" {
+		if style, err := BorderStyle(0).FromString(value); err != nil {
+			w.LogErr(err)
+		} else {
+			box.style = style.(BorderStyle)
+		}
+	}
+	if box.style != BorderStyleNone {
+		box.border = MakeStyleSides(1)
+	}
+	sides(PropertyMargin, &box.margin)
+	sides(PropertyPadding, &box.padding)
+	sides(PropertyBorderWidth, &box.border)
+	if box.style == BorderStyleNone {
+		box.border = StyleSides{}
+	}
+	return
+}
+
+// classes are stored in the class css property, where selectors look for them
+func (w *CWidget) setClasses(classes []string) {
+	if property := w.GetCssProperty(PropertyClass); property != nil {
+		if err := property.Set(strings.Join(classes, " ")); err != nil {
+			w.LogErr(err)
+			return
+		}
+		w.InvalidateStyle()
+	}
+}
+
+// store the computed style in the CSS properties, resetting any properties
+// which are no longer declared to their defaults. The class property is not a
+// style property and is left alone
+func (w *CWidget) applyComputedStyle(style *ComputedStyle) {
+	for name, property := range w.css {
+		if name == PropertyClass {
+			continue
+		}
+		if declared := style.Get(name); declared != nil {
+			if value, err := parseCssPropertyValue(property.Type(), declared.Value); err != nil {
+				w.LogError("%v css property: %v", name, err)
+			} else if err := property.Set(value); err != nil {
+				w.LogErr(err)
+			}
+		} else if err := property.Set(property.Default()); err != nil {
+			w.LogErr(err)
+		}
+	}
+}
+
+// overlay the computed style onto the given theme, only those properties
+// declared by a StyleSheet (or inherited from a parent's declaration) are
+// applied so the theme given remains the default for everything else
+func (w *CWidget) styleTheme(theme cdk.Theme) cdk.Theme {
+	style := w.GetComputedStyle()
+	if len(style.Properties) == 0 {
+		return theme
+	}
+	theme.Content.Normal = w.styleApply(theme.Content.Normal, style, false)
+	theme.Content.Focused = w.styleApply(theme.Content.Focused, style, false)
+	theme.Content.Active = w.styleApply(theme.Content.Active, style, false)
+	theme.Border.Normal = w.styleApply(theme.Border.Normal, style, true)
+	theme.Border.Focused = w.styleApply(theme.Border.Focused, style, true)
+	theme.Border.Active = w.styleApply(theme.Border.Active, style, true)
+	return theme
+}
+
+func (w *CWidget) styleApply(s cdk.Style, style *ComputedStyle, border bool) cdk.Style {
+	color := func(name cdk.Property, fn func(c cdk.Color) cdk.Style) {
+		if style.Get(name) != nil {
+			if c, err := w.GetCssColor(name); err == nil {
+				s = fn(c)
+			}
+		}
+	}
+	attr := func(name cdk.Property, fn func(v bool) cdk.Style) {
+		if style.Get(name) != nil {
+			if v, err := w.GetCssBool(name); err == nil {
+				s = fn(v)
+			}
+		}
+	}
+	color(PropertyColor, func(c cdk.Color) cdk.Style { return s.Foreground(c) })
+	color(PropertyBackgroundColor, func(c cdk.Color) cdk.Style { return s.Background(c) })
+	attr(PropertyBold, func(v bool) cdk.Style { return s.Bold(v) })
+	attr(PropertyBlink, func(v bool) cdk.Style { return s.Blink(v) })
+	attr(PropertyReverse, func(v bool) cdk.Style { return s.Reverse(v) })
+	attr(PropertyUnderline, func(v bool) cdk.Style { return s.Underline(v) })
+	attr(PropertyDim, func(v bool) cdk.Style { return s.Dim(v) })
+	if border {
+		color(PropertyBorderColor, func(c cdk.Color) cdk.Style { return s.Foreground(c) })
+		color(PropertyBorderBackgroundColor, func(c cdk.Color) cdk.Style { return s.Background(c) })
+	}
+	return s
+}
+
 // Returns the current theme, adjusted for Widget focus and accounting for
 // any PARENT_SENSITIVE conditions. This method is primarily useful in drawable
 // Widget types during the Invalidate() and Draw() stages of the Widget
 // lifecycle
 func (w *CWidget) GetThemeRequest() (theme cdk.Theme) {
-	theme = w.GetTheme()
+	theme = w.styleTheme(w.GetTheme())
 	if (w.CanFocus() && w.IsFocused()) || w.IsParentFocused() {
 		theme.Content.Normal = theme.Content.Focused
 		theme.Border.Normal = theme.Border.Focused
@@ -2368,7 +2790,20 @@ func (w *CWidget) HasState(s StateType) bool {
 func (w *CWidget) UnsetState(v StateType) {
 	if f := w.Emit(SignalUnsetState, w, v); f == cdk.EVENT_PASS {
 		w.state = w.state &^ v
+		w.InvalidateStyle()
+	}
+}
+
+// set or unset the prelight state of an ancestor of the hovered Widget, the
+// style is recomputed the next time it is requested without invalidating the
+// Widget, see Window.updateHoverState
+func (w *CWidget) setPrelight(prelight bool) {
+	if prelight {
+		w.state = w.state | StatePrelight
+	} else {
+		w.state = w.state &^ StatePrelight
 	}
+	w.styleDirty = true
 }
 
 // Returns the current flags for the Widget instance
@@ -2376,6 +2811,15 @@ func (w *CWidget) GetFlags() WidgetFlags {
 	return w.flags
 }
 
+// returns the outermost instance embedding this CWidget, for the methods of
+// embedded types to dispatch through, see InitTypeItem
+func (w *CWidget) getSelf() Widget {
+	if self, ok := w.self.(Widget); ok {
+		return self
+	}
+	return w
+}
+
 // Returns TRUE if the Widget instance has the given flag, FALSE otherwise
 func (w *CWidget) HasFlags(f WidgetFlags) bool {
 	return w.flags.HasBit(f)
@@ -2524,6 +2968,7 @@ func (w *CWidget) GetWidgetAt(p *cdk.Point2I) Widget {
 }
 
 func (w *CWidget) handleLostFocus(_ []interface{}, _ ...interface{}) cdk.EventFlag {
+	w.InvalidateStyle()
 	theme := w.GetTheme()
 	w.SetThemeRequest(theme)
 	w.Invalidate()
@@ -2531,6 +2976,7 @@ func (w *CWidget) handleLostFocus(_ []interface{}, _ ...interface{}) cdk.EventFl
 }
 
 func (w *CWidget) handleGainedFocus(_ []interface{}, _ ...interface{}) cdk.EventFlag {
+	w.InvalidateStyle()
 	theme := w.GetTheme()
 	theme.Content.Normal = theme.Content.Focused
 	theme.Border.Normal = theme.Border.Focused
@@ -2887,12 +3333,11 @@ const SignalGrabBrokenEvent cdk.Signal = "grab-broken-event"
 
 const SignalGrabFocus cdk.Signal = "grab-focus"
 
-// The ::grab-notify signal is emitted when a widget becomes shadowed by a
-// CTK grab (not a pointer or keyboard grab) on another widget, or when it
-// becomes unshadowed due to a grab being removed. A widget is shadowed by a
-// GrabAdd when the topmost grab widget in the grab stack of its
-// window group is not its ancestor.
+// The ::grab-notify signal is emitted when a grab widget becomes shadowed by
+// a GrabAdd on another widget, or when it becomes unshadowed due to the grab
+// above it being removed with GrabRemove.
 // Listener function arguments:
+//      widget Widget the grab widget
 //      wasGrabbed bool FALSE if the widget becomes shadowed, TRUE if it becomes unshadowed
 const SignalGrabNotify cdk.Signal = "grab-notify"
 
@@ -2918,6 +3363,8 @@ const SignalKeyReleaseEvent cdk.Signal = "key-release-event"
 
 // Gets emitted if keyboard navigation fails. See KeynavFailed
 // for details.
+// Listener function arguments:
+//      direction DirectionType the direction of movement
 const SignalKeynavFailed cdk.Signal = "keynav-failed"
 
 // The ::leave-notify-event will be emitted when the pointer leaves the
@@ -2935,6 +3382,11 @@ const SignalMap cdk.Signal = "map"
 // automatically for all new windows.
 const SignalMapEvent cdk.Signal = "map-event"
 
+// The ::mnemonic-activate signal is emitted when a mnemonic of the widget is
+// activated. Listeners return EVENT_STOP to prevent the default activation
+// or focusing of the widget.
+// Listener function arguments:
+//      groupCycling bool       TRUE if other widgets share the mnemonic
 const SignalMnemonicActivate cdk.Signal = "mnemonic-activate"
 
 // The ::motion-notify-event signal is emitted when the pointer moves over
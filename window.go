@@ -2,6 +2,7 @@ package ctk
 
 import (
 	"fmt"
+	"unicode"
 
 	"github.com/kckrinke/go-cdk"
 )
@@ -25,6 +26,16 @@ func init() {
 		}
 		return ErrFallthrough
 	}
+	bindings := BindingSetByType(TypeWindow)
+	bindings.AddSignal(cdk.KeyTab, cdk.ModNone, SignalMoveFocus, DIR_TAB_FORWARD)
+	bindings.AddSignal(cdk.KeyTab, cdk.ModShift, SignalMoveFocus, DIR_TAB_BACKWARD)
+	bindings.AddSignal(cdk.KeyBacktab, cdk.ModNone, SignalMoveFocus, DIR_TAB_BACKWARD)
+	bindings.AddSignal(cdk.KeyBacktab, cdk.ModShift, SignalMoveFocus, DIR_TAB_FORWARD)
+	bindings.AddSignal(cdk.KeyUp, cdk.ModNone, SignalMoveFocus, DIR_UP)
+	bindings.AddSignal(cdk.KeyDown, cdk.ModNone, SignalMoveFocus, DIR_DOWN)
+	bindings.AddSignal(cdk.KeyLeft, cdk.ModNone, SignalMoveFocus, DIR_LEFT)
+	bindings.AddSignal(cdk.KeyRight, cdk.ModNone, SignalMoveFocus, DIR_RIGHT)
+	bindings.AddSignal(cdk.KeyEnter, cdk.ModNone, SignalActivateDefault)
 }
 
 // Window Hierarchy:
@@ -100,6 +111,7 @@ type Window interface {
 	GetDestroyWithParent() (value bool)
 	GetMnemonicModifier() (value cdk.ModMask)
 	GetModal() (value bool)
+	GetWindowType() (value WindowType)
 	GetPosition(rootX int, rootY int)
 	GetRole() (value string)
 	GetSize() (width, height int)
@@ -120,6 +132,7 @@ type Window interface {
 	SetOpacity(opacity float64)
 	GetMnemonicsVisible() (value bool)
 	SetMnemonicsVisible(setting bool)
+	GetMnemonicsShown() (shown bool)
 	GetDisplayManager() (dm cdk.DisplayManager)
 	SetDisplayManager(dm cdk.DisplayManager)
 	GetVBox() (vbox VBox)
@@ -133,6 +146,10 @@ type Window interface {
 	GetThemeRequest() (theme cdk.Theme)
 	Invalidate() cdk.EventFlag
 	Draw(canvas cdk.Canvas) cdk.EventFlag
+	AddStyleProvider(provider CssProvider, priority StyleProviderPriority)
+	RemoveStyleProvider(provider CssProvider)
+	LoadStyleSheetFromString(source string) (err error)
+	LoadStyleSheetFromFile(path string) (err error)
 }
 
 // The CWindow structure implements the Window interface and is
@@ -149,9 +166,12 @@ type CWindow struct {
 	focused        interface{}
 	eventFocus     interface{}
 	hoverFocus     Widget
-	accelGroups    []*CAccelGroup
+	defaultWidget  Widget
+	accelGroups    []AccelGroup
 	mnemonics      []*mnemonicEntry
 	mnemonicMod    cdk.ModMask
+	mnemonicsHeld  bool
+	styleProvider  *CCssProvider
 }
 
 type mnemonicEntry struct {
@@ -196,9 +216,10 @@ func (w *CWindow) Init() (already bool) {
 	w.origin.X = 0
 	w.origin.Y = 0
 	w.SetTheme(cdk.DefaultColorTheme)
+	initHotReload()
 	w.SetParent(w)
 	w.SetWindow(w)
-	w.accelGroups = make([]*CAccelGroup, 0)
+	w.accelGroups = make([]AccelGroup, 0)
 	w.mnemonics = make([]*mnemonicEntry, 0)
 	w.mnemonicMod = cdk.ModAlt
 	_ = w.InstallProperty(PropertyAcceptFocus, cdk.BoolProperty, true, true)
@@ -231,6 +252,9 @@ func (w *CWindow) Init() (already bool) {
 	w.canvas = cdk.NewCanvas(cdk.Point2I{}, cdk.Rectangle{}, cdk.DefaultColorTheme.Content.Normal)
 	_ = w.GetVBox()
 	w.hoverFocus = nil
+	w.Connect(SignalMoveFocus, fmt.Sprintf("%v.move-focus", w.ObjectName()), w.handleMoveFocus)
+	w.Connect(SignalActivateDefault, fmt.Sprintf("%v.activate-default", w.ObjectName()), w.handleActivateDefault)
+	w.Connect(SignalDestroyEvent, fmt.Sprintf("%v.destroy-style", w.ObjectName()), w.handleDestroyStyle)
 	w.Invalidate()
 	return false
 }
@@ -319,18 +343,48 @@ func (w *CWindow) GetResizable() (value bool) {
 	return
 }
 
-// Associate accel_group with window , such that calling
-// AccelGroupsActivate on window will activate accelerators in
-// accel_group .
+// Associate accel_group with window , such that key events received by the
+// window activate the accelerators in accel_group , before the focused
+// widget receives the event. Accelerator groups added later take precedence
+// over those added earlier. Adding a group already associated has no effect.
 // Parameters:
 // 	window	window to attach accelerator group to
 // 	accelGroup	a AccelGroup
-func (w *CWindow) AddAccelGroup(accelGroup AccelGroup) {}
+func (w *CWindow) AddAccelGroup(accelGroup AccelGroup) {
+	for _, group := range w.accelGroups {
+		if group.ObjectID() == accelGroup.ObjectID() {
+			return
+		}
+	}
+	w.accelGroups = append(w.accelGroups, accelGroup)
+}
 
 // Reverses the effects of AddAccelGroup.
 // Parameters:
 // 	accelGroup	a AccelGroup
-func (w *CWindow) RemoveAccelGroup(accelGroup AccelGroup) {}
+func (w *CWindow) RemoveAccelGroup(accelGroup AccelGroup) {
+	for idx, group := range w.accelGroups {
+		if group.ObjectID() == accelGroup.ObjectID() {
+			w.accelGroups = append(w.accelGroups[:idx], w.accelGroups[idx+1:]...)
+			return
+		}
+	}
+}
+
+// activate the accelerators of the associated accelerator groups matching the
+// key event, most recently added groups first
+func (w *CWindow) activateAccelGroups(event *cdk.EventKey) (activated bool) {
+	key, mods := accelEventKey(event)
+	if key == 0 {
+		return false
+	}
+	for idx := len(w.accelGroups) - 1; idx >= 0; idx-- {
+		if w.accelGroups[idx].AccelGroupActivate(w, key, mods) {
+			return true
+		}
+	}
+	return false
+}
 
 // Activates the current focused widget within the window.
 // Returns:
@@ -348,10 +402,21 @@ func (w *CWindow) ActivateFocus() (value bool) {
 // Activates the default widget for the window, unless the current focused
 // widget has been configured to receive the default action (see
 // WidgetSetReceivesDefault), in which case the focused widget is
+// activated. Widgets which are not sensitive or not visible are not
 // activated.
 // Returns:
 // 	TRUE if a widget got activated.
 func (w *CWindow) ActivateDefault() (value bool) {
+	if focused, ok := w.GetFocus().(Widget); ok && focused.GetReceivesDefault() {
+		if focused.IsSensitive() && focused.IsVisible() {
+			focused.Activate()
+			return true
+		}
+	}
+	if dw := w.defaultWidget; dw != nil && dw.IsSensitive() && dw.IsVisible() && grabContains(w.getWithinGrab(), dw) {
+		dw.Activate()
+		return true
+	}
 	return false
 }
 
@@ -362,12 +427,42 @@ func (w *CWindow) ActivateDefault() (value bool) {
 // lowering the dialog below the parent.
 // Parameters:
 // 	modal	whether the window is modal
+//
+// A visible modal window holds a grab, see GrabAdd, receiving all key and
+// mouse events until it is hidden or made non-modal.
 func (w *CWindow) SetModal(modal bool) {
 	if err := w.SetBoolProperty(PropertyModal, modal); err != nil {
 		w.LogErr(err)
+	} else if !modal {
+		GrabRemove(w)
+	} else if w.IsVisible() {
+		GrabAdd(w)
 	}
 }
 
+// Shows the window, adding a grab for modal windows. See SetModal.
+func (w *CWindow) Show() {
+	w.CBin.Show()
+	if w.GetModal() {
+		GrabAdd(w)
+	}
+}
+
+// Shows the window and all of its children, adding a grab for modal windows.
+// See SetModal.
+func (w *CWindow) ShowAll() {
+	w.CBin.ShowAll()
+	if w.GetModal() {
+		GrabAdd(w)
+	}
+}
+
+// Hides the window, removing any grab held by the window.
+func (w *CWindow) Hide() {
+	GrabRemove(w)
+	w.CBin.Hide()
+}
+
 // This function sets up hints about how a window can be resized by the user.
 // You can set a minimum and maximum size; allowed resize increments (e.g.
 // for xterm, you can only resize by the size of a character); aspect ratios;
@@ -547,12 +642,11 @@ func (w *CWindow) RemoveMnemonic(keyval rune, target interface{}) {
 		var mnemonics []*mnemonicEntry
 		for _, entry := range w.mnemonics {
 			if entry.key == keyval {
-				if widget, ok := entry.target.(Widget); ok {
-					if widget.ObjectID() != tw.ObjectID() {
-						mnemonics = append(mnemonics, entry)
-					}
+				if widget, ok := entry.target.(Widget); ok && widget.ObjectID() == tw.ObjectID() {
+					continue
 				}
 			}
+			mnemonics = append(mnemonics, entry)
 		}
 		w.mnemonics = mnemonics
 	} else {
@@ -579,23 +673,62 @@ func (w *CWindow) RemoveWidgetMnemonics(target interface{}) {
 	}
 }
 
-// Activates the targets associated with the mnemonic.
+// Activates the targets associated with the mnemonic. When a single
+// sensitive and visible widget has the mnemonic, the mnemonic-activate signal
+// is emitted on it and, when not handled, the widget is activated or focused.
+// When several widgets share the mnemonic, the focus cycles through them with
+// each activation instead. The letter case of keyval is ignored.
 // Parameters:
 // 	keyval	the mnemonic
 // 	modifier	the modifiers
 // 	returns	TRUE if the activation is done.
 func (w *CWindow) MnemonicActivate(keyval rune, modifier cdk.ModMask) (activated bool) {
-	if modifier == w.mnemonicMod {
-		for _, entry := range w.mnemonics {
-			if entry.key == keyval {
-				if sa, ok := entry.target.(Widget); ok && sa.IsSensitive() && sa.IsVisible() {
-					sa.Activate()
-					return true
-				}
+	if modifier != w.mnemonicMod {
+		return
+	}
+	keyval = unicode.ToLower(keyval)
+	grab := w.getWithinGrab()
+	var targets []Widget
+	for _, entry := range w.mnemonics {
+		if entry.key == keyval {
+			if target, ok := entry.target.(Widget); ok && target.IsSensitive() && target.IsVisible() && grabContains(grab, target) {
+				targets = append(targets, target)
 			}
 		}
 	}
-	return
+	switch len(targets) {
+	case 0:
+		return false
+	case 1:
+		return mnemonicActivate(targets[0], false)
+	}
+	// several widgets share the mnemonic, move on from the focused one
+	next := 0
+	if focused, ok := w.GetFocus().(Widget); ok && w.focused != nil {
+		for idx, target := range targets {
+			if focused.ObjectID() == target.ObjectID() || focused.IsAncestor(target) {
+				next = (idx + 1) % len(targets)
+				break
+			}
+		}
+	}
+	return mnemonicActivate(targets[next], true)
+}
+
+// emit the mnemonic-activate signal on the target and when not handled,
+// activate the target unless group cycling, grabbing the focus otherwise
+func mnemonicActivate(target Widget, groupCycling bool) bool {
+	if target.MnemonicActivate(groupCycling) {
+		return true
+	}
+	if !groupCycling && target.Activate() {
+		return true
+	}
+	if target.CanFocus() {
+		target.GrabFocus()
+		return true
+	}
+	return false
 }
 
 // Activates mnemonics and accelerators for this Window. This is normally
@@ -607,7 +740,15 @@ func (w *CWindow) MnemonicActivate(keyval rune, modifier cdk.ModMask) (activated
 // Returns:
 // 	TRUE if a mnemonic or accelerator was found and activated.
 func (w *CWindow) ActivateKey(event cdk.EventKey) (value bool) {
-	return false
+	if key, mods := accelEventKey(&event); key != 0 {
+		if r, ok := accelKeyRune(key); ok && w.MnemonicActivate(r, mods&^cdk.ModShift) {
+			return true
+		}
+	}
+	if w.getWithinGrab() != nil {
+		return false
+	}
+	return w.activateAccelGroups(&event)
 }
 
 // Propagate a key press or release event to the focus widget and up the
@@ -634,7 +775,7 @@ func (w *CWindow) GetFocus() (focus interface{}) {
 	if w.focused != nil {
 		return w.focused
 	}
-	fc, _ := w.GetFocusChain()
+	fc := w.getGrabFocusChain()
 	if len(fc) > 0 {
 		return fc[0]
 	}
@@ -652,6 +793,14 @@ func (w *CWindow) GetFocus() (focus interface{}) {
 func (w *CWindow) SetFocus(focus interface{}) {
 	if fw, ok := focus.(Sensitive); ok && fw.CanFocus() && fw.IsVisible() {
 		w.focused = focus
+		if widget, ok := focus.(Widget); ok {
+			// each ancestor tracks the child leading to the focus
+			child := widget
+			for parent := widget.GetParent(); parent != nil && parent.ObjectID() != child.ObjectID(); parent = parent.GetParent() {
+				parent.SetFocusChild(child)
+				child = parent
+			}
+		}
 	} else {
 		w.focused = nil
 	}
@@ -663,7 +812,7 @@ func (w *CWindow) SetFocus(focus interface{}) {
 // 	the default widget, or NULL if there is none.
 // 	[transfer none]
 func (w *CWindow) GetDefaultWidget() (value Widget) {
-	return nil
+	return w.defaultWidget
 }
 
 // The default widget is the widget that's activated when the user presses
@@ -676,7 +825,34 @@ func (w *CWindow) GetDefaultWidget() (value Widget) {
 // Parameters:
 // 	defaultWidget	widget to be the default, or NULL to unset the
 // default widget for the toplevel.
-func (w *CWindow) SetDefault(defaultWidget Widget) {}
+//
+// The HAS_DEFAULT flag and has-default property of the previous and the new
+// default widget are updated and both are restyled, for the :default
+// pseudo-class.
+func (w *CWindow) SetDefault(defaultWidget Widget) {
+	if defaultWidget != nil && !defaultWidget.HasFlags(CAN_DEFAULT) {
+		w.LogError("widget cannot be the default: %v", defaultWidget.ObjectName())
+		return
+	}
+	if previous := w.defaultWidget; previous != nil {
+		if defaultWidget != nil && previous.ObjectID() == defaultWidget.ObjectID() {
+			return
+		}
+		previous.UnsetFlags(HAS_DEFAULT)
+		if err := previous.SetBoolProperty(PropertyHasDefault, false); err != nil {
+			previous.LogErr(err)
+		}
+		previous.InvalidateStyle()
+	}
+	w.defaultWidget = defaultWidget
+	if defaultWidget != nil {
+		defaultWidget.SetFlags(HAS_DEFAULT)
+		if err := defaultWidget.SetBoolProperty(PropertyHasDefault, true); err != nil {
+			defaultWidget.LogErr(err)
+		}
+		defaultWidget.InvalidateStyle()
+	}
+}
 
 // Presents a window to the user. This may mean raising the window in the
 // stacking order, deiconifying it, moving it to the current desktop, and/or
@@ -1228,9 +1404,17 @@ func (w *CWindow) HasGroup() (value bool) {
 // Gets the type of the window. See WindowType.
 // Returns:
 // 	the type of the window
-// func (w *CWindow) GetWindowType() (value WindowType) {
-// 	return nil
-// }
+func (w *CWindow) GetWindowType() (value WindowType) {
+	if v, err := w.GetStructProperty(PropertyType); err != nil {
+		w.LogErr(err)
+	} else {
+		var ok bool
+		if value, ok = v.(WindowType); !ok && v != nil {
+			w.LogError("value stored in %v is not of WindowType: %v (%T)", PropertyType, v, v)
+		}
+	}
+	return
+}
 
 // Asks the window manager to move window to the given position. Window
 // managers are free to ignore this; most window managers ignore requests for
@@ -1367,7 +1551,11 @@ func (w *CWindow) GetMnemonicsVisible() (value bool) {
 	return
 }
 
-// Sets the mnemonics-visible property.
+// Sets the mnemonics-visible property, which is set by default. When unset,
+// the mnemonic underlines of the labels within the window are only drawn while
+// the mnemonic modifier is held. As terminals only report modifiers along with
+// another key, the underlines are drawn once a key pressed with the mnemonic
+// modifier activates no mnemonic or accelerator.
 // Parameters:
 // 	setting	the new value
 func (w *CWindow) SetMnemonicsVisible(setting bool) {
@@ -1376,6 +1564,13 @@ func (w *CWindow) SetMnemonicsVisible(setting bool) {
 	}
 }
 
+// Returns TRUE if the mnemonic underlines of the labels within the window are
+// to be drawn, which is when the mnemonics-visible property is set or while
+// the mnemonic modifier is held. See SetMnemonicModifier.
+func (w *CWindow) GetMnemonicsShown() (shown bool) {
+	return w.mnemonicsHeld || w.GetMnemonicsVisible()
+}
+
 func (w *CWindow) GetDisplayManager() (dm cdk.DisplayManager) {
 	if w.displayManager == nil {
 		w.displayManager = cdk.GetDisplayManager()
@@ -1405,7 +1600,7 @@ func (w *CWindow) GetVBox() (vbox VBox) {
 }
 
 func (w *CWindow) GetNextFocus() (next interface{}) {
-	fc, _ := w.GetFocusChain()
+	fc := w.getGrabFocusChain()
 	if focused := w.GetFocus(); focused != nil {
 		if wFocused, ok := focused.(Widget); ok {
 			found := false
@@ -1432,7 +1627,7 @@ func (w *CWindow) GetNextFocus() (next interface{}) {
 }
 
 func (w *CWindow) GetPreviousFocus() (previous interface{}) {
-	fc, _ := w.CBin.GetFocusChain()
+	fc := w.getGrabFocusChain()
 	nfc := len(fc)
 	if focused := w.GetFocus(); focused != nil {
 		if wFocused, ok := focused.(Widget); ok {
@@ -1460,15 +1655,24 @@ func (w *CWindow) GetPreviousFocus() (previous interface{}) {
 	return
 }
 
+// Moves the focus to the next widget of the focus chain, wrapping around to
+// the first one unless the keynav-failed signal is handled at the end of the
+// chain, see WidgetKeynavFailed.
 func (w *CWindow) FocusNext() cdk.EventFlag {
 	focused := w.GetFocus()
 	if focused != nil {
+		if fw, ok := focused.(Widget); ok && w.isFocusChainEnd(fw, true) && fw.KeynavFailed(DIR_TAB_FORWARD) {
+			return cdk.EVENT_STOP
+		}
 		if next := w.GetNextFocus(); next != nil {
 			if fw, ok := focused.(Widget); ok {
 				fw.Emit(SignalLostFocus, w)
 			}
-			if nw, ok := next.(Sensitive); ok {
-				nw.GrabFocus()
+			// handlers of the focus signal may take the focus themselves
+			if nw, ok := next.(Widget); !ok || nw.Emit(SignalFocus, nw, DIR_TAB_FORWARD) != cdk.EVENT_STOP {
+				if sw, ok := next.(Sensitive); ok {
+					sw.GrabFocus()
+				}
 			}
 			return cdk.EVENT_STOP
 		}
@@ -1477,15 +1681,24 @@ func (w *CWindow) FocusNext() cdk.EventFlag {
 	return cdk.EVENT_PASS
 }
 
+// Moves the focus to the previous widget of the focus chain, wrapping around
+// to the last one unless the keynav-failed signal is handled at the start of
+// the chain, see WidgetKeynavFailed.
 func (w *CWindow) FocusPrevious() cdk.EventFlag {
 	focused := w.GetFocus()
 	if focused != nil {
+		if fw, ok := focused.(Widget); ok && w.isFocusChainEnd(fw, false) && fw.KeynavFailed(DIR_TAB_BACKWARD) {
+			return cdk.EVENT_STOP
+		}
 		if prev := w.GetPreviousFocus(); prev != nil {
 			if fw, ok := focused.(Widget); ok {
 				fw.Emit(SignalLostFocus, w)
 			}
-			if pw, ok := prev.(Sensitive); ok {
-				pw.GrabFocus()
+			// handlers of the focus signal may take the focus themselves
+			if pw, ok := prev.(Widget); !ok || pw.Emit(SignalFocus, pw, DIR_TAB_BACKWARD) != cdk.EVENT_STOP {
+				if sw, ok := prev.(Sensitive); ok {
+					sw.GrabFocus()
+				}
 			}
 			return cdk.EVENT_STOP
 		}
@@ -1494,6 +1707,188 @@ func (w *CWindow) FocusPrevious() cdk.EventFlag {
 	return cdk.EVENT_PASS
 }
 
+// returns TRUE if the focused widget is the last of the focus chain, or the
+// first one if not forward
+func (w *CWindow) isFocusChainEnd(focused Widget, forward bool) bool {
+	fc := w.getGrabFocusChain()
+	if len(fc) == 0 {
+		return false
+	}
+	end := fc[0]
+	if forward {
+		end = fc[len(fc)-1]
+	}
+	ew, ok := end.(Widget)
+	return ok && ew.ObjectID() == focused.ObjectID()
+}
+
+// Moves the focus within the window in the given direction. The tab
+// directions follow the focus chain, see FocusNext and FocusPrevious. The
+// arrow directions move the focus to the nearest widget in that direction,
+// preferring widgets lined up with the focused one. When there is no widget
+// in that direction, the keynav-failed signal is emitted and unless handled,
+// the focus wraps around to the far side of the window. See
+// WidgetKeynavFailed and SettingsSetKeynavWrapAround.
+// Parameters:
+// 	direction	direction of focus movement
+// Returns:
+// 	TRUE if focus ended up inside the window
+func (w *CWindow) ChildFocus(direction DirectionType) (value bool) {
+	switch direction {
+	case DIR_TAB_FORWARD:
+		return w.FocusNext() == cdk.EVENT_STOP
+	case DIR_TAB_BACKWARD:
+		return w.FocusPrevious() == cdk.EVENT_STOP
+	}
+	focused, ok := w.GetFocus().(Widget)
+	if !ok {
+		return false
+	}
+	if w.focused == nil {
+		// nothing has the focus yet, start with the first of the focus chain
+		focused.GrabFocus()
+		return true
+	}
+	fc := w.getGrabFocusChain()
+	next := focusInDirection(focused, fc, direction, false)
+	if next == nil {
+		if focused.KeynavFailed(direction) {
+			return true
+		}
+		if next = focusInDirection(focused, fc, direction, true); next == nil {
+			return false
+		}
+	}
+	next.GrabFocus()
+	return true
+}
+
+// activate the key bindings of the focused widget and each of its ancestors,
+// up to a grab widget within the window, followed by those of the window
+// itself, see BindingSet
+func (w *CWindow) activateBindings(e *cdk.EventKey) bool {
+	grab := w.getWithinGrab()
+	if fw, ok := w.GetFocus().(Widget); ok {
+		for widget := fw; widget != nil && widget.ObjectID() != w.ObjectID(); {
+			if BindingsActivateEvent(widget, e) {
+				return true
+			}
+			if grab != nil && widget.ObjectID() == grab.ObjectID() {
+				break
+			}
+			parent := widget.GetParent()
+			if parent == nil || parent.ObjectID() == widget.ObjectID() {
+				break
+			}
+			widget = parent
+		}
+	}
+	return BindingsActivateEvent(w, e)
+}
+
+// redirects key and mouse events to the current grab widget, see GrabAdd.
+// Hidden grab widgets are skipped. Events for a grab widget within the window
+// are processed by the window, limited to the grab widget, see getWithinGrab.
+// Mouse events outside of the grab widget are discarded and a click outside
+// of a popup window dismisses the popup. Returns TRUE if the event was
+// handled by the grab, along with the resulting event flag.
+func (w *CWindow) processGrabEvent(evt cdk.Event) (grabbed bool, f cdk.EventFlag) {
+	grab := grabGetVisible()
+	if grab == nil || grab.ObjectID() == w.ObjectID() {
+		return false, cdk.EVENT_PASS
+	}
+	_, isWindow := grab.(Window)
+	within := !isWindow && grab.IsAncestor(w)
+	switch e := evt.(type) {
+	case *cdk.EventKey:
+		if within {
+			if fw, ok := w.GetFocus().(Widget); ok && (fw.ObjectID() == grab.ObjectID() || fw.IsAncestor(grab)) {
+				return false, cdk.EVENT_PASS
+			}
+		}
+		return true, grab.ProcessEvent(evt)
+	case *cdk.EventMouse:
+		if grab.HasPoint(cdk.NewPoint2I(e.Position())) {
+			if within {
+				return false, cdk.EVENT_PASS
+			}
+			return true, grab.ProcessEvent(evt)
+		}
+		switch e.State() {
+		case cdk.BUTTON_PRESS, cdk.DRAG_START:
+			dismissGrabPopup(grab)
+		}
+		return true, cdk.EVENT_STOP
+	}
+	return false, cdk.EVENT_PASS
+}
+
+// returns the current grab widget when it is a widget within the window, or
+// nil otherwise. While such a grab is held, mnemonics, the focus chain, the
+// default widget and key bindings are limited to the grab widget and its
+// descendants, and the accelerators of the window are not activated.
+func (w *CWindow) getWithinGrab() Widget {
+	grab := grabGetVisible()
+	if grab == nil || grab.ObjectID() == w.ObjectID() {
+		return nil
+	}
+	if _, isWindow := grab.(Window); isWindow || !grab.IsAncestor(w) {
+		return nil
+	}
+	return grab
+}
+
+// returns the focus chain of the window, limited to the grab widget within
+// the window, see getWithinGrab
+func (w *CWindow) getGrabFocusChain() (fc []interface{}) {
+	chain, _ := w.GetFocusChain()
+	grab := w.getWithinGrab()
+	if grab == nil {
+		return chain
+	}
+	for _, fci := range chain {
+		if fcw, ok := fci.(Widget); ok && grabContains(grab, fcw) {
+			fc = append(fc, fci)
+		}
+	}
+	return
+}
+
+// activates the default widget when Enter was not consumed by the focused
+// widget, see ActivateDefault
+func (w *CWindow) handleActivateDefault(data []interface{}, argv ...interface{}) cdk.EventFlag {
+	if w.ActivateDefault() {
+		return cdk.EVENT_STOP
+	}
+	return cdk.EVENT_PASS
+}
+
+// moves the focus along the focus chain, or in the direction of the arrow
+// keys, for the "move-focus" key bindings
+func (w *CWindow) handleMoveFocus(data []interface{}, argv ...interface{}) cdk.EventFlag {
+	if len(argv) < 2 {
+		return cdk.EVENT_PASS
+	}
+	direction, ok := argv[1].(DirectionType)
+	if !ok {
+		return cdk.EVENT_PASS
+	}
+	switch direction {
+	case DIR_TAB_FORWARD:
+		w.LogDebug("tab key focus next: %v", w.GetNextFocus())
+		w.FocusNext()
+		return cdk.EVENT_STOP
+	case DIR_TAB_BACKWARD:
+		w.LogDebug("tab key focus previous: %v", w.GetPreviousFocus())
+		w.FocusPrevious()
+		return cdk.EVENT_STOP
+	}
+	if w.ChildFocus(direction) {
+		return cdk.EVENT_STOP
+	}
+	return cdk.EVENT_PASS
+}
+
 func (w *CWindow) GetEventFocus() (o interface{}) {
 	if dm := w.GetDisplayManager(); dm != nil {
 		o = dm.GetEventFocus()
@@ -1512,18 +1907,31 @@ func (w *CWindow) SetEventFocus(o interface{}) {
 }
 
 func (w *CWindow) ProcessEvent(evt cdk.Event) cdk.EventFlag {
+	runMainCalls()
 	w.Lock()
 	defer w.Unlock()
+	if grabbed, f := w.processGrabEvent(evt); grabbed {
+		return f
+	}
 	switch e := evt.(type) {
 	case *cdk.EventError:
 		if f := w.Emit(SignalError, w, e); f == cdk.EVENT_PASS {
 			w.LogError(e.Error())
 		}
 	case *cdk.EventKey:
+		// terminals only report the modifiers along with another key, so the
+		// mnemonic modifier is considered held from the first key with it which
+		// activates nothing, until a key arrives without it
+		_, mods := accelEventKey(e)
+		held := w.mnemonicMod != cdk.ModNone && mods&w.mnemonicMod == w.mnemonicMod
+		if !held {
+			w.mnemonicsHeld = false
+		}
 		if f := w.Emit(SignalEventKey, w, e); f == cdk.EVENT_PASS {
-			if w.MnemonicActivate(e.Rune(), e.Modifiers()) {
+			if w.ActivateKey(*e) {
 				return cdk.EVENT_STOP
 			}
+			w.mnemonicsHeld = held
 			// check focused
 			if fi := w.GetFocus(); fi != nil {
 				if sw, ok := fi.(Sensitive); ok && sw.IsSensitive() && sw.IsVisible() {
@@ -1533,32 +1941,16 @@ func (w *CWindow) ProcessEvent(evt cdk.Event) cdk.EventFlag {
 					}
 				}
 			}
-			// check focus change
-			switch e.Key() {
-			case cdk.KeyBacktab:
-				w.LogDebug("shift+tab key caught")
-				if e.Modifiers().Has(cdk.ModShift) {
-					w.LogDebug("tab key focus next: %v", w.GetNextFocus())
-					w.FocusNext()
-				} else {
-					w.LogDebug("tab key focus previous: %v", w.GetNextFocus())
-					w.FocusPrevious()
-				}
-				return cdk.EVENT_STOP
-			case cdk.KeyTab:
-				if e.Modifiers().Has(cdk.ModShift) {
-					w.LogDebug("tab key focus previous: %v", w.GetNextFocus())
-					w.FocusPrevious()
-				} else {
-					w.LogDebug("tab key focus next: %v", w.GetNextFocus())
-					w.FocusNext()
-				}
+			// check key bindings, including focus change
+			if w.activateBindings(e) {
 				return cdk.EVENT_STOP
 			}
 		}
 	case *cdk.EventMouse:
+		w.mnemonicsHeld = false
 		// need to track enter/leave widget states
 		if f := w.Emit(SignalEventMouse, w, e); f == cdk.EVENT_PASS {
+			hoverChanged := false
 			if mw := w.GetWidgetAt(cdk.NewPoint2I(e.Position())); mw != nil {
 				if w.hoverFocus != nil {
 					if w.hoverFocus.ObjectID() != mw.ObjectID() {
@@ -1566,18 +1958,31 @@ func (w *CWindow) ProcessEvent(evt cdk.Event) cdk.EventFlag {
 						w.hoverFocus.LogDebug("signal leave")
 						mw.Emit(SignalEnter)
 						mw.LogDebug("signal enter")
+						hoverChanged = w.updateHoverState(w.hoverFocus, mw)
 						w.hoverFocus = mw
 					}
 				} else {
+					hoverChanged = w.updateHoverState(nil, mw)
 					w.hoverFocus = mw
 				}
 				if ms, ok := mw.(Sensitive); ok && ms.IsSensitive() && ms.IsVisible() {
-					return ms.ProcessEvent(e)
+					if f := ms.ProcessEvent(e); f == cdk.EVENT_STOP {
+						return f
+					}
+				}
+				if hoverChanged {
+					// have the display draw the new prelight state
+					return cdk.EVENT_STOP
 				}
 			}
 		}
 	case *cdk.EventResize:
-		alloc := cdk.MakeRectangle(w.GetDisplayManager().Display().Size())
+		display := w.GetDisplayManager().Display()
+		alloc := cdk.MakeRectangle(display.Size())
+		media := GetStyleMedia()
+		media.Width, media.Height, media.Colors = alloc.W, alloc.H, display.Colors()
+		SetStyleMedia(media)
+		initStyleTheme()
 		origin := cdk.MakePoint2I(0, 0)
 		w.SetAllocation(alloc)
 		w.SetOrigin(origin.X, origin.Y)
@@ -1592,6 +1997,103 @@ func (w *CWindow) ProcessEvent(evt cdk.Event) cdk.EventFlag {
 	return w.Emit(SignalCdkEvent, w, evt)
 }
 
+// Registers the given CssProvider for all Widgets within the Window, at the
+// given priority. Registering a provider again changes the priority. See
+// AddProviderForDisplay for registering a provider with all Windows.
+func (w *CWindow) AddStyleProvider(provider CssProvider, priority StyleProviderPriority) {
+	addStyleSheet(provider.GetStyleSheet(), priority, w.ObjectID())
+}
+
+// Unregisters a CssProvider added with AddStyleProvider.
+func (w *CWindow) RemoveStyleProvider(provider CssProvider) {
+	removeStyleSheet(provider.GetStyleSheet(), w.ObjectID())
+}
+
+// Loads the given CSS source as the application style of the Window,
+// replacing any style previously loaded with LoadStyleSheetFromString or
+// LoadStyleSheetFromFile. The style is applied at
+// STYLE_PROVIDER_PRIORITY_APPLICATION.
+func (w *CWindow) LoadStyleSheetFromString(source string) (err error) {
+	if err = w.getStyleProvider().LoadFromString(source); err == nil {
+		w.AddStyleProvider(w.styleProvider, STYLE_PROVIDER_PRIORITY_APPLICATION)
+	}
+	return
+}
+
+// Loads the CSS file at the given path as the application style of the
+// Window. See LoadStyleSheetFromString.
+func (w *CWindow) LoadStyleSheetFromFile(path string) (err error) {
+	if err = w.getStyleProvider().LoadFromFile(path); err == nil {
+		w.AddStyleProvider(w.styleProvider, STYLE_PROVIDER_PRIORITY_APPLICATION)
+	}
+	return
+}
+
+func (w *CWindow) getStyleProvider() *CCssProvider {
+	if w.styleProvider == nil {
+		w.styleProvider = NewCssProvider()
+	}
+	return w.styleProvider
+}
+
+// release the style loaded with LoadStyleSheetFromString or
+// LoadStyleSheetFromFile when the Window is destroyed
+func (w *CWindow) handleDestroyStyle(_ []interface{}, _ ...interface{}) cdk.EventFlag {
+	if w.styleProvider != nil {
+		w.RemoveStyleProvider(w.styleProvider)
+		w.styleProvider.Destroy()
+		w.styleProvider = nil
+	}
+	return cdk.EVENT_PASS
+}
+
+// move the prelight state from the previously hovered Widget (and ancestors)
+// to the newly hovered Widget (and ancestors). Only the previously and newly
+// hovered Widgets are invalidated, the ancestors which changed state restyle
+// the next time they are drawn. Returns TRUE if any Widget changed state.
+func (w *CWindow) updateHoverState(previous, next Widget) (changed bool) {
+	chain := func(widget Widget) (widgets map[int]Widget) {
+		widgets = make(map[int]Widget)
+		for widget != nil && widget.ObjectID() != w.ObjectID() {
+			widgets[widget.ObjectID()] = widget
+			parent := widget.GetParent()
+			if parent == nil || parent.ObjectID() == widget.ObjectID() {
+				break
+			}
+			widget = parent
+		}
+		return
+	}
+	hovered := func(widget Widget) bool {
+		return (previous != nil && widget.ObjectID() == previous.ObjectID()) ||
+			(next != nil && widget.ObjectID() == next.ObjectID())
+	}
+	update := func(widget Widget, prelight bool) {
+		if hovered(widget) {
+			if prelight {
+				widget.SetState(StatePrelight)
+			} else {
+				widget.UnsetState(StatePrelight)
+			}
+		} else if pw, ok := widget.(interface{ setPrelight(bool) }); ok {
+			pw.setPrelight(prelight)
+		}
+		changed = true
+	}
+	before, after := chain(previous), chain(next)
+	for id, widget := range before {
+		if _, ok := after[id]; !ok {
+			update(widget, false)
+		}
+	}
+	for id, widget := range after {
+		if _, ok := before[id]; !ok {
+			update(widget, true)
+		}
+	}
+	return
+}
+
 func (w *CWindow) GetThemeRequest() (theme cdk.Theme) {
 	// CWindow implements both cdk.Window and ctk.Widget, both have differing
 	// implementations of GetThemeRequest(), this method coerces the request
@@ -1616,6 +2118,7 @@ func (w *CWindow) Invalidate() cdk.EventFlag {
 }
 
 func (w *CWindow) Draw(canvas cdk.Canvas) cdk.EventFlag {
+	runMainCalls()
 	w.Lock()
 	defer w.Unlock()
 	size := canvas.GetSize()
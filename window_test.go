@@ -0,0 +1,294 @@
+package ctk
+
+import (
+	"testing"
+
+	"github.com/kckrinke/go-cdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWindow(t *testing.T) {
+	Convey("Testing Windows", t, func() {
+		Convey("directional focus navigation", func() {
+			window := NewWindowWithTitle("focus")
+			vbox := window.GetVBox()
+			top, bottom := NewHBox(true, 0), NewHBox(true, 0)
+			a, b := NewButtonWithLabel("a"), NewButtonWithLabel("b")
+			c, d := NewButtonWithLabel("c"), NewButtonWithLabel("d")
+			top.PackStart(a, true, true, 0)
+			top.PackStart(b, true, true, 0)
+			bottom.PackStart(c, true, true, 0)
+			bottom.PackStart(d, true, true, 0)
+			vbox.PackStart(top, true, true, 0)
+			vbox.PackStart(bottom, true, true, 0)
+			window.ShowAll()
+			window.SetAllocation(cdk.MakeRectangle(40, 10))
+			window.Resize()
+			key := func(k cdk.Key) cdk.EventFlag {
+				return window.ProcessEvent(cdk.NewEventKey(k, 0, cdk.ModNone))
+			}
+			a.GrabFocus()
+			So(key(cdk.KeyRight), ShouldEqual, cdk.EVENT_STOP)
+			So(b.IsFocus(), ShouldBeTrue)
+			key(cdk.KeyDown)
+			So(d.IsFocus(), ShouldBeTrue)
+			key(cdk.KeyLeft)
+			So(c.IsFocus(), ShouldBeTrue)
+			key(cdk.KeyUp)
+			So(a.IsFocus(), ShouldBeTrue)
+			// wrapping around to the far side
+			key(cdk.KeyLeft)
+			So(b.IsFocus(), ShouldBeTrue)
+			settings := GetDefaultSettings()
+			settings.SetKeynavWrapAround(false)
+			key(cdk.KeyRight)
+			So(b.IsFocus(), ShouldBeTrue)
+			settings.SetKeynavWrapAround(true)
+			// keynav-failed bubbles up to the ancestors
+			var failed []interface{}
+			top.Connect(SignalKeynavFailed, "window-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				if direction, _ := argv[1].(DirectionType); direction != DIR_RIGHT {
+					return cdk.EVENT_PASS
+				}
+				failed = argv[1:]
+				return cdk.EVENT_STOP
+			})
+			So(key(cdk.KeyRight), ShouldEqual, cdk.EVENT_STOP)
+			So(b.IsFocus(), ShouldBeTrue)
+			So(failed, ShouldResemble, []interface{}{DIR_RIGHT})
+			// explicitly set focus chains
+			vbox.SetFocusChain([]interface{}{d, NewButtonWithLabel("unpacked"), a})
+			fc, explicit := vbox.GetFocusChain()
+			So(explicit, ShouldBeTrue)
+			So(fc, ShouldHaveLength, 2)
+			d.GrabFocus()
+			key(cdk.KeyTab)
+			So(a.IsFocus(), ShouldBeTrue)
+			key(cdk.KeyTab)
+			So(d.IsFocus(), ShouldBeTrue)
+			vbox.UnsetFocusChain()
+			fc, explicit = vbox.GetFocusChain()
+			So(explicit, ShouldBeFalse)
+			So(fc, ShouldHaveLength, 4)
+		})
+		Convey("scrolling to the focus", func() {
+			window := NewWindowWithTitle("scrolling")
+			viewport := NewScrolledViewport()
+			list := NewVBox(false, 0)
+			list.SetSizeRequest(-1, 20)
+			var buttons []*CButton
+			for i := 0; i < 20; i++ {
+				button := NewButtonWithLabel("item")
+				list.PackStart(button, false, false, 0)
+				buttons = append(buttons, button)
+			}
+			viewport.Add(list)
+			So(list.GetFocusVAdjustment(), ShouldEqual, viewport.GetVAdjustment())
+			window.GetVBox().PackStart(viewport, true, true, 0)
+			window.ShowAll()
+			window.SetAllocation(cdk.MakeRectangle(20, 7))
+			window.Resize()
+			vadjustment := viewport.GetVAdjustment()
+			So(vadjustment.GetValue(), ShouldEqual, 0)
+			buttons[19].GrabFocus()
+			So(list.GetFocusChild(), ShouldEqual, buttons[19])
+			So(vadjustment.GetValue(), ShouldBeGreaterThan, 0)
+			// the arrow keys move the focus within the viewport before scrolling
+			So(window.ProcessEvent(cdk.NewEventKey(cdk.KeyUp, 0, cdk.ModNone)), ShouldEqual, cdk.EVENT_STOP)
+			So(buttons[18].IsFocus(), ShouldBeTrue)
+			buttons[0].GrabFocus()
+			So(vadjustment.GetValue(), ShouldEqual, 0)
+			viewport.Remove(list)
+			So(list.GetFocusVAdjustment(), ShouldBeNil)
+		})
+		Convey("default widget", func() {
+			window := NewWindowWithTitle("default")
+			vbox := window.GetVBox()
+			other, ok := NewButtonWithLabel("other"), NewButtonWithLabel("ok")
+			vbox.PackStart(other, false, false, 0)
+			vbox.PackStart(ok, false, false, 0)
+			window.ShowAll()
+			activated := 0
+			ok.Connect(SignalActivate, "window-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				activated++
+				return cdk.EVENT_STOP
+			})
+			sheet := NewStyleSheet()
+			So(sheet.ParseString(`button:default { underline: true; }`), ShouldBeNil)
+			AddStyleSheet(sheet, STYLE_PROVIDER_PRIORITY_APPLICATION)
+			defer RemoveStyleSheet(sheet)
+			So(ok.GetComputedStyle().Get(PropertyUnderline), ShouldBeNil)
+			ok.GrabDefault()
+			So(window.GetDefaultWidget(), ShouldEqual, ok)
+			So(ok.HasDefault(), ShouldBeTrue)
+			So(ok.GetComputedStyle().Get(PropertyUnderline).Value, ShouldEqual, "true")
+			So(other.GetComputedStyle().Get(PropertyUnderline), ShouldBeNil)
+			enter := func() cdk.EventFlag {
+				return window.ProcessEvent(cdk.NewEventKey(cdk.KeyEnter, 0, cdk.ModNone))
+			}
+			So(enter(), ShouldEqual, cdk.EVENT_STOP)
+			So(activated, ShouldEqual, 1)
+			// the focused button consumes enter itself
+			other.GrabFocus()
+			So(enter(), ShouldEqual, cdk.EVENT_STOP)
+			So(activated, ShouldEqual, 1)
+			// unless it does not receive the default
+			So(window.ActivateDefault(), ShouldBeTrue)
+			So(activated, ShouldEqual, 1)
+			other.SetReceivesDefault(false)
+			So(window.ActivateDefault(), ShouldBeTrue)
+			So(activated, ShouldEqual, 2)
+			ok.SetSensitive(false)
+			So(window.ActivateDefault(), ShouldBeFalse)
+			ok.SetSensitive(true)
+			// widgets which cannot be the default
+			ok.SetCanDefault(false)
+			So(window.GetDefaultWidget(), ShouldBeNil)
+			So(ok.HasDefault(), ShouldBeFalse)
+			So(ok.GetComputedStyle().Get(PropertyUnderline), ShouldBeNil)
+			label := NewLabel("label")
+			window.SetDefault(label)
+			So(window.GetDefaultWidget(), ShouldBeNil)
+			So(window.ActivateDefault(), ShouldBeFalse)
+			// grabbing the default before being packed into the window
+			later := NewButtonWithLabel("later")
+			later.GrabDefault()
+			So(later.HasDefault(), ShouldBeTrue)
+			vbox.PackStart(later, false, false, 0)
+			So(window.GetDefaultWidget(), ShouldEqual, later)
+			vbox.Remove(later)
+			So(window.GetDefaultWidget(), ShouldBeNil)
+			So(later.HasDefault(), ShouldBeFalse)
+			// a claim is refused while the window has another default
+			ok.SetCanDefault(true)
+			ok.GrabDefault()
+			refused := NewButtonWithLabel("refused")
+			refused.GrabDefault()
+			vbox.PackStart(refused, false, false, 0)
+			So(window.GetDefaultWidget(), ShouldEqual, ok)
+			So(refused.HasDefault(), ShouldBeFalse)
+			// builder properties set the flags
+			built := NewButtonWithLabel("built")
+			element := &CBuilderElement{Builder: NewBuilder(), Instance: built}
+			So(element.ApplyProperty("receives-default", "False"), ShouldBeTrue)
+			So(built.GetReceivesDefault(), ShouldBeFalse)
+			So(element.ApplyProperty("receives-default", "True"), ShouldBeTrue)
+			So(built.GetReceivesDefault(), ShouldBeTrue)
+			So(element.ApplyProperty("can-default", "False"), ShouldBeTrue)
+			So(built.GetCanDefault(), ShouldBeFalse)
+			So(element.ApplyProperty("can-default", "True"), ShouldBeTrue)
+			So(built.GetCanDefault(), ShouldBeTrue)
+		})
+		Convey("dialog default response", func() {
+			dialog := NewDialogWithButtons("default", nil, DialogModal, "_Yes", ResponseYes, "_No", ResponseNo)
+			dialog.SetDefaultResponse(ResponseNo)
+			So(dialog.GetDefaultWidget(), ShouldEqual, dialog.GetWidgetForResponse(ResponseNo))
+			So(dialog.ProcessEvent(cdk.NewEventKey(cdk.KeyEnter, 0, cdk.ModNone)), ShouldEqual, cdk.EVENT_STOP)
+			So(dialog.response, ShouldEqual, ResponseNo)
+		})
+		Convey("modal grabs", func() {
+			window := NewWindowWithTitle("main")
+			button := NewButtonWithLabel("button")
+			window.GetVBox().PackStart(button, false, false, 0)
+			window.ShowAll()
+			button.GrabFocus()
+			activated := 0
+			button.Connect(SignalActivate, "window-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				activated++
+				return cdk.EVENT_STOP
+			})
+			enter := cdk.NewEventKey(cdk.KeyEnter, 0, cdk.ModNone)
+			So(window.ProcessEvent(enter), ShouldEqual, cdk.EVENT_STOP)
+			So(activated, ShouldEqual, 1)
+			// a modal dialog receives all input once shown
+			dialog := NewDialogWithButtons("modal", window, DialogModal, "_Ok", ResponseOk)
+			So(dialog.GetModal(), ShouldBeTrue)
+			So(dialog.HasGrab(), ShouldBeFalse)
+			var notified []interface{}
+			dialog.Connect(SignalGrabNotify, "window-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				notified = append(notified, argv[1])
+				return cdk.EVENT_PASS
+			})
+			dialog.ShowAll()
+			So(GrabGetCurrent(), ShouldEqual, dialog)
+			So(dialog.HasGrab(), ShouldBeTrue)
+			window.ProcessEvent(enter)
+			So(activated, ShouldEqual, 1)
+			// nested popups are restored in reverse order
+			popup := NewWindow()
+			So(popup.SetStructProperty(PropertyType, WindowPopup), ShouldBeNil)
+			So(popup.GetWindowType(), ShouldEqual, WindowPopup)
+			popup.Show()
+			So(popup.HasGrab(), ShouldBeFalse)
+			GrabAdd(popup)
+			So(GrabGetCurrent(), ShouldEqual, popup)
+			So(notified, ShouldResemble, []interface{}{false})
+			So(dismissGrabPopup(dialog), ShouldBeFalse)
+			So(dismissGrabPopup(popup), ShouldBeTrue)
+			So(popup.IsVisible(), ShouldBeFalse)
+			So(GrabGetCurrent(), ShouldEqual, dialog)
+			So(notified, ShouldResemble, []interface{}{false, true})
+			// popups may refuse to be dismissed
+			popup.Show()
+			GrabAdd(popup)
+			popup.Connect(SignalGrabBrokenEvent, "window-test", func(data []interface{}, argv ...interface{}) cdk.EventFlag {
+				return cdk.EVENT_STOP
+			})
+			So(dismissGrabPopup(popup), ShouldBeFalse)
+			So(popup.IsVisible(), ShouldBeTrue)
+			popup.Hide()
+			So(popup.HasGrab(), ShouldBeFalse)
+			So(GrabGetCurrent(), ShouldEqual, dialog)
+			// hiding the dialog releases the grab
+			dialog.Hide()
+			So(GrabGetCurrent(), ShouldBeNil)
+			window.ProcessEvent(enter)
+			So(activated, ShouldEqual, 2)
+			// making a visible window modal grabs the input
+			other := NewWindowWithTitle("other")
+			other.Show()
+			other.SetModal(true)
+			So(other.HasGrab(), ShouldBeTrue)
+			other.SetModal(false)
+			So(GrabGetCurrent(), ShouldBeNil)
+		})
+		Convey("grabs within a window", func() {
+			window := NewWindowWithTitle("main")
+			outside := NewButtonWithMnemonic("_Outside")
+			inner := NewHBox(false, 0)
+			first, second := NewButtonWithLabel("first"), NewButtonWithLabel("second")
+			inner.PackStart(first, false, false, 0)
+			inner.PackStart(second, false, false, 0)
+			window.GetVBox().PackStart(outside, false, false, 0)
+			window.GetVBox().PackStart(inner, false, false, 0)
+			window.ShowAll()
+			outside.GrabDefault()
+			first.GrabFocus()
+			GrabAdd(inner)
+			defer GrabRemove(inner)
+			So(window.getWithinGrab(), ShouldEqual, inner)
+			// the focus chain is limited to the grab widget
+			tab := cdk.NewEventKey(cdk.KeyTab, 0, cdk.ModNone)
+			So(window.ProcessEvent(tab), ShouldEqual, cdk.EVENT_STOP)
+			So(window.GetFocus(), ShouldEqual, second)
+			So(window.ProcessEvent(tab), ShouldEqual, cdk.EVENT_STOP)
+			So(window.GetFocus(), ShouldEqual, first)
+			// and so are mnemonics and the default widget
+			So(window.MnemonicActivate('o', window.GetMnemonicModifier()), ShouldBeFalse)
+			first.SetReceivesDefault(false)
+			So(window.ActivateDefault(), ShouldBeFalse)
+			// hidden grab widgets are skipped
+			hidden := NewButtonWithLabel("hidden")
+			GrabAdd(hidden)
+			So(GrabGetCurrent(), ShouldEqual, hidden)
+			So(window.getWithinGrab(), ShouldEqual, inner)
+			// destroying or hiding a widget releases its grab
+			hidden.Destroy()
+			So(GrabGetCurrent(), ShouldEqual, inner)
+			inner.Hide()
+			So(GrabGetCurrent(), ShouldBeNil)
+			So(window.getWithinGrab(), ShouldBeNil)
+			So(window.ActivateDefault(), ShouldBeTrue)
+		})
+	})
+}